@@ -0,0 +1,9 @@
+// Package api embeds the OpenAPI specification implemented by the server.
+package api
+
+import _ "embed"
+
+// OpenAPI is the contents of openapi.yaml.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
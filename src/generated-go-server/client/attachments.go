@@ -0,0 +1,104 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// UploadTenderAttachment attaches the contents of r to a tender under the file name name.
+func (c *Client) UploadTenderAttachment(ctx context.Context, tenderID, username, name string, r io.Reader) (*openapi.Attachment, error) {
+	return c.uploadAttachment(ctx, tenderPath(tenderID), username, name, r)
+}
+
+// TenderAttachments lists the current attachments of a tender, or those of an earlier version
+// when version is not 0. username may be empty for a published tender.
+func (c *Client) TenderAttachments(ctx context.Context, tenderID, username string, version int32) ([]openapi.Attachment, error) {
+	return c.listAttachments(ctx, tenderPath(tenderID), username, version)
+}
+
+// DownloadTenderAttachment writes the contents of a tender attachment to w.
+func (c *Client) DownloadTenderAttachment(ctx context.Context, w io.Writer, tenderID, attachmentID, username string) error {
+	return c.download(ctx, tenderPath(tenderID)+"/attachments/"+url.PathEscape(attachmentID), optionalUsername(username), w)
+}
+
+// DeleteTenderAttachment removes an attachment from the current version of a tender.
+func (c *Client) DeleteTenderAttachment(ctx context.Context, tenderID, attachmentID, username string) error {
+	return c.do(ctx, http.MethodDelete, tenderPath(tenderID)+"/attachments/"+url.PathEscape(attachmentID), usernameQuery(username), nil, nil)
+}
+
+// UploadBidAttachment attaches the contents of r to a bid under the file name name.
+func (c *Client) UploadBidAttachment(ctx context.Context, bidID, username, name string, r io.Reader) (*openapi.Attachment, error) {
+	return c.uploadAttachment(ctx, bidPath(bidID), username, name, r)
+}
+
+// BidAttachments lists the current attachments of a bid, or those of an earlier version
+// when version is not 0.
+func (c *Client) BidAttachments(ctx context.Context, bidID, username string, version int32) ([]openapi.Attachment, error) {
+	return c.listAttachments(ctx, bidPath(bidID), username, version)
+}
+
+// DownloadBidAttachment writes the contents of a bid attachment to w.
+func (c *Client) DownloadBidAttachment(ctx context.Context, w io.Writer, bidID, attachmentID, username string) error {
+	return c.download(ctx, bidPath(bidID)+"/attachments/"+url.PathEscape(attachmentID), usernameQuery(username), w)
+}
+
+// DeleteBidAttachment removes an attachment from the current version of a bid.
+func (c *Client) DeleteBidAttachment(ctx context.Context, bidID, attachmentID, username string) error {
+	return c.do(ctx, http.MethodDelete, bidPath(bidID)+"/attachments/"+url.PathEscape(attachmentID), usernameQuery(username), nil, nil)
+}
+
+func (c *Client) uploadAttachment(ctx context.Context, path, username, name string, r io.Reader) (*openapi.Attachment, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", name)
+	if err == nil {
+		_, err = io.Copy(part, r)
+	}
+	if err == nil {
+		err = mw.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("client: encode request: %w", err)
+	}
+
+	var out openapi.Attachment
+	err = c.roundTrip(ctx, http.MethodPost, path+"/attachments", usernameQuery(username), body.Bytes(), mw.FormDataContentType(), "application/json", func(resp *http.Response) error {
+		return c.handle(resp, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) listAttachments(ctx context.Context, path, username string, version int32) ([]openapi.Attachment, error) {
+	q := optionalUsername(username)
+	if version > 0 {
+		q.Set("version", strconv.Itoa(int(version)))
+	}
+
+	var attachments []openapi.Attachment
+	if err := c.do(ctx, http.MethodGet, path+"/attachments", q, nil, &attachments); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func tenderPath(tenderID string) string { return "/tenders/" + url.PathEscape(tenderID) }
+
+func bidPath(bidID string) string { return "/bids/" + url.PathEscape(bidID) }
+
+func optionalUsername(username string) url.Values {
+	if username == "" {
+		return url.Values{}
+	}
+	return usernameQuery(username)
+}
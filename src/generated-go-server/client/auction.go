@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// TenderAuction returns the state of the reverse auction of a tender. username may be empty
+// for a published tender.
+func (c *Client) TenderAuction(ctx context.Context, tenderID, username string) (*openapi.Auction, error) {
+	var auction openapi.Auction
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/auction", optionalUsername(username), nil, &auction); err != nil {
+		return nil, err
+	}
+	return &auction, nil
+}
+
+// CreateTenderAuction schedules a reverse auction on a Delivery tender without lots.
+func (c *Client) CreateTenderAuction(ctx context.Context, tenderID, username string, req openapi.CreateAuctionRequest) (*openapi.Auction, error) {
+	var auction openapi.Auction
+	if err := c.do(ctx, http.MethodPost, tenderPath(tenderID)+"/auction", usernameQuery(username), req, &auction); err != nil {
+		return nil, err
+	}
+	return &auction, nil
+}
+
+// PlaceAuctionOffer offers a lower price for the bid of the user and returns the auction
+// after the offer.
+func (c *Client) PlaceAuctionOffer(ctx context.Context, tenderID, username, bidID string, price openapi.Money) (*openapi.Auction, error) {
+	var auction openapi.Auction
+	req := openapi.AuctionOfferRequest{BidId: bidID, Price: price}
+	if err := c.do(ctx, http.MethodPost, tenderPath(tenderID)+"/auction/offers", usernameQuery(username), req, &auction); err != nil {
+		return nil, err
+	}
+	return &auction, nil
+}
+
+// FollowTenderAuction calls fn with the state of the auction and then with every update
+// until the auction finishes, ctx is cancelled or fn returns an error. A dropped stream is
+// not resumed: calling FollowTenderAuction again starts with the current state.
+func (c *Client) FollowTenderAuction(ctx context.Context, tenderID, username string, fn func(openapi.Auction) error) error {
+	return c.roundTrip(ctx, http.MethodGet, tenderPath(tenderID)+"/auction/events", usernameQuery(username), nil, "", "text/event-stream", func(resp *http.Response) error {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return c.handle(resp, nil)
+		}
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				// Event names, heartbeats and the blank lines between events.
+				continue
+			}
+			var auction openapi.Auction
+			if err := json.Unmarshal([]byte(data), &auction); err != nil {
+				return fmt.Errorf("client: decode event: %w", err)
+			}
+			if err := fn(auction); err != nil {
+				return err
+			}
+			if auction.Status == openapi.AUCTION_FINISHED {
+				return nil
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("client: read response: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// CreateBid creates a bid for a tender.
+func (c *Client) CreateBid(ctx context.Context, req openapi.CreateBidRequest) (*openapi.Bid, error) {
+	var bid openapi.Bid
+	if err := c.do(ctx, http.MethodPost, "/bids/new", nil, req, &bid); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// GetUserBids lists the bids created by username.
+func (c *Client) GetUserBids(ctx context.Context, username string, page Page) ([]openapi.Bid, error) {
+	q := page.values()
+	q.Set("username", username)
+
+	var bids []openapi.Bid
+	if err := c.do(ctx, http.MethodGet, "/bids/my", q, nil, &bids); err != nil {
+		return nil, err
+	}
+	return bids, nil
+}
+
+// UserBids iterates over all bids created by username.
+func (c *Client) UserBids(ctx context.Context, username string, pageSize int32) *Iterator[openapi.Bid] {
+	return newIterator(ctx, pageSize, func(ctx context.Context, page Page) ([]openapi.Bid, error) {
+		return c.GetUserBids(ctx, username, page)
+	})
+}
+
+// GetBidsForTender lists the bids of a tender visible to username in the given order,
+// oldest first when sort is empty.
+func (c *Client) GetBidsForTender(ctx context.Context, tenderID, username string, sort openapi.BidSort, page Page) ([]openapi.Bid, error) {
+	q := page.values()
+	q.Set("username", username)
+	if sort != "" {
+		q.Set("sort", string(sort))
+	}
+
+	var bids []openapi.Bid
+	if err := c.do(ctx, http.MethodGet, "/bids/"+url.PathEscape(tenderID)+"/list", q, nil, &bids); err != nil {
+		return nil, err
+	}
+	return bids, nil
+}
+
+// BidsForTender iterates over all bids of a tender visible to username.
+func (c *Client) BidsForTender(ctx context.Context, tenderID, username string, sort openapi.BidSort, pageSize int32) *Iterator[openapi.Bid] {
+	return newIterator(ctx, pageSize, func(ctx context.Context, page Page) ([]openapi.Bid, error) {
+		return c.GetBidsForTender(ctx, tenderID, username, sort, page)
+	})
+}
+
+// GetBidStatus returns the current status of a bid.
+func (c *Client) GetBidStatus(ctx context.Context, bidID, username string) (openapi.BidStatus, error) {
+	var status openapi.BidStatus
+	err := c.do(ctx, http.MethodGet, "/bids/"+url.PathEscape(bidID)+"/status", usernameQuery(username), nil, &status)
+	return status, err
+}
+
+// UpdateBidStatus changes the status of a bid.
+func (c *Client) UpdateBidStatus(ctx context.Context, bidID string, status openapi.BidStatus, username string) (*openapi.Bid, error) {
+	q := usernameQuery(username)
+	q.Set("status", string(status))
+
+	var bid openapi.Bid
+	if err := c.do(ctx, http.MethodPut, "/bids/"+url.PathEscape(bidID)+"/status", q, nil, &bid); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// EditBid changes the parameters of a bid. Empty fields of req are left unchanged.
+func (c *Client) EditBid(ctx context.Context, bidID, username string, req openapi.EditBidRequest) (*openapi.Bid, error) {
+	var bid openapi.Bid
+	if err := c.do(ctx, http.MethodPatch, "/bids/"+url.PathEscape(bidID)+"/edit", usernameQuery(username), req, &bid); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// SubmitBidDecision approves or rejects a bid on behalf of a tender responsible. lotID names
+// the lot the decision is about; it may be empty for bids on a single lot or on a tender
+// without lots.
+func (c *Client) SubmitBidDecision(ctx context.Context, bidID string, decision openapi.BidDecision, lotID, username string) (*openapi.Bid, error) {
+	q := usernameQuery(username)
+	q.Set("decision", string(decision))
+	if lotID != "" {
+		q.Set("lotId", lotID)
+	}
+
+	var bid openapi.Bid
+	if err := c.do(ctx, http.MethodPut, "/bids/"+url.PathEscape(bidID)+"/submit_decision", q, nil, &bid); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// SubmitBidFeedback leaves a review on a bid.
+func (c *Client) SubmitBidFeedback(ctx context.Context, bidID, feedback, username string) (*openapi.Bid, error) {
+	q := usernameQuery(username)
+	q.Set("bidFeedback", feedback)
+
+	var bid openapi.Bid
+	if err := c.do(ctx, http.MethodPut, "/bids/"+url.PathEscape(bidID)+"/feedback", q, nil, &bid); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// RollbackBid restores the parameters of a previous version of a bid.
+func (c *Client) RollbackBid(ctx context.Context, bidID string, version int32, username string) (*openapi.Bid, error) {
+	path := "/bids/" + url.PathEscape(bidID) + "/rollback/" + strconv.Itoa(int(version))
+
+	var bid openapi.Bid
+	if err := c.do(ctx, http.MethodPut, path, usernameQuery(username), nil, &bid); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// GetBidReviews lists the reviews left on earlier bids of authorUsername, as seen by the
+// responsible requesterUsername of the tender.
+func (c *Client) GetBidReviews(ctx context.Context, tenderID, authorUsername, requesterUsername string, page Page) ([]openapi.BidReview, error) {
+	q := page.values()
+	q.Set("authorUsername", authorUsername)
+	q.Set("requesterUsername", requesterUsername)
+
+	var reviews []openapi.BidReview
+	if err := c.do(ctx, http.MethodGet, "/bids/"+url.PathEscape(tenderID)+"/reviews", q, nil, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// BidReviews iterates over all reviews returned by GetBidReviews.
+func (c *Client) BidReviews(ctx context.Context, tenderID, authorUsername, requesterUsername string, pageSize int32) *Iterator[openapi.BidReview] {
+	return newIterator(ctx, pageSize, func(ctx context.Context, page Page) ([]openapi.BidReview, error) {
+		return c.GetBidReviews(ctx, tenderID, authorUsername, requesterUsername, page)
+	})
+}
@@ -0,0 +1,334 @@
+// Package client is a typed Go client for the Tender Management API.
+//
+//	c, err := client.New("http://localhost:8080/api", client.WithRetry(client.DefaultRetryPolicy))
+//	tender, err := c.CreateTender(ctx, openapi.CreateTenderRequest{...})
+//
+// Every method takes a context, returns the model structs of the server package and
+// reports failed requests as *Error.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+const defaultUserAgent = "tender-client-go/1.0"
+
+// RetryPolicy controls how failed requests are retried. Only idempotent requests (GET, PUT and
+// requests with an idempotency key) are retried, after network errors and 429, 502, 503 and
+// 504 responses.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt. It doubles for every next one.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy makes up to three attempts with 100ms, 200ms delays.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+}
+
+// AuthFunc adds credentials to an outgoing request.
+type AuthFunc func(*http.Request) error
+
+// Client calls the tender API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	auth       AuthFunc
+	retry      RetryPolicy
+	userAgent  string
+	language   string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithAuth sets a function that adds credentials to every request.
+func WithAuth(auth AuthFunc) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithBearerToken sends the token in the Authorization header.
+func WithBearerToken(token string) Option {
+	return WithAuth(func(r *http.Request) error {
+		r.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// WithRetry enables retries. Without it every request is made once.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithUserAgent overrides the User-Agent header.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithLanguage sets Accept-Language, which selects the language of error messages.
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.language = lang
+	}
+}
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a context whose request carries key in the Idempotency-Key header.
+// CreateTender, CreateBid and SubmitBidDecision answer a repeated request with the response of
+// the first one, so a request with a key is retried even if it is a POST. Use a fresh key, for
+// example a UUID, for every call.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key
+}
+
+// New creates a client for the API at baseURL, e.g. "http://localhost:8080/api".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("client: base url %q must be absolute", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		retry:      RetryPolicy{MaxAttempts: 1},
+		userAgent:  defaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+
+	return c, nil
+}
+
+// do sends a request and decodes a successful JSON response into out, which may be nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("client: encode request: %w", err)
+		}
+	}
+
+	return c.roundTrip(ctx, method, path, query, payload, "application/json", "application/json", func(resp *http.Response) error {
+		return c.handle(resp, out)
+	})
+}
+
+// download sends a GET request and copies a successful response body to w as it arrives.
+// Failed attempts are retried only while nothing has been written to w.
+func (c *Client) download(ctx context.Context, path string, query url.Values, w io.Writer) error {
+	return c.roundTrip(ctx, http.MethodGet, path, query, nil, "", "*/*", func(resp *http.Response) error {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return c.handle(resp, nil)
+		}
+		defer resp.Body.Close()
+		if _, err := io.Copy(w, resp.Body); err != nil {
+			return fmt.Errorf("client: read response: %w", err)
+		}
+		return nil
+	})
+}
+
+// roundTrip sends the request, retrying idempotent methods according to the retry policy,
+// and passes every response to handle.
+func (c *Client) roundTrip(ctx context.Context, method, path string, query url.Values, payload []byte, contentType, accept string, handle func(*http.Response) error) error {
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	attempts := 1
+	if method == http.MethodGet || method == http.MethodPut || idempotencyKey(ctx) != "" {
+		attempts = c.retry.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff(attempt, lastErr)); err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.send(ctx, method, u.String(), payload, contentType, accept)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		lastErr = handle(resp)
+		if lastErr == nil || !retryable(lastErr) {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+func (c *Client) send(ctx context.Context, method, rawURL string, payload []byte, contentType, accept string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("client: build request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+	if key := idempotencyKey(ctx); key != "" {
+		req.Header.Set(openapi.IdempotencyKeyHeader, key)
+	}
+	if c.auth != nil {
+		if err := c.auth(req); err != nil {
+			return nil, fmt.Errorf("client: auth: %w", err)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client: %s %s: %w", method, rawURL, err)
+	}
+	return resp, nil
+}
+
+func (c *Client) handle(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("client: read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newError(resp, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("client: decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) backoff(attempt int, lastErr error) time.Duration {
+	if e, ok := lastErr.(*Error); ok && e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+
+	d := c.retry.InitialBackoff << (attempt - 1)
+	if c.retry.MaxBackoff > 0 && (d > c.retry.MaxBackoff || d <= 0) {
+		d = c.retry.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Up to 20% jitter so that clients failing together do not retry together.
+	return d - time.Duration(rand.Int63n(int64(d)/5+1))
+}
+
+func retryable(err error) bool {
+	e, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Page selects a slice of a list. Zero values use the server defaults.
+type Page struct {
+	Limit  int32
+	Offset int32
+}
+
+func (p Page) values() url.Values {
+	q := url.Values{}
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(int(p.Limit)))
+	}
+	if p.Offset > 0 {
+		q.Set("offset", strconv.Itoa(int(p.Offset)))
+	}
+	return q
+}
+
+func usernameQuery(username string) url.Values {
+	q := url.Values{}
+	q.Set("username", username)
+	return q
+}
@@ -0,0 +1,412 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GIT_USER_ID/GIT_REPO_ID/api"
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+const (
+	tenderID = "550e8400-e29b-41d4-a716-446655440000"
+	bidID    = "61a485f0-e29b-41d4-a716-446655440000"
+	orgID    = "7d1c6f5e-5c1a-4f6e-9a0b-2f2b0c3d4e5f"
+)
+
+// fakeService implements the operations exercised by the tests; the others panic
+// through the nil embedded interface.
+type fakeService struct {
+	openapi.DefaultAPIServicer
+
+	tenders      []openapi.Tender
+	serviceTypes []openapi.TenderServiceType
+	requests     int
+	created      openapi.CreateTenderRequest
+}
+
+func (f *fakeService) CheckServer(ctx context.Context) (openapi.ImplResponse, error) {
+	return openapi.Response(http.StatusOK, "ok"), nil
+}
+
+func (f *fakeService) CreateTender(ctx context.Context, req openapi.CreateTenderRequest) (openapi.ImplResponse, error) {
+	f.created = req
+	return openapi.Response(http.StatusOK, openapi.Tender{
+		Id:             tenderID,
+		Name:           req.Name,
+		Description:    req.Description,
+		Status:         openapi.CREATED,
+		ServiceType:    req.ServiceType,
+		OrganizationId: req.OrganizationId,
+		Version:        1,
+		CreatedAt:      "2024-09-01T10:00:00Z",
+	}), nil
+}
+
+func (f *fakeService) GetTenders(ctx context.Context, limit, offset int32, serviceType []openapi.TenderServiceType) (openapi.ImplResponse, error) {
+	f.serviceTypes = serviceType
+	f.requests++
+	end := min(int(offset+limit), len(f.tenders))
+	start := min(int(offset), end)
+	return openapi.Response(http.StatusOK, f.tenders[start:end]), nil
+}
+
+func (f *fakeService) GetTenderStatus(ctx context.Context, id, username string) (openapi.ImplResponse, error) {
+	if username != "test_user" {
+		return openapi.Response(http.StatusUnauthorized, nil), openapi.NewAPIError(openapi.ErrCodeUserNotFound, openapi.ErrNoUser)
+	}
+	return openapi.Response(http.StatusNotFound, nil), openapi.NewAPIError(openapi.ErrCodeTenderNotFound, openapi.ErrNotFound)
+}
+
+func (f *fakeService) SubmitBidDecision(ctx context.Context, id string, decision openapi.BidDecision, username string, lotId string) (openapi.ImplResponse, error) {
+	var lotIds []string
+	if lotId != "" {
+		lotIds = []string{lotId}
+	}
+	return openapi.Response(http.StatusOK, openapi.Bid{
+		Id:         id,
+		LotIds:     lotIds,
+		Name:       "bid",
+		Status:     openapi.PUBLISHED_BID,
+		TenderId:   tenderID,
+		AuthorType: openapi.USER,
+		AuthorId:   orgID,
+		Version:    1,
+		CreatedAt:  "2024-09-01T10:00:00Z",
+	}), nil
+}
+
+// newTestServer runs the real router, including spec validation, on top of svc.
+func newTestServer(t *testing.T, svc openapi.DefaultAPIServicer, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+
+	router := openapi.NewRouter(openapi.NewDefaultAPIController(svc))
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	validator, err := openapi.NewSpecValidator(api.OpenAPI, openapi.ValidationConfig{Requests: true, Responses: true}, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router.Use(validator.Middleware)
+
+	var handler http.Handler = openapi.Localize(router, openapi.LocaleRU)
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...Option) *Client {
+	t.Helper()
+
+	c, err := New(srv.URL+"/api", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCreateTender(t *testing.T) {
+	svc := &fakeService{}
+	c := newTestClient(t, newTestServer(t, svc, nil))
+
+	req := openapi.CreateTenderRequest{
+		Name:            "Доставка",
+		Description:     "Доставка оборудования",
+		ServiceType:     openapi.DELIVERY,
+		OrganizationId:  orgID,
+		CreatorUsername: "test_user",
+	}
+	tender, err := c.CreateTender(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if svc.created != req {
+		t.Errorf("server got %+v, want %+v", svc.created, req)
+	}
+	if tender.Id != tenderID || tender.Name != req.Name || tender.Status != openapi.CREATED {
+		t.Errorf("unexpected tender %+v", tender)
+	}
+}
+
+func TestCheckServer(t *testing.T) {
+	c := newTestClient(t, newTestServer(t, &fakeService{}, nil))
+	if err := c.CheckServer(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubmitBidDecision(t *testing.T) {
+	c := newTestClient(t, newTestServer(t, &fakeService{}, nil))
+
+	bid, err := c.SubmitBidDecision(context.Background(), bidID, openapi.APPROVED, "", "test_user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bid.Id != bidID || bid.Status != openapi.PUBLISHED_BID || len(bid.LotIds) != 0 {
+		t.Errorf("unexpected bid %+v", bid)
+	}
+
+	bid, err = c.SubmitBidDecision(context.Background(), bidID, openapi.APPROVED, tenderID, "test_user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bid.LotIds) != 1 || bid.LotIds[0] != tenderID {
+		t.Errorf("lotId was not passed: %+v", bid)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	c := newTestClient(t, newTestServer(t, &fakeService{}, nil), WithLanguage("en"))
+	ctx := context.Background()
+
+	_, err := c.GetTenderStatus(ctx, tenderID, "test_user")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != openapi.ErrCodeTenderNotFound {
+		t.Errorf("got %d %s, want 404 TENDER_NOT_FOUND", apiErr.StatusCode, apiErr.Code)
+	}
+	if apiErr.Message != "Tender not found" {
+		t.Errorf("message = %q, want the English title", apiErr.Message)
+	}
+	if apiErr.RequestID == "" {
+		t.Error("request id is empty")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = false")
+	}
+
+	_, err = c.GetTenderStatus(ctx, tenderID, "nobody")
+	if !errors.Is(err, ErrUnauthorized) || ErrorCode(err) != openapi.ErrCodeUserNotFound {
+		t.Errorf("err = %v, want USER_NOT_FOUND", err)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	c := newTestClient(t, newTestServer(t, &fakeService{}, nil))
+
+	_, err := c.CreateTender(context.Background(), openapi.CreateTenderRequest{
+		Name:            "Доставка",
+		Description:     "Доставка оборудования",
+		ServiceType:     openapi.DELIVERY,
+		OrganizationId:  "not-a-uuid",
+		CreatorUsername: "test_user",
+	})
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != openapi.ErrCodeValidationFailed {
+		t.Fatalf("err = %v, want VALIDATION_FAILED", err)
+	}
+	if len(apiErr.Violations) != 1 || apiErr.Violations[0].Field != "organizationId" {
+		t.Errorf("violations = %+v, want one for organizationId", apiErr.Violations)
+	}
+	if !errors.Is(err, ErrBadRequest) {
+		t.Error("errors.Is(err, ErrBadRequest) = false")
+	}
+}
+
+func TestTendersIterator(t *testing.T) {
+	svc := &fakeService{}
+	for i := 0; i < 7; i++ {
+		svc.tenders = append(svc.tenders, openapi.Tender{
+			Id:          tenderID,
+			Name:        "tender",
+			Description: "d",
+			Status:      openapi.PUBLISHED,
+			ServiceType: openapi.CONSTRUCTION,
+			Version:     int32(i + 1),
+			CreatedAt:   "2024-09-01T10:00:00Z",
+		})
+	}
+	c := newTestClient(t, newTestServer(t, svc, nil))
+
+	tenders, err := c.Tenders(context.Background(), 3, openapi.CONSTRUCTION, openapi.DELIVERY).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tenders) != 7 {
+		t.Fatalf("got %d tenders, want 7", len(tenders))
+	}
+	for i, tender := range tenders {
+		if tender.Version != int32(i+1) {
+			t.Errorf("tender %d has version %d, pages are out of order", i, tender.Version)
+		}
+	}
+	if svc.requests != 3 {
+		t.Errorf("made %d requests, want 3", svc.requests)
+	}
+	if len(svc.serviceTypes) != 2 || svc.serviceTypes[1] != openapi.DELIVERY {
+		t.Errorf("server got service types %v", svc.serviceTypes)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls atomic.Int32
+	flaky := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	srv := newTestServer(t, &fakeService{}, flaky)
+	ctx := context.Background()
+
+	c := newTestClient(t, srv, WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+	if err := c.CheckServer(ctx); err != nil {
+		t.Fatalf("CheckServer after retries: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("made %d attempts, want 3", calls.Load())
+	}
+
+	calls.Store(0)
+	c = newTestClient(t, srv, WithRetry(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	if err := c.CheckServer(ctx); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("err = %v, want ErrUnavailable once attempts are exhausted", err)
+	}
+
+	calls.Store(0)
+	_, err := c.CreateTender(ctx, openapi.CreateTenderRequest{})
+	if err == nil || calls.Load() != 1 {
+		t.Errorf("POST was attempted %d times, want exactly once", calls.Load())
+	}
+}
+
+func TestIdempotencyKeyRetry(t *testing.T) {
+	var calls atomic.Int32
+	var keys sync.Map
+	flaky := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := calls.Add(1)
+			keys.Store(n, r.Header.Get(openapi.IdempotencyKeyHeader))
+			if n == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	c := newTestClient(t, newTestServer(t, &fakeService{}, flaky), WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	ctx := WithIdempotencyKey(context.Background(), "create-tender-1")
+	_, _ = c.CreateTender(ctx, openapi.CreateTenderRequest{})
+	if calls.Load() != 2 {
+		t.Fatalf("POST with a key was attempted %d times, want 2", calls.Load())
+	}
+	for n := int32(1); n <= 2; n++ {
+		if key, _ := keys.Load(n); key != "create-tender-1" {
+			t.Errorf("attempt %d sent key %q", n, key)
+		}
+	}
+}
+
+func TestAuthAndContext(t *testing.T) {
+	var auth atomic.Value
+	capture := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth.Store(r.Header.Get("Authorization"))
+			next.ServeHTTP(w, r)
+		})
+	}
+	c := newTestClient(t, newTestServer(t, &fakeService{}, capture), WithBearerToken("secret"))
+
+	if err := c.CheckServer(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := auth.Load(); got != "Bearer secret" {
+		t.Errorf("Authorization = %q", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.CheckServer(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+// fakeAuctions streams a running auction and whatever is then published to hub.
+type fakeAuctions struct {
+	openapi.AuctionAPIServicer
+
+	hub       *openapi.AuctionHub
+	following chan struct{}
+}
+
+func (f *fakeAuctions) FollowTenderAuction(ctx context.Context, id, username string) (openapi.ImplResponse, error) {
+	updates, stop := f.hub.Follow(id)
+	close(f.following)
+	return openapi.Response(http.StatusOK, &openapi.AuctionFeed{
+		Current: openapi.Auction{TenderId: id, Status: openapi.AUCTION_RUNNING},
+		Updates: updates,
+		Stop:    stop,
+	}), nil
+}
+
+func TestFollowTenderAuction(t *testing.T) {
+	svc := &fakeAuctions{hub: openapi.NewAuctionHub(), following: make(chan struct{})}
+	router := openapi.NewRouter(openapi.NewAuctionAPIController(svc))
+	validator, err := openapi.NewSpecValidator(api.OpenAPI, openapi.ValidationConfig{Requests: true, Responses: true}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router.Use(validator.Middleware)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	got := make(chan openapi.Auction)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.FollowTenderAuction(context.Background(), tenderID, "test_user", func(a openapi.Auction) error {
+			got <- a
+			return nil
+		})
+	}()
+
+	receive := func() openapi.Auction {
+		t.Helper()
+		select {
+		case a := <-got:
+			return a
+		case err := <-done:
+			t.Fatalf("stream ended early: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event")
+		}
+		return openapi.Auction{}
+	}
+
+	if a := receive(); a.TenderId != tenderID || a.Status != openapi.AUCTION_RUNNING {
+		t.Errorf("first event %+v, want the current state", a)
+	}
+	<-svc.following
+	best := openapi.Money{Currency: "RUB"}
+	svc.hub.Publish(openapi.Auction{TenderId: tenderID, Status: openapi.AUCTION_RUNNING, BestPrice: &best, OfferCount: 1})
+	if a := receive(); a.OfferCount != 1 || a.BestPrice == nil {
+		t.Errorf("offer event %+v", a)
+	}
+	svc.hub.Publish(openapi.Auction{TenderId: tenderID, Status: openapi.AUCTION_FINISHED, OfferCount: 1, WinningBidId: bidID})
+	if a := receive(); a.Status != openapi.AUCTION_FINISHED || a.WinningBidId != bidID {
+		t.Errorf("final event %+v", a)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("FollowTenderAuction: %v", err)
+	}
+}
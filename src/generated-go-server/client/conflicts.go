@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// TenderConflicts lists the conflicts of interest flagged on a tender of the user's organization.
+func (c *Client) TenderConflicts(ctx context.Context, tenderID, username string) ([]openapi.TenderConflict, error) {
+	var conflicts []openapi.TenderConflict
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/conflicts", usernameQuery(username), nil, &conflicts); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// SetTenderConflictPolicy sets how CreateBid and SubmitBidDecision treat conflicts of interest on the tender.
+func (c *Client) SetTenderConflictPolicy(ctx context.Context, tenderID, username string, policy openapi.ConflictPolicy) (*openapi.TenderConflictPolicy, error) {
+	var saved openapi.TenderConflictPolicy
+	req := openapi.TenderConflictPolicy{Policy: policy}
+	if err := c.do(ctx, http.MethodPut, tenderPath(tenderID)+"/conflicts/policy", usernameQuery(username), req, &saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// Sentinel errors matched by errors.Is against *Error by HTTP status.
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnavailable  = errors.New("service unavailable")
+)
+
+// Error is a non-2xx response of the API.
+type Error struct {
+	StatusCode int
+	// Code is the stable error code of the server, e.g. openapi.ErrCodeTenderNotFound.
+	Code openapi.ErrorCode
+	// Message is the localized title of the error.
+	Message string
+	// Detail explains this particular occurrence, if the server provided it.
+	Detail     string
+	RequestID  string
+	Violations []openapi.Violation
+	// RetryAfter is the delay requested by the server in the Retry-After header.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("tender api: %d", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + string(e.Code)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.RequestID != "" {
+		msg += " (request " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors of this package.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
+
+// ErrorCode returns the server error code of err, or an empty string if err is not an *Error.
+func ErrorCode(err error) openapi.ErrorCode {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+// newError builds an Error from a problem+json body, falling back to the legacy
+// {"reason": ...} body and finally to the status text.
+func newError(resp *http.Response, body []byte) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(openapi.RequestIDHeader),
+	}
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if secs, err := strconv.Atoi(s); err == nil && secs > 0 {
+			e.RetryAfter = time.Duration(secs) * time.Second
+		}
+	}
+
+	var p openapi.Problem
+	if err := json.Unmarshal(body, &p); err == nil && (p.Code != "" || p.Reason != "") {
+		e.Code = p.Code
+		e.Message = p.Title
+		if e.Message == "" {
+			e.Message = p.Reason
+		}
+		e.Detail = p.Detail
+		e.Violations = p.Violations
+		if p.RequestID != "" {
+			e.RequestID = p.RequestID
+		}
+		return e
+	}
+
+	e.Message = http.StatusText(resp.StatusCode)
+	return e
+}
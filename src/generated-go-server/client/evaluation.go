@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// TenderCriteria lists the evaluation criteria of a tender. username may be empty for a
+// published tender.
+func (c *Client) TenderCriteria(ctx context.Context, tenderID, username string) ([]openapi.EvaluationCriterion, error) {
+	var criteria []openapi.EvaluationCriterion
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/criteria", optionalUsername(username), nil, &criteria); err != nil {
+		return nil, err
+	}
+	return criteria, nil
+}
+
+// CreateTenderCriterion adds an evaluation criterion to a tender whose bids have not been
+// scored yet.
+func (c *Client) CreateTenderCriterion(ctx context.Context, tenderID, username string, req openapi.CreateCriterionRequest) (*openapi.EvaluationCriterion, error) {
+	var criterion openapi.EvaluationCriterion
+	if err := c.do(ctx, http.MethodPost, tenderPath(tenderID)+"/criteria", usernameQuery(username), req, &criterion); err != nil {
+		return nil, err
+	}
+	return &criterion, nil
+}
+
+// DeleteTenderCriterion removes an evaluation criterion from a tender whose bids have not
+// been scored yet.
+func (c *Client) DeleteTenderCriterion(ctx context.Context, tenderID, criterionID, username string) error {
+	return c.do(ctx, http.MethodDelete, tenderPath(tenderID)+"/criteria/"+url.PathEscape(criterionID), usernameQuery(username), nil, nil)
+}
+
+// SubmitBidScores scores a published bid on behalf of a tender responsible and returns all
+// scores the user has given to it.
+func (c *Client) SubmitBidScores(ctx context.Context, bidID, username string, scores ...openapi.BidScoreInput) ([]openapi.BidScore, error) {
+	var out []openapi.BidScore
+	req := openapi.SubmitBidScoresRequest{Scores: scores}
+	if err := c.do(ctx, http.MethodPut, bidPath(bidID)+"/scores", usernameQuery(username), req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TenderRanking returns the published bids of a tender ordered by their weighted score.
+func (c *Client) TenderRanking(ctx context.Context, tenderID, username string) (*openapi.TenderRanking, error) {
+	var ranking openapi.TenderRanking
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/ranking", usernameQuery(username), nil, &ranking); err != nil {
+		return nil, err
+	}
+	return &ranking, nil
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// ExportTenders writes the tenders matching the service types to w as a CSV or XLSX file.
+func (c *Client) ExportTenders(ctx context.Context, w io.Writer, format openapi.ExportFormat, serviceTypes ...openapi.TenderServiceType) error {
+	q := url.Values{}
+	q.Set("format", string(format))
+	for _, st := range serviceTypes {
+		q.Add("service_type", string(st))
+	}
+	return c.download(ctx, "/tenders/export", q, w)
+}
+
+// ExportBids writes the bids of a tender to w as a CSV or XLSX file on behalf of a tender responsible,
+// in the order of sort. An empty sort keeps the server default.
+func (c *Client) ExportBids(ctx context.Context, w io.Writer, tenderID, username string, format openapi.ExportFormat, sort openapi.BidSort) error {
+	q := usernameQuery(username)
+	q.Set("format", string(format))
+	if sort != "" {
+		q.Set("sort", string(sort))
+	}
+	return c.download(ctx, "/tenders/"+url.PathEscape(tenderID)+"/bids/export", q, w)
+}
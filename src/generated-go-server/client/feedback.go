@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// SubmitBidReview leaves feedback on a bid with a rating from 1 to 5 and optional category tags.
+func (c *Client) SubmitBidReview(ctx context.Context, bidID, username, feedback string, rating int32, tags ...openapi.FeedbackTag) (*openapi.Bid, error) {
+	q := usernameQuery(username)
+	q.Set("bidFeedback", feedback)
+	q.Set("rating", strconv.Itoa(int(rating)))
+	for _, tag := range tags {
+		q.Add("tags", string(tag))
+	}
+
+	var bid openapi.Bid
+	if err := c.do(ctx, http.MethodPut, bidPath(bidID)+"/feedback", q, nil, &bid); err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+// EditBidFeedback changes the user's current feedback on a bid; unset fields are kept.
+func (c *Client) EditBidFeedback(ctx context.Context, bidID, username string, req openapi.EditBidFeedbackRequest) (*openapi.BidFeedback, error) {
+	var feedback openapi.BidFeedback
+	if err := c.do(ctx, http.MethodPatch, bidPath(bidID)+"/feedback", usernameQuery(username), req, &feedback); err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// RetractBidFeedback retracts the user's current feedback on a bid.
+func (c *Client) RetractBidFeedback(ctx context.Context, bidID, username string) error {
+	return c.do(ctx, http.MethodDelete, bidPath(bidID)+"/feedback", usernameQuery(username), nil, nil)
+}
+
+// BidFeedbackHistory lists every version of the user's feedback on a bid, retracted ones included.
+func (c *Client) BidFeedbackHistory(ctx context.Context, bidID, username string) ([]openapi.BidFeedbackVersion, error) {
+	var versions []openapi.BidFeedbackVersion
+	if err := c.do(ctx, http.MethodGet, bidPath(bidID)+"/feedback/history", usernameQuery(username), nil, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// BidReviewSummary sums up the reviews returned by GetBidReviews: ratings and their
+// distribution, and the reviews per tag.
+func (c *Client) BidReviewSummary(ctx context.Context, tenderID, authorUsername, requesterUsername string) (*openapi.BidReviewSummary, error) {
+	q := url.Values{}
+	q.Set("authorUsername", authorUsername)
+	q.Set("requesterUsername", requesterUsername)
+
+	var summary openapi.BidReviewSummary
+	if err := c.do(ctx, http.MethodGet, "/bids/"+url.PathEscape(tenderID)+"/reviews/summary", q, nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
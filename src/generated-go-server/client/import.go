@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// ImportTenders uploads a CSV (text/csv) or NDJSON (application/x-ndjson) file of tenders.
+// With dryRun the file is only checked. Rows that fail the checks are reported in the
+// Violations of the returned *Error.
+func (c *Client) ImportTenders(ctx context.Context, r io.Reader, contentType string, dryRun bool) (*openapi.TenderImportResult, error) {
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("client: read import file: %w", err)
+	}
+
+	q := url.Values{}
+	if dryRun {
+		q.Set("dryRun", strconv.FormatBool(dryRun))
+	}
+
+	var out openapi.TenderImportResult
+	err = c.roundTrip(ctx, http.MethodPost, "/tenders/import", q, payload, contentType, "application/json", func(resp *http.Response) error {
+		return c.handle(resp, &out)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
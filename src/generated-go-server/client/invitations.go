@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// TenderInvitations lists the invitations to a private tender of the user's organization.
+func (c *Client) TenderInvitations(ctx context.Context, tenderID, username string) ([]openapi.Invitation, error) {
+	var invitations []openapi.Invitation
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/invitations", usernameQuery(username), nil, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// InviteToTender invites an organization or an employee to a private tender. Exactly one of
+// req.OrganizationId and req.Username is set.
+func (c *Client) InviteToTender(ctx context.Context, tenderID, username string, req openapi.CreateInvitationRequest) (*openapi.Invitation, error) {
+	var invitation openapi.Invitation
+	if err := c.do(ctx, http.MethodPost, tenderPath(tenderID)+"/invitations", usernameQuery(username), req, &invitation); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// TenderInvitationHistory returns every change of the invitation list of a tender in order.
+func (c *Client) TenderInvitationHistory(ctx context.Context, tenderID, username string) ([]openapi.InvitationChange, error) {
+	var changes []openapi.InvitationChange
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/invitations/history", usernameQuery(username), nil, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// RevokeTenderInvitation revokes an invitation to a tender of the user's organization.
+func (c *Client) RevokeTenderInvitation(ctx context.Context, tenderID, invitationID, username string) (*openapi.Invitation, error) {
+	var invitation openapi.Invitation
+	path := tenderPath(tenderID) + "/invitations/" + url.PathEscape(invitationID) + "/revoke"
+	if err := c.do(ctx, http.MethodPut, path, usernameQuery(username), nil, &invitation); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// MyInvitations lists the invitations addressed to the user or to the organizations the user
+// is responsible for.
+func (c *Client) MyInvitations(ctx context.Context, username string) ([]openapi.Invitation, error) {
+	var invitations []openapi.Invitation
+	if err := c.do(ctx, http.MethodGet, "/invitations/my", usernameQuery(username), nil, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation accepts an invitation; the addressee may then bid on the tender.
+func (c *Client) AcceptInvitation(ctx context.Context, invitationID, username string) (*openapi.Invitation, error) {
+	return c.respondInvitation(ctx, invitationID, username, "accept")
+}
+
+// DeclineInvitation declines an invitation.
+func (c *Client) DeclineInvitation(ctx context.Context, invitationID, username string) (*openapi.Invitation, error) {
+	return c.respondInvitation(ctx, invitationID, username, "decline")
+}
+
+func (c *Client) respondInvitation(ctx context.Context, invitationID, username, action string) (*openapi.Invitation, error) {
+	var invitation openapi.Invitation
+	path := "/invitations/" + url.PathEscape(invitationID) + "/" + action
+	if err := c.do(ctx, http.MethodPut, path, usernameQuery(username), nil, &invitation); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import "context"
+
+// defaultPageSize is the largest page the API allows.
+const defaultPageSize = 50
+
+// Iterator walks a paginated list page by page:
+//
+//	it := c.Tenders(ctx, 0)
+//	for it.Next() {
+//		tender := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    func(ctx context.Context, page Page) ([]T, error)
+	pageSize int32
+
+	page   []T
+	pos    int
+	offset int32
+	done   bool
+	err    error
+}
+
+func newIterator[T any](ctx context.Context, pageSize int32, fetch func(context.Context, Page) ([]T, error)) *Iterator[T] {
+	if pageSize <= 0 || pageSize > defaultPageSize {
+		pageSize = defaultPageSize
+	}
+	return &Iterator[T]{ctx: ctx, fetch: fetch, pageSize: pageSize}
+}
+
+// Next advances to the next item, fetching the next page when needed. It returns false
+// when the list is exhausted or a request failed.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos < len(it.page) {
+		it.pos++
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	page, err := it.fetch(it.ctx, Page{Limit: it.pageSize, Offset: it.offset})
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page, it.pos = page, 0
+	it.offset += int32(len(page))
+	// A short page is the last one.
+	it.done = int32(len(page)) < it.pageSize
+
+	if len(page) == 0 {
+		return false
+	}
+	it.pos = 1
+	return true
+}
+
+// Value returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Value() T {
+	return it.page[it.pos-1]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All drains the iterator into a slice.
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
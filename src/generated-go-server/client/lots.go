@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// TenderLots lists the lots of a tender in the order they were added. username may be empty
+// for a published tender.
+func (c *Client) TenderLots(ctx context.Context, tenderID, username string) ([]openapi.Lot, error) {
+	var lots []openapi.Lot
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/lots", optionalUsername(username), nil, &lots); err != nil {
+		return nil, err
+	}
+	return lots, nil
+}
+
+// CreateTenderLot adds a lot to a tender that has not been published yet.
+func (c *Client) CreateTenderLot(ctx context.Context, tenderID, username string, req openapi.CreateLotRequest) (*openapi.Lot, error) {
+	var lot openapi.Lot
+	if err := c.do(ctx, http.MethodPost, tenderPath(tenderID)+"/lots", usernameQuery(username), req, &lot); err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+// EditTenderLot changes an open lot. Empty fields of req are left unchanged.
+func (c *Client) EditTenderLot(ctx context.Context, tenderID, lotID, username string, req openapi.EditLotRequest) (*openapi.Lot, error) {
+	var lot openapi.Lot
+	if err := c.do(ctx, http.MethodPatch, lotPath(tenderID, lotID)+"/edit", usernameQuery(username), req, &lot); err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+// CancelTenderLot cancels an open lot. The tender is closed once none of its lots is open.
+func (c *Client) CancelTenderLot(ctx context.Context, tenderID, lotID, username string) (*openapi.Lot, error) {
+	var lot openapi.Lot
+	if err := c.do(ctx, http.MethodPut, lotPath(tenderID, lotID)+"/cancel", usernameQuery(username), nil, &lot); err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+func lotPath(tenderID, lotID string) string {
+	return tenderPath(tenderID) + "/lots/" + url.PathEscape(lotID)
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// Notifications returns a page of the inbox of username, newest first, with the unread count.
+func (c *Client) Notifications(ctx context.Context, username string, unreadOnly bool, page Page) (*openapi.NotificationInbox, error) {
+	q := page.values()
+	q.Set("username", username)
+	if unreadOnly {
+		q.Set("unreadOnly", "true")
+	}
+
+	var inbox openapi.NotificationInbox
+	if err := c.do(ctx, http.MethodGet, "/notifications", q, nil, &inbox); err != nil {
+		return nil, err
+	}
+	return &inbox, nil
+}
+
+// AllNotifications iterates over the whole inbox of username, newest first.
+func (c *Client) AllNotifications(ctx context.Context, username string, unreadOnly bool, pageSize int32) *Iterator[openapi.Notification] {
+	return newIterator(ctx, pageSize, func(ctx context.Context, page Page) ([]openapi.Notification, error) {
+		inbox, err := c.Notifications(ctx, username, unreadOnly, page)
+		if err != nil {
+			return nil, err
+		}
+		return inbox.Notifications, nil
+	})
+}
+
+// MarkNotificationRead marks one notification of username as read.
+func (c *Client) MarkNotificationRead(ctx context.Context, notificationID, username string) (*openapi.Notification, error) {
+	var notification openapi.Notification
+	path := "/notifications/" + url.PathEscape(notificationID) + "/read"
+	if err := c.do(ctx, http.MethodPut, path, usernameQuery(username), nil, &notification); err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of username as read and returns
+// how many there were.
+func (c *Client) MarkAllNotificationsRead(ctx context.Context, username string) (int32, error) {
+	var marked openapi.NotificationsMarked
+	if err := c.do(ctx, http.MethodPut, "/notifications/read_all", usernameQuery(username), nil, &marked); err != nil {
+		return 0, err
+	}
+	return marked.Marked, nil
+}
+
+// NotificationPreferences returns whether username receives each type of event.
+func (c *Client) NotificationPreferences(ctx context.Context, username string) ([]openapi.NotificationPreference, error) {
+	var preferences []openapi.NotificationPreference
+	if err := c.do(ctx, http.MethodGet, "/notifications/preferences", usernameQuery(username), nil, &preferences); err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+// SetNotificationPreferences turns the given event types on or off and returns the
+// preferences for every type.
+func (c *Client) SetNotificationPreferences(ctx context.Context, username string, preferences ...openapi.NotificationPreference) ([]openapi.NotificationPreference, error) {
+	req := openapi.UpdateNotificationPreferencesRequest{Preferences: preferences}
+	if req.Preferences == nil {
+		req.Preferences = []openapi.NotificationPreference{}
+	}
+	var updated []openapi.NotificationPreference
+	if err := c.do(ctx, http.MethodPut, "/notifications/preferences", usernameQuery(username), req, &updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// EmailSettings returns the address and language of the notification emails of username.
+func (c *Client) EmailSettings(ctx context.Context, username string) (*openapi.EmailSettings, error) {
+	var settings openapi.EmailSettings
+	if err := c.do(ctx, http.MethodGet, "/notifications/email", usernameQuery(username), nil, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SetEmailSettings sets the address and language of the notification emails of username and
+// turns them on or off.
+func (c *Client) SetEmailSettings(ctx context.Context, username string, req openapi.UpdateEmailSettingsRequest) (*openapi.EmailSettings, error) {
+	var settings openapi.EmailSettings
+	if err := c.do(ctx, http.MethodPut, "/notifications/email", usernameQuery(username), req, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// TenderQuestions lists the questions on a tender the user may see: all of them for the
+// responsibles, otherwise the own ones and those answered publicly. username may be empty
+// for a published tender.
+func (c *Client) TenderQuestions(ctx context.Context, tenderID, username string) ([]openapi.TenderQuestion, error) {
+	var questions []openapi.TenderQuestion
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/questions", optionalUsername(username), nil, &questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// AskTenderQuestion asks a clarification question about a published tender.
+func (c *Client) AskTenderQuestion(ctx context.Context, tenderID, username, question string) (*openapi.TenderQuestion, error) {
+	var asked openapi.TenderQuestion
+	req := openapi.AskQuestionRequest{Question: question}
+	if err := c.do(ctx, http.MethodPost, tenderPath(tenderID)+"/questions", usernameQuery(username), req, &asked); err != nil {
+		return nil, err
+	}
+	return &asked, nil
+}
+
+// AnswerTenderQuestion answers a question on a tender of the user's organization. A question
+// is answered once.
+func (c *Client) AnswerTenderQuestion(ctx context.Context, tenderID, questionID, username string, req openapi.AnswerQuestionRequest) (*openapi.TenderQuestion, error) {
+	var answered openapi.TenderQuestion
+	path := tenderPath(tenderID) + "/questions/" + url.PathEscape(questionID) + "/answer"
+	if err := c.do(ctx, http.MethodPut, path, usernameQuery(username), req, &answered); err != nil {
+		return nil, err
+	}
+	return &answered, nil
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// Reputation returns the reputation of a user or an organization as a bid author.
+func (c *Client) Reputation(ctx context.Context, authorType openapi.BidAuthorType, authorID, username string) (*openapi.Reputation, error) {
+	var reputation openapi.Reputation
+	path := "/reputation/" + url.PathEscape(string(authorType)) + "/" + url.PathEscape(authorID)
+	if err := c.do(ctx, http.MethodGet, path, usernameQuery(username), nil, &reputation); err != nil {
+		return nil, err
+	}
+	return &reputation, nil
+}
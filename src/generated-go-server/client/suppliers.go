@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// OrganizationSuppliers returns the supplier list of an organization the user is responsible for.
+func (c *Client) OrganizationSuppliers(ctx context.Context, organizationID, username string) (*openapi.SupplierList, error) {
+	var list openapi.SupplierList
+	if err := c.do(ctx, http.MethodGet, organizationPath(organizationID)+"/suppliers", usernameQuery(username), nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// SetOrganizationSupplier qualifies or blacklists a supplier, replacing its previous entry.
+func (c *Client) SetOrganizationSupplier(ctx context.Context, organizationID, username string, req openapi.SetSupplierRequest) (*openapi.SupplierEntry, error) {
+	var entry openapi.SupplierEntry
+	if err := c.do(ctx, http.MethodPut, organizationPath(organizationID)+"/suppliers", usernameQuery(username), req, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// SetOrganizationSupplierPolicy sets whether the organization only accepts bids from qualified suppliers.
+func (c *Client) SetOrganizationSupplierPolicy(ctx context.Context, organizationID, username string, requireQualification bool) (*openapi.SupplierPolicy, error) {
+	var policy openapi.SupplierPolicy
+	req := openapi.SupplierPolicy{RequireQualification: requireQualification}
+	if err := c.do(ctx, http.MethodPut, organizationPath(organizationID)+"/suppliers/policy", usernameQuery(username), req, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// DeleteOrganizationSupplier removes an entry from the supplier list of an organization.
+func (c *Client) DeleteOrganizationSupplier(ctx context.Context, organizationID, supplierID, username string) error {
+	return c.do(ctx, http.MethodDelete, organizationPath(organizationID)+"/suppliers/"+url.PathEscape(supplierID), usernameQuery(username), nil, nil)
+}
+
+// BidEligibility tells whether a bid by the user, or by organizationID when it is set, would be
+// accepted on the tender and why not otherwise.
+func (c *Client) BidEligibility(ctx context.Context, tenderID, username, organizationID string) (*openapi.BidEligibility, error) {
+	var eligibility openapi.BidEligibility
+	query := usernameQuery(username)
+	if organizationID != "" {
+		query.Set("organizationId", organizationID)
+	}
+	if err := c.do(ctx, http.MethodGet, tenderPath(tenderID)+"/eligibility", query, nil, &eligibility); err != nil {
+		return nil, err
+	}
+	return &eligibility, nil
+}
+
+func organizationPath(organizationID string) string {
+	return "/organizations/" + url.PathEscape(organizationID)
+}
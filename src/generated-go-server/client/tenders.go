@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// CheckServer returns nil when the server is ready to handle requests.
+func (c *Client) CheckServer(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/ping", nil, nil, nil)
+}
+
+// GetTenders lists published tenders, optionally filtered by service type.
+func (c *Client) GetTenders(ctx context.Context, page Page, serviceTypes ...openapi.TenderServiceType) ([]openapi.Tender, error) {
+	q := page.values()
+	for _, st := range serviceTypes {
+		q.Add("service_type", string(st))
+	}
+
+	var tenders []openapi.Tender
+	if err := c.do(ctx, http.MethodGet, "/tenders", q, nil, &tenders); err != nil {
+		return nil, err
+	}
+	return tenders, nil
+}
+
+// Tenders iterates over all tenders matching the service types. pageSize 0 uses the largest page.
+func (c *Client) Tenders(ctx context.Context, pageSize int32, serviceTypes ...openapi.TenderServiceType) *Iterator[openapi.Tender] {
+	return newIterator(ctx, pageSize, func(ctx context.Context, page Page) ([]openapi.Tender, error) {
+		return c.GetTenders(ctx, page, serviceTypes...)
+	})
+}
+
+// CreateTender creates a tender on behalf of req.CreatorUsername.
+func (c *Client) CreateTender(ctx context.Context, req openapi.CreateTenderRequest) (*openapi.Tender, error) {
+	var tender openapi.Tender
+	if err := c.do(ctx, http.MethodPost, "/tenders/new", nil, req, &tender); err != nil {
+		return nil, err
+	}
+	return &tender, nil
+}
+
+// GetUserTenders lists the tenders created by username.
+func (c *Client) GetUserTenders(ctx context.Context, username string, page Page) ([]openapi.Tender, error) {
+	q := page.values()
+	q.Set("username", username)
+
+	var tenders []openapi.Tender
+	if err := c.do(ctx, http.MethodGet, "/tenders/my", q, nil, &tenders); err != nil {
+		return nil, err
+	}
+	return tenders, nil
+}
+
+// UserTenders iterates over all tenders created by username.
+func (c *Client) UserTenders(ctx context.Context, username string, pageSize int32) *Iterator[openapi.Tender] {
+	return newIterator(ctx, pageSize, func(ctx context.Context, page Page) ([]openapi.Tender, error) {
+		return c.GetUserTenders(ctx, username, page)
+	})
+}
+
+// GetTenderStatus returns the current status of a tender.
+func (c *Client) GetTenderStatus(ctx context.Context, tenderID, username string) (openapi.TenderStatus, error) {
+	var status openapi.TenderStatus
+	err := c.do(ctx, http.MethodGet, "/tenders/"+url.PathEscape(tenderID)+"/status", usernameQuery(username), nil, &status)
+	return status, err
+}
+
+// UpdateTenderStatus changes the status of a tender.
+func (c *Client) UpdateTenderStatus(ctx context.Context, tenderID string, status openapi.TenderStatus, username string) (*openapi.Tender, error) {
+	q := usernameQuery(username)
+	q.Set("status", string(status))
+
+	var tender openapi.Tender
+	if err := c.do(ctx, http.MethodPut, "/tenders/"+url.PathEscape(tenderID)+"/status", q, nil, &tender); err != nil {
+		return nil, err
+	}
+	return &tender, nil
+}
+
+// EditTender changes the parameters of a tender. Empty fields of req are left unchanged.
+func (c *Client) EditTender(ctx context.Context, tenderID, username string, req openapi.EditTenderRequest) (*openapi.Tender, error) {
+	var tender openapi.Tender
+	if err := c.do(ctx, http.MethodPatch, "/tenders/"+url.PathEscape(tenderID)+"/edit", usernameQuery(username), req, &tender); err != nil {
+		return nil, err
+	}
+	return &tender, nil
+}
+
+// RollbackTender restores the parameters of a previous version of a tender as a new version.
+func (c *Client) RollbackTender(ctx context.Context, tenderID string, version int32, username string) (*openapi.Tender, error) {
+	path := "/tenders/" + url.PathEscape(tenderID) + "/rollback/" + strconv.Itoa(int(version))
+
+	var tender openapi.Tender
+	if err := c.do(ctx, http.MethodPut, path, usernameQuery(username), nil, &tender); err != nil {
+		return nil, err
+	}
+	return &tender, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GIT_USER_ID/GIT_REPO_ID/client"
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// backend runs the operations shared by both modes: *client.Client talks to a running
+// server, localBackend calls the services directly against the database.
+type backend interface {
+	GetTenders(ctx context.Context, page client.Page, serviceTypes ...openapi.TenderServiceType) ([]openapi.Tender, error)
+	GetUserTenders(ctx context.Context, username string, page client.Page) ([]openapi.Tender, error)
+	GetTenderStatus(ctx context.Context, tenderID, username string) (openapi.TenderStatus, error)
+	UpdateTenderStatus(ctx context.Context, tenderID string, status openapi.TenderStatus, username string) (*openapi.Tender, error)
+	RollbackTender(ctx context.Context, tenderID string, version int32, username string) (*openapi.Tender, error)
+
+	GetBidsForTender(ctx context.Context, tenderID, username string, sort openapi.BidSort, page client.Page) ([]openapi.Bid, error)
+	GetUserBids(ctx context.Context, username string, page client.Page) ([]openapi.Bid, error)
+	GetBidStatus(ctx context.Context, bidID, username string) (openapi.BidStatus, error)
+	UpdateBidStatus(ctx context.Context, bidID string, status openapi.BidStatus, username string) (*openapi.Bid, error)
+	RollbackBid(ctx context.Context, bidID string, version int32, username string) (*openapi.Bid, error)
+}
+
+var _ backend = (*client.Client)(nil)
+
+// localBackend adapts the HTTP-shaped results of the services to the client signatures.
+type localBackend struct {
+	svc *openapi.AdminService
+}
+
+var _ backend = localBackend{}
+
+// decode copies the body of a successful service response into out. The round trip
+// through JSON gives the same values the HTTP API would return.
+func decode(resp openapi.ImplResponse, err error, out any) error {
+	if err != nil {
+		return err
+	}
+	if resp.Code >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.Code)
+	}
+	body, err := json.Marshal(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (b localBackend) GetTenders(ctx context.Context, page client.Page, serviceTypes ...openapi.TenderServiceType) ([]openapi.Tender, error) {
+	var tenders []openapi.Tender
+	resp, err := b.svc.GetTenders(ctx, page.Limit, page.Offset, serviceTypes)
+	return tenders, decode(resp, err, &tenders)
+}
+
+func (b localBackend) GetUserTenders(ctx context.Context, username string, page client.Page) ([]openapi.Tender, error) {
+	var tenders []openapi.Tender
+	resp, err := b.svc.GetUserTenders(ctx, page.Limit, page.Offset, username)
+	return tenders, decode(resp, err, &tenders)
+}
+
+func (b localBackend) GetTenderStatus(ctx context.Context, tenderID, username string) (openapi.TenderStatus, error) {
+	var status openapi.TenderStatus
+	resp, err := b.svc.GetTenderStatus(ctx, tenderID, username)
+	return status, decode(resp, err, &status)
+}
+
+func (b localBackend) UpdateTenderStatus(ctx context.Context, tenderID string, status openapi.TenderStatus, username string) (*openapi.Tender, error) {
+	var tender openapi.Tender
+	resp, err := b.svc.UpdateTenderStatus(ctx, tenderID, status, username)
+	return &tender, decode(resp, err, &tender)
+}
+
+func (b localBackend) RollbackTender(ctx context.Context, tenderID string, version int32, username string) (*openapi.Tender, error) {
+	var tender openapi.Tender
+	resp, err := b.svc.RollbackTender(ctx, tenderID, version, username)
+	return &tender, decode(resp, err, &tender)
+}
+
+func (b localBackend) GetBidsForTender(ctx context.Context, tenderID, username string, sort openapi.BidSort, page client.Page) ([]openapi.Bid, error) {
+	var bids []openapi.Bid
+	resp, err := b.svc.GetBidsForTender(ctx, tenderID, username, page.Limit, page.Offset, sort)
+	return bids, decode(resp, err, &bids)
+}
+
+func (b localBackend) GetUserBids(ctx context.Context, username string, page client.Page) ([]openapi.Bid, error) {
+	var bids []openapi.Bid
+	resp, err := b.svc.GetUserBids(ctx, page.Limit, page.Offset, username)
+	return bids, decode(resp, err, &bids)
+}
+
+func (b localBackend) GetBidStatus(ctx context.Context, bidID, username string) (openapi.BidStatus, error) {
+	var status openapi.BidStatus
+	resp, err := b.svc.GetBidStatus(ctx, bidID, username)
+	return status, decode(resp, err, &status)
+}
+
+func (b localBackend) UpdateBidStatus(ctx context.Context, bidID string, status openapi.BidStatus, username string) (*openapi.Bid, error) {
+	var bid openapi.Bid
+	resp, err := b.svc.UpdateBidStatus(ctx, bidID, status, username)
+	return &bid, decode(resp, err, &bid)
+}
+
+func (b localBackend) RollbackBid(ctx context.Context, bidID string, version int32, username string) (*openapi.Bid, error) {
+	var bid openapi.Bid
+	resp, err := b.svc.RollbackBid(ctx, bidID, version, username)
+	return &bid, decode(resp, err, &bid)
+}
+
+// collect pages through a list until a short page is returned.
+func collect[T any](ctx context.Context, fetch func(context.Context, client.Page) ([]T, error)) ([]T, error) {
+	const pageSize = 50
+
+	var items []T
+	for offset := int32(0); ; offset += pageSize {
+		page, err := fetch(ctx, client.Page{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if len(page) < pageSize {
+			return items, nil
+		}
+	}
+}
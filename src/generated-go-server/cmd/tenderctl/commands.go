@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GIT_USER_ID/GIT_REPO_ID/client"
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+	"github.com/google/uuid"
+)
+
+var commands = map[string]map[string]command{
+	"tenders": {
+		"list":       {usage: "list published tenders", run: tendersList},
+		"my":         {usage: "list the tenders of an employee", run: tendersMy},
+		"status":     {usage: "show the status of a tender", run: tendersStatus},
+		"set-status": {usage: "change the status of a tender, -force skips the rights check", run: tendersSetStatus},
+		"rollback":   {usage: "restore a previous version of a tender", run: tendersRollback},
+	},
+	"bids": {
+		"list":       {usage: "list the bids of a tender", run: bidsList},
+		"my":         {usage: "list the bids of an employee", run: bidsMy},
+		"status":     {usage: "show the status of a bid", run: bidsStatus},
+		"set-status": {usage: "change the status of a bid", run: bidsSetStatus},
+		"rollback":   {usage: "restore a previous version of a bid", run: bidsRollback},
+	},
+	"organizations": {
+		"list":               {usage: "list organizations", run: organizationsList},
+		"responsibles":       {usage: "list the employees responsible for an organization", run: organizationsResponsibles},
+		"add-responsible":    {usage: "make an employee responsible for an organization", run: organizationsAddResponsible},
+		"remove-responsible": {usage: "revoke the responsibility of an employee", run: organizationsRemoveResponsible},
+	},
+	"employees": {
+		"list":   {usage: "list employees", run: employeesList},
+		"create": {usage: "register an employee", run: employeesCreate},
+	},
+	"migrations": {
+		"status": {usage: "show applied and pending schema migrations", run: migrationsStatus},
+		"up":     {usage: "apply pending schema migrations", run: migrationsUp},
+	},
+	"export": {
+		"tenders": {usage: "write all tenders as CSV or JSON", run: exportTenders},
+		"bids":    {usage: "write all bids of a tender as CSV or JSON", run: exportBids},
+	},
+}
+
+func tendersList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("tenders list")
+	limit := fs.Int("limit", 5, "maximum number of tenders")
+	offset := fs.Int("offset", 0, "number of tenders to skip")
+	var serviceTypes serviceTypeList
+	fs.Var(&serviceTypes, "service-type", "filter by service type, repeatable")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	tenders, err := a.backend.GetTenders(ctx, client.Page{Limit: int32(*limit), Offset: int32(*offset)}, serviceTypes...)
+	if err != nil {
+		return err
+	}
+	return a.out.print(tenders)
+}
+
+func tendersMy(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("tenders my")
+	username := fs.String("username", "", "employee username")
+	limit := fs.Int("limit", 5, "maximum number of tenders")
+	offset := fs.Int("offset", 0, "number of tenders to skip")
+	if err := parse(fs, args, "username"); err != nil {
+		return err
+	}
+
+	tenders, err := a.backend.GetUserTenders(ctx, *username, client.Page{Limit: int32(*limit), Offset: int32(*offset)})
+	if err != nil {
+		return err
+	}
+	return a.out.print(tenders)
+}
+
+func tendersStatus(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("tenders status")
+	id := fs.String("id", "", "tender id")
+	username := fs.String("username", "", "employee username")
+	if err := parse(fs, args, "id", "username"); err != nil {
+		return err
+	}
+
+	status, err := a.backend.GetTenderStatus(ctx, *id, *username)
+	if err != nil {
+		return err
+	}
+	return a.out.print(status)
+}
+
+func tendersSetStatus(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("tenders set-status")
+	id := fs.String("id", "", "tender id")
+	status := fs.String("status", "", "new status: Created, Published or Closed")
+	username := fs.String("username", "", "responsible employee, not needed with -force")
+	force := fs.Bool("force", false, "change the status without a responsible employee (database mode only)")
+	if err := parse(fs, args, "id", "status"); err != nil {
+		return err
+	}
+
+	if !*force {
+		if *username == "" {
+			return fmt.Errorf("flag -username is required without -force")
+		}
+		tender, err := a.backend.UpdateTenderStatus(ctx, *id, openapi.TenderStatus(*status), *username)
+		if err != nil {
+			return err
+		}
+		return a.out.print(tender)
+	}
+
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+	tenderID, err := uuid.Parse(*id)
+	if err != nil {
+		return fmt.Errorf("invalid tender id: %w", err)
+	}
+	tender, err := a.admin.ForceTenderStatus(ctx, tenderID, openapi.TenderStatus(*status))
+	if err != nil {
+		return err
+	}
+	return a.out.print(tender)
+}
+
+func tendersRollback(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("tenders rollback")
+	id := fs.String("id", "", "tender id")
+	version := fs.Int("version", 0, "version to restore")
+	username := fs.String("username", "", "responsible employee")
+	if err := parse(fs, args, "id", "version", "username"); err != nil {
+		return err
+	}
+
+	tender, err := a.backend.RollbackTender(ctx, *id, int32(*version), *username)
+	if err != nil {
+		return err
+	}
+	return a.out.print(tender)
+}
+
+func bidsList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("bids list")
+	tenderID := fs.String("tender", "", "tender id")
+	username := fs.String("username", "", "employee username")
+	limit := fs.Int("limit", 5, "maximum number of bids")
+	offset := fs.Int("offset", 0, "number of bids to skip")
+	sort := fs.String("sort", "", "createdAt, price or name, prefixed with - for descending order")
+	if err := parse(fs, args, "tender", "username"); err != nil {
+		return err
+	}
+
+	bids, err := a.backend.GetBidsForTender(ctx, *tenderID, *username, openapi.BidSort(*sort), client.Page{Limit: int32(*limit), Offset: int32(*offset)})
+	if err != nil {
+		return err
+	}
+	return a.out.print(bids)
+}
+
+func bidsMy(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("bids my")
+	username := fs.String("username", "", "employee username")
+	limit := fs.Int("limit", 5, "maximum number of bids")
+	offset := fs.Int("offset", 0, "number of bids to skip")
+	if err := parse(fs, args, "username"); err != nil {
+		return err
+	}
+
+	bids, err := a.backend.GetUserBids(ctx, *username, client.Page{Limit: int32(*limit), Offset: int32(*offset)})
+	if err != nil {
+		return err
+	}
+	return a.out.print(bids)
+}
+
+func bidsStatus(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("bids status")
+	id := fs.String("id", "", "bid id")
+	username := fs.String("username", "", "employee username")
+	if err := parse(fs, args, "id", "username"); err != nil {
+		return err
+	}
+
+	status, err := a.backend.GetBidStatus(ctx, *id, *username)
+	if err != nil {
+		return err
+	}
+	return a.out.print(status)
+}
+
+func bidsSetStatus(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("bids set-status")
+	id := fs.String("id", "", "bid id")
+	status := fs.String("status", "", "new status: Created, Published or Canceled")
+	username := fs.String("username", "", "bid author")
+	if err := parse(fs, args, "id", "status", "username"); err != nil {
+		return err
+	}
+
+	bid, err := a.backend.UpdateBidStatus(ctx, *id, openapi.BidStatus(*status), *username)
+	if err != nil {
+		return err
+	}
+	return a.out.print(bid)
+}
+
+func bidsRollback(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("bids rollback")
+	id := fs.String("id", "", "bid id")
+	version := fs.Int("version", 0, "version to restore")
+	username := fs.String("username", "", "bid author")
+	if err := parse(fs, args, "id", "version", "username"); err != nil {
+		return err
+	}
+
+	bid, err := a.backend.RollbackBid(ctx, *id, int32(*version), *username)
+	if err != nil {
+		return err
+	}
+	return a.out.print(bid)
+}
+
+func organizationsList(ctx context.Context, a *app, args []string) error {
+	if err := parse(a.newFlags("organizations list"), args); err != nil {
+		return err
+	}
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+
+	organizations, err := a.admin.ListOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+	return a.out.print(organizations)
+}
+
+func organizationsResponsibles(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("organizations responsibles")
+	id := fs.String("id", "", "organization id")
+	if err := parse(fs, args, "id"); err != nil {
+		return err
+	}
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+	orgID, err := uuid.Parse(*id)
+	if err != nil {
+		return fmt.Errorf("invalid organization id: %w", err)
+	}
+
+	users, err := a.admin.ListResponsibles(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	return a.out.print(users)
+}
+
+func organizationsAddResponsible(ctx context.Context, a *app, args []string) error {
+	return changeResponsible(ctx, a, "organizations add-responsible", args, (*openapi.AdminService).AddResponsible)
+}
+
+func organizationsRemoveResponsible(ctx context.Context, a *app, args []string) error {
+	return changeResponsible(ctx, a, "organizations remove-responsible", args, (*openapi.AdminService).RemoveResponsible)
+}
+
+func changeResponsible(ctx context.Context, a *app, name string, args []string,
+	change func(*openapi.AdminService, context.Context, uuid.UUID, string) error) error {
+	fs := a.newFlags(name)
+	id := fs.String("id", "", "organization id")
+	username := fs.String("username", "", "employee username")
+	if err := parse(fs, args, "id", "username"); err != nil {
+		return err
+	}
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+	orgID, err := uuid.Parse(*id)
+	if err != nil {
+		return fmt.Errorf("invalid organization id: %w", err)
+	}
+
+	if err := change(a.admin, ctx, orgID, *username); err != nil {
+		return err
+	}
+	users, err := a.admin.ListResponsibles(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	return a.out.print(users)
+}
+
+func employeesList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("employees list")
+	limit := fs.Int("limit", 50, "maximum number of employees")
+	offset := fs.Int("offset", 0, "number of employees to skip")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+
+	users, err := a.admin.ListEmployees(ctx, int32(*limit), int32(*offset))
+	if err != nil {
+		return err
+	}
+	return a.out.print(users)
+}
+
+func employeesCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("employees create")
+	username := fs.String("username", "", "unique username")
+	firstName := fs.String("first-name", "", "first name")
+	lastName := fs.String("last-name", "", "last name")
+	if err := parse(fs, args, "username"); err != nil {
+		return err
+	}
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+
+	user, err := a.admin.CreateEmployee(ctx, *username, *firstName, *lastName)
+	if err != nil {
+		return err
+	}
+	return a.out.print(user)
+}
+
+func migrationsStatus(ctx context.Context, a *app, args []string) error {
+	if err := parse(a.newFlags("migrations status"), args); err != nil {
+		return err
+	}
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+
+	state, err := openapi.GetMigrationState(ctx, a.admin.Postgres())
+	if err != nil {
+		return err
+	}
+	return a.out.print(state)
+}
+
+func migrationsUp(ctx context.Context, a *app, args []string) error {
+	if err := parse(a.newFlags("migrations up"), args); err != nil {
+		return err
+	}
+	if err := a.requireDB(); err != nil {
+		return err
+	}
+
+	if err := openapi.Migrate(ctx, a.admin.Postgres()); err != nil {
+		return err
+	}
+	return migrationsStatus(ctx, a, nil)
+}
+
+func exportTenders(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("export tenders")
+	format := fs.String("format", "csv", "csv or json")
+	var serviceTypes serviceTypeList
+	fs.Var(&serviceTypes, "service-type", "filter by service type, repeatable")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	tenders, err := collect(ctx, func(ctx context.Context, page client.Page) ([]openapi.Tender, error) {
+		return a.backend.GetTenders(ctx, page, serviceTypes...)
+	})
+	if err != nil {
+		return err
+	}
+	return export(a, *format, tenders)
+}
+
+func exportBids(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlags("export bids")
+	format := fs.String("format", "csv", "csv or json")
+	tenderID := fs.String("tender", "", "tender id")
+	username := fs.String("username", "", "employee username")
+	if err := parse(fs, args, "tender", "username"); err != nil {
+		return err
+	}
+
+	bids, err := collect(ctx, func(ctx context.Context, page client.Page) ([]openapi.Bid, error) {
+		return a.backend.GetBidsForTender(ctx, *tenderID, *username, "", page)
+	})
+	if err != nil {
+		return err
+	}
+	return export(a, *format, bids)
+}
+
+func export[T any](a *app, format string, items []T) error {
+	if items == nil {
+		items = []T{}
+	}
+	switch format {
+	case "csv":
+		return writeCSV(a.stdout, items)
+	case "json":
+		return json.NewEncoder(a.stdout).Encode(items)
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// serviceTypeList collects a repeatable -service-type flag.
+type serviceTypeList []openapi.TenderServiceType
+
+func (l *serviceTypeList) String() string {
+	return fmt.Sprint(*l)
+}
+
+func (l *serviceTypeList) Set(v string) error {
+	*l = append(*l, openapi.TenderServiceType(v))
+	return nil
+}
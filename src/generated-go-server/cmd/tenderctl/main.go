@@ -0,0 +1,189 @@
+// Command tenderctl operates the tender service: it lists and fixes tenders and bids,
+// manages organizations and employees, applies migrations and exports data.
+//
+// By default it connects to the database configured the same way as the server
+// (config file, .env and environment variables). With -remote it talks to a running
+// server over the HTTP API instead; commands that have no API endpoint then fail.
+//
+//	tenderctl tenders list -service-type Delivery
+//	tenderctl -remote http://localhost:8080/api -output json bids my -username test_user
+//	tenderctl tenders set-status -id <uuid> -status Closed -force
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/GIT_USER_ID/GIT_REPO_ID/client"
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// errRemote is returned by commands that need direct database access.
+var errRemote = errors.New("this command needs direct database access, run it without -remote")
+
+// app is the state shared by all commands.
+type app struct {
+	out     printer
+	stdout  io.Writer
+	stderr  io.Writer
+	backend backend
+	// admin is nil in remote mode.
+	admin *openapi.AdminService
+}
+
+// command is a leaf of the tenderctl command tree, e.g. "tenders list".
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("tenderctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", os.Getenv("CONFIG_PATH"), "path to a YAML or TOML config file")
+	remote := fs.String("remote", os.Getenv("TENDERCTL_REMOTE"), "base URL of the API, e.g. http://localhost:8080/api; the database is used when empty")
+	token := fs.String("token", os.Getenv("TENDERCTL_TOKEN"), "bearer token sent to the remote API")
+	output := fs.String("output", "table", "output format: table or json")
+	verbose := fs.Bool("v", false, "log the queries of the storage layer to stderr")
+	fs.Usage = func() { usage(fs, stderr) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "tenderctl: unknown output format %q\n", *output)
+		return 2
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return 2
+	}
+
+	group, name := fs.Arg(0), fs.Arg(1)
+	cmd, ok := commands[group][name]
+	if !ok {
+		fmt.Fprintf(stderr, "tenderctl: unknown command %q\n", group+" "+name)
+		fs.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{out: printer{w: stdout, json: *output == "json"}, stdout: stdout, stderr: stderr}
+
+	if *remote != "" {
+		opts := []client.Option{client.WithRetry(client.DefaultRetryPolicy), client.WithUserAgent("tenderctl/1.0")}
+		if *token != "" {
+			opts = append(opts, client.WithBearerToken(*token))
+		}
+		c, err := client.New(*remote, opts...)
+		if err != nil {
+			fmt.Fprintf(stderr, "tenderctl: %v\n", err)
+			return 1
+		}
+		a.backend = c
+	} else {
+		config, err := openapi.Load(*configPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "tenderctl: %v\n", err)
+			return 1
+		}
+
+		level := slog.LevelWarn
+		if *verbose {
+			level = slog.LevelDebug
+		}
+		log := slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: level}))
+
+		pg, err := openapi.NewStorage(config.Postgres, log)
+		if err != nil {
+			fmt.Fprintf(stderr, "tenderctl: %v\n", err)
+			return 1
+		}
+		defer pg.Close()
+
+		a.admin = openapi.NewAdminService(pg, log)
+		a.backend = localBackend{svc: a.admin}
+	}
+
+	if err := cmd.run(ctx, a, fs.Args()[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "tenderctl: %s %s: %v\n", group, name, err)
+		return 1
+	}
+	return 0
+}
+
+func usage(fs *flag.FlagSet, w io.Writer) {
+	fmt.Fprintln(w, "Usage: tenderctl [flags] <group> <command> [command flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	groups := make([]string, 0, len(commands))
+	for group := range commands {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		names := make([]string, 0, len(commands[group]))
+		for name := range commands[group] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "  %-34s %s\n", group+" "+name, commands[group][name].usage)
+		}
+	}
+}
+
+// newFlags creates the flag set of a command.
+func (a *app) newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parse parses the flags of a command, rejecting positional arguments and missing required flags.
+func parse(fs *flag.FlagSet, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range required {
+		if !set[name] {
+			return fmt.Errorf("flag -%s is required", name)
+		}
+	}
+	return nil
+}
+
+// requireDB returns errRemote unless tenderctl is connected to the database.
+func (a *app) requireDB() error {
+	if a.admin == nil {
+		return errRemote
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+func newRemote(t *testing.T, tenders []openapi.Tender) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tenders" {
+			http.NotFound(w, r)
+			return
+		}
+		page := tenders
+		if offset := r.URL.Query().Get("offset"); offset != "" && offset != "0" {
+			page = []openapi.Tender{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/api"
+}
+
+var testTenders = []openapi.Tender{{
+	Id:          "550e8400-e29b-41d4-a716-446655440000",
+	Name:        "Доставка",
+	Description: "Доставка, сборка",
+	Status:      openapi.PUBLISHED,
+	ServiceType: openapi.DELIVERY,
+	Version:     2,
+	CreatedAt:   "2024-09-01T10:00:00Z",
+}}
+
+func TestRemoteOutput(t *testing.T) {
+	remote := newRemote(t, testTenders)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-remote", remote, "tenders", "list"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Published") {
+		t.Errorf("unexpected table:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-remote", remote, "-output", "json", "tenders", "list"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	var got []openapi.Tender
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil || len(got) != 1 || got[0] != testTenders[0] {
+		t.Errorf("json output %s: %v", stdout.String(), err)
+	}
+}
+
+func TestRemoteExport(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-remote", newRemote(t, testTenders), "export", "tenders"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	want := "ID,NAME,DESCRIPTION,SERVICE TYPE,STATUS,ORGANIZATION,VERSION,CREATED\n" +
+		`550e8400-e29b-41d4-a716-446655440000,Доставка,"Доставка, сборка",Delivery,Published,,2,2024-09-01T10:00:00Z` + "\n"
+	if stdout.String() != want {
+		t.Errorf("csv:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestRemoteRejectsDatabaseCommands(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-remote", newRemote(t, nil), "organizations", "list"}, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), errRemote.Error()) {
+		t.Errorf("exit %d, stderr %q", code, stderr.String())
+	}
+
+	stderr.Reset()
+	code = run([]string{"-remote", newRemote(t, nil), "tenders", "set-status", "-id", "x", "-status", "Closed", "-force"}, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), errRemote.Error()) {
+		t.Errorf("exit %d, stderr %q", code, stderr.String())
+	}
+}
+
+func TestUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"tenders", "nope"}, &stdout, &stderr); code != 2 {
+		t.Errorf("exit %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), "organizations add-responsible") {
+		t.Errorf("usage does not list commands:\n%s", stderr.String())
+	}
+}
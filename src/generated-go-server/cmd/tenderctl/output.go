@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+)
+
+// printer writes command results as an aligned table or as indented JSON.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func (p printer) print(v any) error {
+	if p.json {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	header, rows := tableOf(v)
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tableOf lays out the result types of tenderctl as rows of cells.
+func tableOf(v any) (header []string, rows [][]string) {
+	switch v := v.(type) {
+	case *openapi.Tender:
+		return tableOf([]openapi.Tender{*v})
+	case []openapi.Tender:
+		header = tenderHeader
+		for _, t := range v {
+			rows = append(rows, tenderRow(t))
+		}
+	case *openapi.Bid:
+		return tableOf([]openapi.Bid{*v})
+	case []openapi.Bid:
+		header = bidHeader
+		for _, b := range v {
+			rows = append(rows, bidRow(b))
+		}
+	case *openapi.User:
+		return tableOf([]openapi.User{*v})
+	case []openapi.User:
+		header = []string{"ID", "USERNAME", "FIRST NAME", "LAST NAME", "CREATED"}
+		for _, u := range v {
+			rows = append(rows, []string{u.Id.String(), u.Username, u.FirstName, u.LastName, u.CreatedAt.Format(time.RFC3339)})
+		}
+	case []openapi.Organization:
+		header = []string{"ID", "NAME", "TYPE", "DESCRIPTION"}
+		for _, o := range v {
+			rows = append(rows, []string{o.ID.String(), o.Name, o.Type, o.Description})
+		}
+	case openapi.MigrationState:
+		header = []string{"CURRENT", "LATEST", "PENDING"}
+		rows = [][]string{{strconv.Itoa(v.Current), strconv.Itoa(v.Latest), strconv.Itoa(v.Pending)}}
+	default:
+		rows = [][]string{{fmt.Sprint(v)}}
+	}
+	return header, rows
+}
+
+var tenderHeader = []string{"ID", "NAME", "SERVICE TYPE", "STATUS", "ORGANIZATION", "VERSION", "CREATED"}
+
+func tenderRow(t openapi.Tender) []string {
+	return []string{t.Id, t.Name, string(t.ServiceType), string(t.Status), t.OrganizationId, strconv.Itoa(int(t.Version)), t.CreatedAt}
+}
+
+var bidHeader = []string{"ID", "NAME", "STATUS", "TENDER", "AUTHOR TYPE", "AUTHOR", "VERSION", "CREATED"}
+
+func bidRow(b openapi.Bid) []string {
+	return []string{b.Id, b.Name, string(b.Status), b.TenderId, string(b.AuthorType), b.AuthorId, strconv.Itoa(int(b.Version)), b.CreatedAt}
+}
+
+// writeCSV writes the table of v as CSV, including descriptions that the table omits.
+func writeCSV(w io.Writer, v any) error {
+	var header []string
+	var rows [][]string
+
+	switch v := v.(type) {
+	case []openapi.Tender:
+		header = append(tenderHeader[:2:2], append([]string{"DESCRIPTION"}, tenderHeader[2:]...)...)
+		for _, t := range v {
+			row := tenderRow(t)
+			rows = append(rows, append(row[:2:2], append([]string{t.Description}, row[2:]...)...))
+		}
+	case []openapi.Bid:
+		header = append(bidHeader[:2:2], append([]string{"DESCRIPTION"}, bidHeader[2:]...)...)
+		for _, b := range v {
+			row := bidRow(b)
+			rows = append(rows, append(row[:2:2], append([]string{b.Description}, row[2:]...)...))
+		}
+	default:
+		header, rows = tableOf(v)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,233 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Access checks shared by the services built on DefaultAPIService. They load the entity and
+// return an *APIError ready for apiErrorResult when the user may not access it.
+
+// tenderForRead loads the tender if the user may see it: published tenders are visible to
+// everyone, or to the invitees for a private one, the others only to the responsibles of the
+// organization.
+func (s *DefaultAPIService) tenderForRead(ctx context.Context, tenderId string, username string) (*Tender, error) {
+	tender, err := s.loadTender(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if username == "" {
+		if tender.Status == PUBLISHED && !tender.Private {
+			return tender, nil
+		}
+		return nil, NewAPIError(ErrCodeInvalidParameter, nil).WithDetail(MsgUsernameRequired)
+	}
+
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status == PUBLISHED {
+		if tender.Private {
+			return s.privateTenderForRead(ctx, user, tender)
+		}
+		return tender, nil
+	}
+	if err := s.checkTenderRights(ctx, user, tender); err != nil {
+		return nil, err
+	}
+	return tender, nil
+}
+
+// tenderForWrite loads the tender if the user is responsible for its organization.
+func (s *DefaultAPIService) tenderForWrite(ctx context.Context, tenderId string, username string) (*Tender, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := s.loadTender(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTenderRights(ctx, user, tender); err != nil {
+		return nil, err
+	}
+	return tender, nil
+}
+
+// bidForRead loads the bid if the user is its author or responsible for its tender. The
+// responsibles of a sealed tender have to wait for the reveal.
+func (s *DefaultAPIService) bidForRead(ctx context.Context, bidId string, username string) (*Bid, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	bid, err := s.loadBid(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	authorErr := s.checkBidAuthor(ctx, user, bid)
+	if authorErr == nil {
+		return bid, nil
+	}
+	if !errors.Is(authorErr, ErrUserNoRightsBid) {
+		return nil, authorErr
+	}
+
+	tenderIdUUID, _ := s.ConvertIntoUUID(bid.TenderId)
+	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
+	if err1 != nil {
+		if errors.Is(err1, ErrUserNoRightsTender) {
+			return nil, authorErr
+		}
+		return nil, NewAPIError(ErrCodeInternal, err2)
+	}
+
+	tender, err := s.loadTender(ctx, bid.TenderId)
+	if err != nil {
+		return nil, err
+	}
+	if tender.bidsSealed() {
+		return nil, sealedBidsError(tender)
+	}
+	return bid, nil
+}
+
+// bidForWrite loads the bid if the user is its author.
+func (s *DefaultAPIService) bidForWrite(ctx context.Context, bidId string, username string) (*Bid, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	bid, err := s.loadBid(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBidAuthor(ctx, user, bid); err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+// checkBidder checks that the author may bid on the tender: an accepted invitation for a
+// private tender, otherwise the rights on the tender, and then the supplier list of the
+// tender's organization.
+func (s *DefaultAPIService) checkBidder(ctx context.Context, tender *Tender, authorType BidAuthorType, authorId uuid.UUID) *APIError {
+	tenderId, _ := s.ConvertIntoUUID(tender.Id)
+
+	if tender.Private {
+		if apiErr := s.checkInvitedBidder(ctx, tenderId, authorType, authorId); apiErr != nil {
+			return apiErr
+		}
+	} else if authorType == USER {
+		_, err := s.getUserById(ctx, authorId)
+		if err != nil {
+			if errors.Is(err, ErrNoUser) {
+				return NewAPIError(ErrCodeUserNotFound, err)
+			}
+			return NewAPIError(ErrCodeInternal, err)
+		}
+		err1, err2 := s.userHasRights(ctx, authorId, tenderId)
+		if err1 != nil {
+			if errors.Is(err1, ErrUserNoRightsTender) {
+				return NewAPIError(ErrCodeForbiddenNotResponsible, err1)
+			}
+			return NewAPIError(ErrCodeInternal, err2)
+		}
+
+	} else if authorType == ORGANIZATION {
+		_, err := s.getOrganizationById(ctx, authorId)
+		if err != nil {
+			if errors.Is(err, ErrNoOrganization) {
+				return NewAPIError(ErrCodeOrganizationNotFound, err)
+			}
+			return NewAPIError(ErrCodeInternal, err)
+		}
+		if err := s.organizationHasRights(ctx, authorId, tenderId); err != nil {
+			if errors.Is(err, ErrOrgNoRightsTender) {
+				return NewAPIError(ErrCodeForbiddenOrganization, err)
+			}
+			return NewAPIError(ErrCodeInternal, err)
+		}
+	}
+
+	organizationId, _ := s.ConvertIntoUUID(tender.OrganizationId)
+	return s.checkSupplier(ctx, organizationId, authorType, authorId)
+}
+
+func (s *DefaultAPIService) loadUser(ctx context.Context, username string) (*User, error) {
+	user, err := s.getUserByName(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrNoUser) {
+			return nil, NewAPIError(ErrCodeUserNotFound, err)
+		}
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return user, nil
+}
+
+func (s *DefaultAPIService) loadTender(ctx context.Context, tenderId string) (*Tender, error) {
+	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInvalidID, err)
+	}
+	tender, err := s.getTenderById(ctx, tenderIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, NewAPIError(ErrCodeTenderNotFound, err)
+		}
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return tender, nil
+}
+
+func (s *DefaultAPIService) loadBid(ctx context.Context, bidId string) (*Bid, error) {
+	bidIdUUID, err := s.ConvertIntoUUID(bidId)
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInvalidID, err)
+	}
+	bid, err := s.getBidById(ctx, bidIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, NewAPIError(ErrCodeBidNotFound, err)
+		}
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return bid, nil
+}
+
+func (s *DefaultAPIService) checkTenderRights(ctx context.Context, user *User, tender *Tender) error {
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
+	if err1 != nil {
+		if errors.Is(err1, ErrUserNoRightsTender) {
+			return NewAPIError(ErrCodeForbiddenNotResponsible, err1)
+		}
+		return NewAPIError(ErrCodeInternal, err2)
+	}
+	return nil
+}
+
+// checkBidAuthor allows the user who wrote the bid or a member of the organization that did,
+// as in EditBid.
+func (s *DefaultAPIService) checkBidAuthor(ctx context.Context, user *User, bid *Bid) error {
+	if bid.AuthorType == USER {
+		if s.ConvertFromUUID(user.Id) != bid.AuthorId {
+			return NewAPIError(ErrCodeForbiddenNotAuthor, ErrUserNoRightsBid)
+		}
+		return nil
+	}
+
+	orgIdUUID, _ := s.ConvertIntoUUID(bid.AuthorId)
+	if err := s.userBelongsToOrganization(ctx, user.Username, orgIdUUID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return NewAPIError(ErrCodeForbiddenNotAuthor, ErrUserNoRightsBid)
+		}
+		return NewAPIError(ErrCodeInternal, err)
+	}
+	return nil
+}
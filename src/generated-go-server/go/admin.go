@@ -0,0 +1,328 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AdminService implements operational tasks that have no HTTP endpoint: managing
+// organizations and employees and fixing tenders stuck in a wrong state. It is used
+// by tenderctl and skips the permission checks of the public API.
+type AdminService struct {
+	*DefaultAPIService
+}
+
+// NewAdminService creates an admin service
+func NewAdminService(pg *Postgres, log *slog.Logger) *AdminService {
+	return &AdminService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// Postgres returns the storage the service works with.
+func (s *AdminService) Postgres() *Postgres {
+	return s.pg
+}
+
+// ListOrganizations returns all organizations ordered by name.
+func (s *AdminService) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	const op = "AdminService.ListOrganizations"
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := s.builder.
+		Select("id", "name", "COALESCE(description, '')", "COALESCE(type::text, '')", "created_at", "updated_at").
+		From("organization").
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to execute SQL query", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+	defer rows.Close()
+
+	organizations := []Organization{}
+	for rows.Next() {
+		var org Organization
+		if err := rows.Scan(&org.ID, &org.Name, &org.Description, &org.Type, &org.CreatedAt, &org.UpdatedAt); err != nil {
+			log.Error("failed to scan organization", slog.Any("err", err))
+			return nil, ErrSQLQuery
+		}
+		organizations = append(organizations, org)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("failed to read organizations", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+
+	return organizations, nil
+}
+
+// ListResponsibles returns the employees responsible for an organization.
+func (s *AdminService) ListResponsibles(ctx context.Context, orgId uuid.UUID) ([]User, error) {
+	if _, err := s.getOrganization(ctx, orgId); err != nil {
+		return nil, err
+	}
+
+	return s.queryUsers(ctx, "AdminService.ListResponsibles", s.builder.
+		Select(userColumns...).
+		From("employee").
+		Join("organization_responsible ON organization_responsible.user_id = employee.id").
+		Where(squirrel.Eq{"organization_responsible.organization_id": orgId}).
+		OrderBy("employee.username"))
+}
+
+// AddResponsible makes username responsible for an organization.
+// It returns ErrAlreadyExists if the employee is already responsible for it.
+func (s *AdminService) AddResponsible(ctx context.Context, orgId uuid.UUID, username string) error {
+	const op = "AdminService.AddResponsible"
+	log := s.log.With(slog.String("op", op))
+
+	if _, err := s.getOrganization(ctx, orgId); err != nil {
+		return err
+	}
+	user, err := s.getUserByName(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userBelongsToOrganization(ctx, username, orgId); err == nil {
+		return ErrAlreadyExists
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	sql, args, err := s.builder.
+		Insert("organization_responsible").
+		Columns("organization_id", "user_id").
+		Values(orgId, user.Id).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("err", err))
+		return ErrSQLQuery
+	}
+
+	if _, err := s.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		log.Error("failed to add responsible", slog.Any("err", err))
+		return ErrSQLQuery
+	}
+
+	log.Info("responsible added", slog.String("organization_id", orgId.String()), slog.String("username", username))
+	return nil
+}
+
+// RemoveResponsible revokes the responsibility of username for an organization.
+func (s *AdminService) RemoveResponsible(ctx context.Context, orgId uuid.UUID, username string) error {
+	const op = "AdminService.RemoveResponsible"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.getUserByName(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	sql, args, err := s.builder.
+		Delete("organization_responsible").
+		Where(squirrel.Eq{"organization_id": orgId, "user_id": user.Id}).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("err", err))
+		return ErrSQLQuery
+	}
+
+	tag, err := s.pg.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to remove responsible", slog.Any("err", err))
+		return ErrSQLQuery
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	log.Info("responsible removed", slog.String("organization_id", orgId.String()), slog.String("username", username))
+	return nil
+}
+
+// ListEmployees returns a page of employees ordered by username.
+func (s *AdminService) ListEmployees(ctx context.Context, limit, offset int32) ([]User, error) {
+	return s.queryUsers(ctx, "AdminService.ListEmployees", s.builder.
+		Select(userColumns...).
+		From("employee").
+		OrderBy("username").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)))
+}
+
+// CreateEmployee registers a new employee. It returns ErrAlreadyExists if the username is taken.
+func (s *AdminService) CreateEmployee(ctx context.Context, username, firstName, lastName string) (*User, error) {
+	const op = "AdminService.CreateEmployee"
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := s.builder.
+		Insert("employee").
+		Columns("username", "first_name", "last_name").
+		Values(username, firstName, lastName).
+		Suffix("RETURNING " + strings.Join(userColumns, ", ")).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+
+	user, err := scanUser(s.pg.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		// unique_violation on employee.username
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrAlreadyExists
+		}
+		log.Error("failed to create employee", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+
+	log.Info("employee created", slog.String("username", username))
+	return user, nil
+}
+
+// ForceTenderStatus sets the status of a tender regardless of who is responsible for it.
+// The previous state is kept in tender_versions and the version is bumped, as EditTender does,
+// so that the change can be rolled back. Closing the tender is announced like UpdateTenderStatus.
+func (s *AdminService) ForceTenderStatus(ctx context.Context, tenderId uuid.UUID, status TenderStatus) (*Tender, error) {
+	const op = "AdminService.ForceTenderStatus"
+	log := s.log.With(slog.String("op", op))
+
+	if !status.IsValid() {
+		return nil, &ValidationError{Field: "status"}
+	}
+
+	tender, err := s.getTenderById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	var creator string
+	err = s.pg.Pool.QueryRow(ctx, `SELECT creator_username FROM tenders WHERE id = $1`, tenderId).Scan(&creator)
+	if err != nil {
+		log.Error("failed to read tender creator", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+	if err := s.addVersionTableTender(ctx, creator, tender); err != nil {
+		return nil, ErrSQLQuery
+	}
+
+	sql, args, err := s.builder.
+		Update("tenders").
+		Set("status", status).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{"id": tenderId}).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return nil, ErrSQLQuery
+	}
+	defer tx.Rollback(ctx)
+
+	previous, err := lockTender(ctx, tx, tenderId)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		log.Error("failed to lock tender", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		log.Error("failed to update tender status", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+	if status == CLOSED && previous != CLOSED {
+		if err := recordEvent(ctx, tx, eventTenderClosed, tenderId, map[string]any{"tenderId": tenderId}); err != nil {
+			log.Error("failed to record the event", slog.Any("err", err))
+			return nil, ErrSQLQuery
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, ErrSQLQuery
+	}
+
+	log.Info("tender status forced", slog.String("tender_id", tenderId.String()), slog.String("status", string(status)))
+	return s.getTenderById(ctx, tenderId)
+}
+
+// getOrganization is getOrganizationById with the placeholders of the service builder.
+func (s *AdminService) getOrganization(ctx context.Context, id uuid.UUID) (*Organization, error) {
+	var org Organization
+	err := s.pg.Pool.QueryRow(ctx,
+		`SELECT id, name, COALESCE(description, ''), COALESCE(type::text, ''), created_at, updated_at FROM organization WHERE id = $1`, id,
+	).Scan(&org.ID, &org.Name, &org.Description, &org.Type, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoOrganization
+		}
+		s.log.Error("failed to read organization", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+	return &org, nil
+}
+
+var userColumns = []string{
+	"employee.id", "employee.username", "COALESCE(employee.first_name, '')", "COALESCE(employee.last_name, '')",
+	"employee.created_at", "employee.updated_at",
+}
+
+func scanUser(row pgx.Row) (*User, error) {
+	var user User
+	if err := row.Scan(&user.Id, &user.Username, &user.FirstName, &user.LastName, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *AdminService) queryUsers(ctx context.Context, op string, query squirrel.SelectBuilder) ([]User, error) {
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to execute SQL query", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+	defer rows.Close()
+
+	users := []User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			log.Error("failed to scan employee", slog.Any("err", err))
+			return nil, ErrSQLQuery
+		}
+		users = append(users, *user)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("failed to read employees", slog.Any("err", err))
+		return nil, ErrSQLQuery
+	}
+
+	return users, nil
+}
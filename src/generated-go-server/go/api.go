@@ -13,6 +13,7 @@ package openapi
 import (
 	"context"
 	"net/http"
+	"os"
 )
 
 // DefaultAPIRouter defines the required methods for binding the api requests to a responses for the DefaultAPI
@@ -39,6 +40,124 @@ type DefaultAPIRouter interface {
 	UpdateTenderStatus(http.ResponseWriter, *http.Request)
 }
 
+// DocsAPIRouter defines the required methods for serving the API documentation
+type DocsAPIRouter interface {
+	GetDocs(http.ResponseWriter, *http.Request)
+	GetOpenAPIJSON(http.ResponseWriter, *http.Request)
+	GetOpenAPIYAML(http.ResponseWriter, *http.Request)
+}
+
+// ExportAPIRouter defines the required methods for binding the export requests to a responses for the ExportAPI
+type ExportAPIRouter interface {
+	ExportBids(http.ResponseWriter, *http.Request)
+	ExportTenders(http.ResponseWriter, *http.Request)
+}
+
+// ImportAPIRouter defines the required methods for binding the import requests to a responses for the ImportAPI
+type ImportAPIRouter interface {
+	ImportTenders(http.ResponseWriter, *http.Request)
+}
+
+// AttachmentAPIRouter defines the required methods for binding the attachment requests to a responses for the AttachmentAPI
+type AttachmentAPIRouter interface {
+	UploadTenderAttachment(http.ResponseWriter, *http.Request)
+	ListTenderAttachments(http.ResponseWriter, *http.Request)
+	DownloadTenderAttachment(http.ResponseWriter, *http.Request)
+	DeleteTenderAttachment(http.ResponseWriter, *http.Request)
+	UploadBidAttachment(http.ResponseWriter, *http.Request)
+	ListBidAttachments(http.ResponseWriter, *http.Request)
+	DownloadBidAttachment(http.ResponseWriter, *http.Request)
+	DeleteBidAttachment(http.ResponseWriter, *http.Request)
+}
+
+// LotAPIRouter defines the required methods for binding the lot requests to a responses for the LotAPI
+type LotAPIRouter interface {
+	ListTenderLots(http.ResponseWriter, *http.Request)
+	CreateTenderLot(http.ResponseWriter, *http.Request)
+	EditTenderLot(http.ResponseWriter, *http.Request)
+	CancelTenderLot(http.ResponseWriter, *http.Request)
+}
+
+// EvaluationAPIRouter defines the required methods for binding the evaluation requests to a responses for the EvaluationAPI
+type EvaluationAPIRouter interface {
+	ListTenderCriteria(http.ResponseWriter, *http.Request)
+	CreateTenderCriterion(http.ResponseWriter, *http.Request)
+	DeleteTenderCriterion(http.ResponseWriter, *http.Request)
+	GetTenderRanking(http.ResponseWriter, *http.Request)
+	SubmitBidScores(http.ResponseWriter, *http.Request)
+}
+
+// AuctionAPIRouter defines the required methods for binding the auction requests to a responses for the AuctionAPI
+type AuctionAPIRouter interface {
+	GetTenderAuction(http.ResponseWriter, *http.Request)
+	CreateTenderAuction(http.ResponseWriter, *http.Request)
+	PlaceAuctionOffer(http.ResponseWriter, *http.Request)
+	FollowTenderAuction(http.ResponseWriter, *http.Request)
+}
+
+// QuestionAPIRouter defines the required methods for binding the question requests to a responses for the QuestionAPI
+type QuestionAPIRouter interface {
+	ListTenderQuestions(http.ResponseWriter, *http.Request)
+	AskTenderQuestion(http.ResponseWriter, *http.Request)
+	AnswerTenderQuestion(http.ResponseWriter, *http.Request)
+}
+
+// InvitationAPIRouter defines the required methods for binding the invitation requests to a responses for the InvitationAPI
+type InvitationAPIRouter interface {
+	ListTenderInvitations(http.ResponseWriter, *http.Request)
+	CreateTenderInvitation(http.ResponseWriter, *http.Request)
+	GetTenderInvitationHistory(http.ResponseWriter, *http.Request)
+	RevokeTenderInvitation(http.ResponseWriter, *http.Request)
+	GetUserInvitations(http.ResponseWriter, *http.Request)
+	AcceptInvitation(http.ResponseWriter, *http.Request)
+	DeclineInvitation(http.ResponseWriter, *http.Request)
+}
+
+// SupplierAPIRouter defines the required methods for binding the supplier requests to a responses for the SupplierAPI
+type SupplierAPIRouter interface {
+	GetOrganizationSuppliers(http.ResponseWriter, *http.Request)
+	SetOrganizationSupplier(http.ResponseWriter, *http.Request)
+	SetOrganizationSupplierPolicy(http.ResponseWriter, *http.Request)
+	DeleteOrganizationSupplier(http.ResponseWriter, *http.Request)
+	CheckBidEligibility(http.ResponseWriter, *http.Request)
+}
+
+// ConflictAPIRouter defines the required methods for binding the conflict requests to a responses for the ConflictAPI
+type ConflictAPIRouter interface {
+	GetTenderConflicts(http.ResponseWriter, *http.Request)
+	SetTenderConflictPolicy(http.ResponseWriter, *http.Request)
+}
+
+// ReputationAPIRouter defines the required methods for binding the reputation requests to a responses for the ReputationAPI
+type ReputationAPIRouter interface {
+	GetReputation(http.ResponseWriter, *http.Request)
+}
+
+// FeedbackAPIRouter defines the required methods for binding the feedback requests to a responses for the FeedbackAPI
+type FeedbackAPIRouter interface {
+	EditBidFeedback(http.ResponseWriter, *http.Request)
+	RetractBidFeedback(http.ResponseWriter, *http.Request)
+	GetBidFeedbackHistory(http.ResponseWriter, *http.Request)
+	GetBidReviewSummary(http.ResponseWriter, *http.Request)
+}
+
+// NotificationAPIRouter defines the required methods for binding the notification requests to a responses for the NotificationAPI
+type NotificationAPIRouter interface {
+	GetNotifications(http.ResponseWriter, *http.Request)
+	MarkNotificationRead(http.ResponseWriter, *http.Request)
+	MarkAllNotificationsRead(http.ResponseWriter, *http.Request)
+	GetNotificationPreferences(http.ResponseWriter, *http.Request)
+	UpdateNotificationPreferences(http.ResponseWriter, *http.Request)
+	GetEmailSettings(http.ResponseWriter, *http.Request)
+	UpdateEmailSettings(http.ResponseWriter, *http.Request)
+}
+
+// HealthAPIRouter defines the required methods for binding the api requests to a responses for the HealthAPI
+type HealthAPIRouter interface {
+	LiveCheck(http.ResponseWriter, *http.Request)
+	ReadyCheck(http.ResponseWriter, *http.Request)
+}
+
 // DefaultAPIServicer defines the api actions for the DefaultAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -51,15 +170,128 @@ type DefaultAPIServicer interface {
 	EditTender(context.Context, string, string, EditTenderRequest) (ImplResponse, error)
 	GetBidReviews(context.Context, string, string, string, int32, int32) (ImplResponse, error)
 	GetBidStatus(context.Context, string, string) (ImplResponse, error)
-	GetBidsForTender(context.Context, string, string, int32, int32) (ImplResponse, error)
+	GetBidsForTender(context.Context, string, string, int32, int32, BidSort) (ImplResponse, error)
 	GetTenderStatus(context.Context, string, string) (ImplResponse, error)
 	GetTenders(context.Context, int32, int32, []TenderServiceType) (ImplResponse, error)
 	GetUserBids(context.Context, int32, int32, string) (ImplResponse, error)
 	GetUserTenders(context.Context, int32, int32, string) (ImplResponse, error)
 	RollbackBid(context.Context, string, int32, string) (ImplResponse, error)
 	RollbackTender(context.Context, string, int32, string) (ImplResponse, error)
-	SubmitBidDecision(context.Context, string, BidDecision, string) (ImplResponse, error)
-	SubmitBidFeedback(context.Context, string, string, string) (ImplResponse, error)
+	SubmitBidDecision(context.Context, string, BidDecision, string, string) (ImplResponse, error)
+	SubmitBidFeedback(context.Context, string, string, string, int32, []FeedbackTag) (ImplResponse, error)
 	UpdateBidStatus(context.Context, string, BidStatus, string) (ImplResponse, error)
 	UpdateTenderStatus(context.Context, string, TenderStatus, string) (ImplResponse, error)
 }
+
+// ExportAPIServicer defines the api actions for the ExportAPI service.
+// A successful result carries an *Export body that is streamed to the client.
+type ExportAPIServicer interface {
+	ExportBids(context.Context, string, string, ExportFormat, BidSort) (ImplResponse, error)
+	ExportTenders(context.Context, ExportFormat, []TenderServiceType) (ImplResponse, error)
+}
+
+// ImportAPIServicer defines the api actions for the ImportAPI service
+type ImportAPIServicer interface {
+	ImportTenders(context.Context, []TenderImportRow, bool) (ImplResponse, error)
+}
+
+// AttachmentAPIServicer defines the api actions for the AttachmentAPI service
+type AttachmentAPIServicer interface {
+	MaxSize() int64
+	UploadTenderAttachment(context.Context, string, string, *os.File) (ImplResponse, error)
+	ListTenderAttachments(context.Context, string, string, int32) (ImplResponse, error)
+	DownloadTenderAttachment(context.Context, string, string, string) (ImplResponse, error)
+	DeleteTenderAttachment(context.Context, string, string, string) (ImplResponse, error)
+	UploadBidAttachment(context.Context, string, string, *os.File) (ImplResponse, error)
+	ListBidAttachments(context.Context, string, string, int32) (ImplResponse, error)
+	DownloadBidAttachment(context.Context, string, string, string) (ImplResponse, error)
+	DeleteBidAttachment(context.Context, string, string, string) (ImplResponse, error)
+}
+
+// LotAPIServicer defines the api actions for the LotAPI service
+type LotAPIServicer interface {
+	ListTenderLots(context.Context, string, string) (ImplResponse, error)
+	CreateTenderLot(context.Context, string, string, CreateLotRequest) (ImplResponse, error)
+	EditTenderLot(context.Context, string, string, string, EditLotRequest) (ImplResponse, error)
+	CancelTenderLot(context.Context, string, string, string) (ImplResponse, error)
+}
+
+// EvaluationAPIServicer defines the api actions for the EvaluationAPI service
+type EvaluationAPIServicer interface {
+	ListTenderCriteria(context.Context, string, string) (ImplResponse, error)
+	CreateTenderCriterion(context.Context, string, string, CreateCriterionRequest) (ImplResponse, error)
+	DeleteTenderCriterion(context.Context, string, string, string) (ImplResponse, error)
+	GetTenderRanking(context.Context, string, string) (ImplResponse, error)
+	SubmitBidScores(context.Context, string, string, SubmitBidScoresRequest) (ImplResponse, error)
+}
+
+// AuctionAPIServicer defines the api actions for the AuctionAPI service
+type AuctionAPIServicer interface {
+	GetTenderAuction(context.Context, string, string) (ImplResponse, error)
+	CreateTenderAuction(context.Context, string, string, CreateAuctionRequest) (ImplResponse, error)
+	PlaceAuctionOffer(context.Context, string, string, AuctionOfferRequest) (ImplResponse, error)
+	FollowTenderAuction(context.Context, string, string) (ImplResponse, error)
+}
+
+// QuestionAPIServicer defines the api actions for the QuestionAPI service
+type QuestionAPIServicer interface {
+	ListTenderQuestions(context.Context, string, string) (ImplResponse, error)
+	AskTenderQuestion(context.Context, string, string, AskQuestionRequest) (ImplResponse, error)
+	AnswerTenderQuestion(context.Context, string, string, string, AnswerQuestionRequest) (ImplResponse, error)
+}
+
+// InvitationAPIServicer defines the api actions for the InvitationAPI service
+type InvitationAPIServicer interface {
+	ListTenderInvitations(context.Context, string, string) (ImplResponse, error)
+	CreateTenderInvitation(context.Context, string, string, CreateInvitationRequest) (ImplResponse, error)
+	GetTenderInvitationHistory(context.Context, string, string) (ImplResponse, error)
+	RevokeTenderInvitation(context.Context, string, string, string) (ImplResponse, error)
+	GetUserInvitations(context.Context, string) (ImplResponse, error)
+	AcceptInvitation(context.Context, string, string) (ImplResponse, error)
+	DeclineInvitation(context.Context, string, string) (ImplResponse, error)
+}
+
+// SupplierAPIServicer defines the api actions for the SupplierAPI service
+type SupplierAPIServicer interface {
+	GetOrganizationSuppliers(context.Context, string, string) (ImplResponse, error)
+	SetOrganizationSupplier(context.Context, string, string, SetSupplierRequest) (ImplResponse, error)
+	SetOrganizationSupplierPolicy(context.Context, string, string, SupplierPolicy) (ImplResponse, error)
+	DeleteOrganizationSupplier(context.Context, string, string, string) (ImplResponse, error)
+	CheckBidEligibility(context.Context, string, string, string) (ImplResponse, error)
+}
+
+// ConflictAPIServicer defines the api actions for the ConflictAPI service
+type ConflictAPIServicer interface {
+	GetTenderConflicts(context.Context, string, string) (ImplResponse, error)
+	SetTenderConflictPolicy(context.Context, string, string, TenderConflictPolicy) (ImplResponse, error)
+}
+
+// ReputationAPIServicer defines the api actions for the ReputationAPI service
+type ReputationAPIServicer interface {
+	GetReputation(context.Context, BidAuthorType, string, string) (ImplResponse, error)
+}
+
+// FeedbackAPIServicer defines the api actions for the FeedbackAPI service
+type FeedbackAPIServicer interface {
+	EditBidFeedback(context.Context, string, string, EditBidFeedbackRequest) (ImplResponse, error)
+	RetractBidFeedback(context.Context, string, string) (ImplResponse, error)
+	GetBidFeedbackHistory(context.Context, string, string) (ImplResponse, error)
+	GetBidReviewSummary(context.Context, string, string, string) (ImplResponse, error)
+}
+
+// NotificationAPIServicer defines the api actions for the NotificationAPI service
+type NotificationAPIServicer interface {
+	GetNotifications(context.Context, string, int32, int32, bool) (ImplResponse, error)
+	MarkNotificationRead(context.Context, string, string) (ImplResponse, error)
+	MarkAllNotificationsRead(context.Context, string) (ImplResponse, error)
+	GetNotificationPreferences(context.Context, string) (ImplResponse, error)
+	UpdateNotificationPreferences(context.Context, string, UpdateNotificationPreferencesRequest) (ImplResponse, error)
+	GetEmailSettings(context.Context, string) (ImplResponse, error)
+	UpdateEmailSettings(context.Context, string, UpdateEmailSettingsRequest) (ImplResponse, error)
+}
+
+// HealthAPIServicer defines the api actions for the HealthAPI service
+type HealthAPIServicer interface {
+	LiveCheck(context.Context) (ImplResponse, error)
+	ReadyCheck(context.Context) (ImplResponse, error)
+}
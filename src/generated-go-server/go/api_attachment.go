@@ -0,0 +1,296 @@
+package openapi
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// multipartOverhead is allowed on top of the file size for the multipart headers and boundaries.
+const multipartOverhead = 1 << 20
+
+// AttachmentAPIController binds attachment requests to the attachment service and writes the service results to the http response
+type AttachmentAPIController struct {
+	service      AttachmentAPIServicer
+	errorHandler ErrorHandler
+}
+
+// AttachmentAPIOption for how the controller is set up.
+type AttachmentAPIOption func(*AttachmentAPIController)
+
+// WithAttachmentAPIErrorHandler inject ErrorHandler into controller
+func WithAttachmentAPIErrorHandler(h ErrorHandler) AttachmentAPIOption {
+	return func(c *AttachmentAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewAttachmentAPIController creates an attachment api controller
+func NewAttachmentAPIController(s AttachmentAPIServicer, opts ...AttachmentAPIOption) *AttachmentAPIController {
+	controller := &AttachmentAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the AttachmentAPIController
+func (c *AttachmentAPIController) Routes() Routes {
+	return Routes{
+		"UploadTenderAttachment": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/{tenderId}/attachments",
+			c.UploadTenderAttachment,
+		},
+		"ListTenderAttachments": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/attachments",
+			c.ListTenderAttachments,
+		},
+		"DownloadTenderAttachment": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/attachments/{attachmentId}",
+			c.DownloadTenderAttachment,
+		},
+		"DeleteTenderAttachment": Route{
+			strings.ToUpper("Delete"),
+			"/api/tenders/{tenderId}/attachments/{attachmentId}",
+			c.DeleteTenderAttachment,
+		},
+		"UploadBidAttachment": Route{
+			strings.ToUpper("Post"),
+			"/api/bids/{bidId}/attachments",
+			c.UploadBidAttachment,
+		},
+		"ListBidAttachments": Route{
+			strings.ToUpper("Get"),
+			"/api/bids/{bidId}/attachments",
+			c.ListBidAttachments,
+		},
+		"DownloadBidAttachment": Route{
+			strings.ToUpper("Get"),
+			"/api/bids/{bidId}/attachments/{attachmentId}",
+			c.DownloadBidAttachment,
+		},
+		"DeleteBidAttachment": Route{
+			strings.ToUpper("Delete"),
+			"/api/bids/{bidId}/attachments/{attachmentId}",
+			c.DeleteBidAttachment,
+		},
+	}
+}
+
+// UploadTenderAttachment - Загрузка файла к тендеру
+func (c *AttachmentAPIController) UploadTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", true)
+	if !ok {
+		return
+	}
+	fileParam, err := c.readFile(w, r)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.UploadTenderAttachment(r.Context(), tenderIdParam, usernameParam, fileParam)
+	c.writeResult(w, r, result, err)
+}
+
+// ListTenderAttachments - Вложения тендера
+func (c *AttachmentAPIController) ListTenderAttachments(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", false)
+	if !ok {
+		return
+	}
+	versionParam, err := parseAttachmentVersion(r)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.ListTenderAttachments(r.Context(), tenderIdParam, usernameParam, versionParam)
+	c.writeResult(w, r, result, err)
+}
+
+// DownloadTenderAttachment - Скачивание вложения тендера
+func (c *AttachmentAPIController) DownloadTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", false)
+	if !ok {
+		return
+	}
+	result, err := c.service.DownloadTenderAttachment(r.Context(), tenderIdParam, mux.Vars(r)["attachmentId"], usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// DeleteTenderAttachment - Удаление вложения тендера
+func (c *AttachmentAPIController) DeleteTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", true)
+	if !ok {
+		return
+	}
+	result, err := c.service.DeleteTenderAttachment(r.Context(), tenderIdParam, mux.Vars(r)["attachmentId"], usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// UploadBidAttachment - Загрузка файла к предложению
+func (c *AttachmentAPIController) UploadBidAttachment(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.entityParams(w, r, "bidId", true)
+	if !ok {
+		return
+	}
+	fileParam, err := c.readFile(w, r)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.UploadBidAttachment(r.Context(), bidIdParam, usernameParam, fileParam)
+	c.writeResult(w, r, result, err)
+}
+
+// ListBidAttachments - Вложения предложения
+func (c *AttachmentAPIController) ListBidAttachments(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.entityParams(w, r, "bidId", true)
+	if !ok {
+		return
+	}
+	versionParam, err := parseAttachmentVersion(r)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.ListBidAttachments(r.Context(), bidIdParam, usernameParam, versionParam)
+	c.writeResult(w, r, result, err)
+}
+
+// DownloadBidAttachment - Скачивание вложения предложения
+func (c *AttachmentAPIController) DownloadBidAttachment(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.entityParams(w, r, "bidId", true)
+	if !ok {
+		return
+	}
+	result, err := c.service.DownloadBidAttachment(r.Context(), bidIdParam, mux.Vars(r)["attachmentId"], usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// DeleteBidAttachment - Удаление вложения предложения
+func (c *AttachmentAPIController) DeleteBidAttachment(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.entityParams(w, r, "bidId", true)
+	if !ok {
+		return
+	}
+	result, err := c.service.DeleteBidAttachment(r.Context(), bidIdParam, mux.Vars(r)["attachmentId"], usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// entityParams reads the id of the tender or bid from the path and the username from the query.
+func (c *AttachmentAPIController) entityParams(w http.ResponseWriter, r *http.Request, idParam string, usernameRequired bool) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	id := mux.Vars(r)[idParam]
+	if id == "" {
+		c.errorHandler(w, r, &RequiredError{idParam}, nil)
+		return "", "", false
+	}
+	if usernameRequired && !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return id, query.Get("username"), true
+}
+
+// readFile saves the file part of a multipart request to a temporary file. The request body
+// is limited to the largest accepted file.
+func (c *AttachmentAPIController) readFile(w http.ResponseWriter, r *http.Request) (*os.File, error) {
+	maxSize := c.service.MaxSize()
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+
+	file, err := ReadFormFileToTempFile(r, "file")
+	if err == nil {
+		return file, nil
+	}
+
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return nil, NewAPIError(ErrCodePayloadTooLarge, err).WithDetail(MsgAttachmentTooLarge, maxSize)
+	case errors.Is(err, http.ErrMissingFile):
+		return nil, &RequiredError{Field: "file"}
+	default:
+		return nil, &ParsingError{Param: "file", Err: err}
+	}
+}
+
+func (c *AttachmentAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	if content, ok := result.Body.(*AttachmentContent); ok {
+		writeAttachment(w, r, content)
+		return
+	}
+	if result.Code == http.StatusNoContent {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// writeAttachment sends the file as a download. The checksum serves as the ETag, so clients
+// can verify the contents and skip downloading an unchanged file.
+func writeAttachment(w http.ResponseWriter, r *http.Request, content *AttachmentContent) {
+	defer content.Content.Close()
+
+	etag := `"` + content.Sha256 + `"`
+	h := w.Header()
+	h.Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": content.Name})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	h.Set("Content-Type", content.ContentType)
+	h.Set("Content-Length", strconv.FormatInt(content.Size, 10))
+	h.Set("Content-Disposition", disposition)
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method != http.MethodHead {
+		_, _ = io.Copy(w, content.Content)
+	}
+}
+
+// parseAttachmentVersion parses the optional version query parameter; 0 means the current version.
+func parseAttachmentVersion(r *http.Request) (int32, error) {
+	v := r.URL.Query().Get("version")
+	if v == "" {
+		return 0, nil
+	}
+	version, err := parseNumericParameter[int32](
+		v,
+		WithParse[int32](parseInt32),
+		WithMinimum[int32](1),
+	)
+	if err != nil {
+		return 0, &ParsingError{Param: "version", Err: err}
+	}
+	return version, nil
+}
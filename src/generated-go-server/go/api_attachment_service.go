@@ -0,0 +1,366 @@
+package openapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AttachmentAPIService stores files attached to tenders and bids. Metadata is kept in
+// Postgres, contents in a BlobStore. Reading an attachment requires the same rights as
+// reading its tender or bid; uploading and removing require the right to edit it.
+type AttachmentAPIService struct {
+	*DefaultAPIService
+	blobs   BlobStore
+	maxSize int64
+}
+
+// NewAttachmentAPIService creates an attachment api service
+func NewAttachmentAPIService(pg *Postgres, blobs BlobStore, cfg AttachmentsConfig, log *slog.Logger) *AttachmentAPIService {
+	return &AttachmentAPIService{
+		DefaultAPIService: NewDefaultAPIService(pg, log),
+		blobs:             blobs,
+		maxSize:           cfg.MaxSize,
+	}
+}
+
+// MaxSize is the largest accepted file in bytes.
+func (s *AttachmentAPIService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// UploadTenderAttachment - Загрузка файла к тендеру
+func (s *AttachmentAPIService) UploadTenderAttachment(ctx context.Context, tenderId string, username string, file *os.File) (ImplResponse, error) {
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		os.Remove(file.Name())
+		return apiErrorResult(err)
+	}
+	return s.upload(ctx, attachmentEntityTender, tender.Id, username, file)
+}
+
+// ListTenderAttachments - Вложения тендера, текущие или указанной версии
+func (s *AttachmentAPIService) ListTenderAttachments(ctx context.Context, tenderId string, username string, version int32) (ImplResponse, error) {
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return s.list(ctx, attachmentEntityTender, tender.Id, tender.Version, version)
+}
+
+// DownloadTenderAttachment - Скачивание вложения тендера
+func (s *AttachmentAPIService) DownloadTenderAttachment(ctx context.Context, tenderId string, attachmentId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return s.download(ctx, attachmentEntityTender, tender.Id, attachmentId)
+}
+
+// DeleteTenderAttachment - Удаление вложения из текущей версии тендера
+func (s *AttachmentAPIService) DeleteTenderAttachment(ctx context.Context, tenderId string, attachmentId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return s.remove(ctx, attachmentEntityTender, tender.Id, attachmentId)
+}
+
+// UploadBidAttachment - Загрузка файла к предложению
+func (s *AttachmentAPIService) UploadBidAttachment(ctx context.Context, bidId string, username string, file *os.File) (ImplResponse, error) {
+	bid, err := s.bidForWrite(ctx, bidId, username)
+	if err != nil {
+		os.Remove(file.Name())
+		return apiErrorResult(err)
+	}
+	return s.upload(ctx, attachmentEntityBid, bid.Id, username, file)
+}
+
+// ListBidAttachments - Вложения предложения, текущие или указанной версии
+func (s *AttachmentAPIService) ListBidAttachments(ctx context.Context, bidId string, username string, version int32) (ImplResponse, error) {
+	bid, err := s.bidForRead(ctx, bidId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return s.list(ctx, attachmentEntityBid, bid.Id, bid.Version, version)
+}
+
+// DownloadBidAttachment - Скачивание вложения предложения
+func (s *AttachmentAPIService) DownloadBidAttachment(ctx context.Context, bidId string, attachmentId string, username string) (ImplResponse, error) {
+	bid, err := s.bidForRead(ctx, bidId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return s.download(ctx, attachmentEntityBid, bid.Id, attachmentId)
+}
+
+// DeleteBidAttachment - Удаление вложения из текущей версии предложения
+func (s *AttachmentAPIService) DeleteBidAttachment(ctx context.Context, bidId string, attachmentId string, username string) (ImplResponse, error) {
+	bid, err := s.bidForWrite(ctx, bidId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return s.remove(ctx, attachmentEntityBid, bid.Id, attachmentId)
+}
+
+// upload checks the file, copies it to the blob store and records it as a current attachment.
+// The temporary file from the request is removed in any case.
+func (s *AttachmentAPIService) upload(ctx context.Context, entityType string, entityId string, username string, file *os.File) (ImplResponse, error) {
+	const op = "AttachmentAPIService.upload"
+	log := s.log.With(slog.String("op", op))
+
+	defer os.Remove(file.Name())
+
+	// ReadFormFileToTempFile names the file "<original name>.<random suffix>" and closes it.
+	name := filepath.Base(file.Name())
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		return errorDetailResult(ErrCodeInvalidParameter, MsgAttachmentNameRequired)
+	}
+
+	f, err := os.Open(file.Name())
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if info.Size() > s.maxSize {
+		return errorDetailResult(ErrCodePayloadTooLarge, MsgAttachmentTooLarge, s.maxSize)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return errorResult(ErrCodeInternal, err)
+	}
+	contentType, ok := detectAttachmentType(name, head[:n])
+	if !ok {
+		return errorDetailResult(ErrCodeUnsupportedMediaType, MsgAttachmentTypeAllowed, allowedAttachmentExtensions())
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	id := uuid.New()
+	key := id.String()
+	hash := sha256.New()
+	size, err := s.blobs.Put(ctx, key, io.TeeReader(f, hash))
+	if err != nil {
+		log.Error("failed to store the file", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	attachment := Attachment{
+		Id:          key,
+		Name:        name,
+		ContentType: contentType,
+		Size:        size,
+		Sha256:      hex.EncodeToString(hash.Sum(nil)),
+		UploadedBy:  username,
+	}
+	createdAt := time.Now()
+
+	sql, args, err := s.builder.
+		Insert("attachments").
+		Columns("id", "entity_type", "entity_id", "file_name", "content_type", "size", "sha256", "storage_key", "uploaded_by", "created_at").
+		Values(id, entityType, entityId, attachment.Name, attachment.ContentType, attachment.Size, attachment.Sha256, key, username, createdAt).
+		ToSql()
+	if err == nil {
+		_, err = s.pg.Pool.Exec(ctx, sql, args...)
+	}
+	if err != nil {
+		log.Error("failed to save the attachment", slog.Any("error", err))
+		if err := s.blobs.Delete(context.WithoutCancel(ctx), key); err != nil {
+			log.Error("failed to delete the orphaned file", slog.String("key", key), slog.Any("error", err))
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	attachment.CreatedAt = createdAt.Format(time.RFC3339)
+	return Response(http.StatusCreated, attachment), nil
+}
+
+// list returns the current attachments or, for an earlier version, the attachments it had
+// when it was replaced.
+func (s *AttachmentAPIService) list(ctx context.Context, entityType string, entityId string, currentVersion int32, version int32) (ImplResponse, error) {
+	const op = "AttachmentAPIService.list"
+	log := s.log.With(slog.String("op", op))
+
+	query := s.builder.
+		Select(attachmentColumns...).
+		From("attachments a").
+		Where(squirrel.Eq{"a.entity_type": entityType, "a.entity_id": entityId}).
+		OrderBy("a.created_at", "a.id")
+
+	if version == 0 || version == currentVersion {
+		query = query.Where(squirrel.Eq{"a.removed_at": nil})
+	} else {
+		exists, err := s.versionExists(ctx, entityType, entityId, version)
+		if err != nil {
+			log.Error("failed to look up the version", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+		if !exists {
+			return errorResult(ErrCodeVersionNotFound, nil)
+		}
+		query = query.
+			Join("attachment_snapshots snapshot ON snapshot.attachment_id = a.id").
+			Where(squirrel.Eq{"snapshot.version": version})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to fetch attachments", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer rows.Close()
+
+	attachments := []Attachment{}
+	for rows.Next() {
+		attachment, _, err := scanAttachment(rows)
+		if err != nil {
+			log.Error("failed to scan attachment", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	return Response(http.StatusOK, attachments), nil
+}
+
+// versionExists reports whether the version table has the version of the tender or bid.
+func (s *AttachmentAPIService) versionExists(ctx context.Context, entityType string, entityId string, version int32) (bool, error) {
+	table, column := "tender_versions", "tender_id"
+	if entityType == attachmentEntityBid {
+		table, column = "bids_versions", "bid_id"
+	}
+
+	sql, args, err := s.builder.
+		Select("1").
+		From(table).
+		Where(squirrel.Eq{column: entityId, "version": version}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var one int
+	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&one)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// download opens any attachment that ever belonged to the entity, so that files of earlier
+// versions stay available.
+func (s *AttachmentAPIService) download(ctx context.Context, entityType string, entityId string, attachmentId string) (ImplResponse, error) {
+	const op = "AttachmentAPIService.download"
+	log := s.log.With(slog.String("op", op))
+
+	attachment, key, err := s.getAttachment(ctx, entityType, entityId, attachmentId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	content, err := s.blobs.Open(ctx, key)
+	if err != nil {
+		log.Error("failed to open the file", slog.String("key", key), slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, &AttachmentContent{Attachment: *attachment, Content: content}), nil
+}
+
+// remove takes the attachment out of the current version. The file is kept for the snapshots
+// of earlier versions.
+func (s *AttachmentAPIService) remove(ctx context.Context, entityType string, entityId string, attachmentId string) (ImplResponse, error) {
+	if _, _, err := s.getAttachment(ctx, entityType, entityId, attachmentId); err != nil {
+		return apiErrorResult(err)
+	}
+
+	sql, args, err := s.builder.
+		Update("attachments").
+		Set("removed_at", time.Now()).
+		Where(squirrel.Eq{"id": attachmentId, "removed_at": nil}).
+		ToSql()
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	tag, err := s.pg.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errorResult(ErrCodeAttachmentNotFound, nil)
+	}
+	return Response(http.StatusNoContent, nil), nil
+}
+
+func (s *AttachmentAPIService) getAttachment(ctx context.Context, entityType string, entityId string, attachmentId string) (*Attachment, string, error) {
+	attachmentIdUUID, err := s.ConvertIntoUUID(attachmentId)
+	if err != nil {
+		return nil, "", NewAPIError(ErrCodeInvalidID, err)
+	}
+
+	sql, args, err := s.builder.
+		Select(attachmentColumns...).
+		From("attachments a").
+		Where(squirrel.Eq{"a.id": attachmentIdUUID, "a.entity_type": entityType, "a.entity_id": entityId}).
+		ToSql()
+	if err != nil {
+		return nil, "", NewAPIError(ErrCodeInternal, err)
+	}
+
+	attachment, key, err := scanAttachment(s.pg.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", NewAPIError(ErrCodeAttachmentNotFound, err)
+		}
+		return nil, "", NewAPIError(ErrCodeInternal, err)
+	}
+	return &attachment, key, nil
+}
+
+var attachmentColumns = []string{
+	"a.id", "a.file_name", "a.content_type", "a.size", "a.sha256", "a.uploaded_by", "a.created_at", "a.storage_key",
+}
+
+func scanAttachment(row pgx.Row) (Attachment, string, error) {
+	var (
+		attachment Attachment
+		id         uuid.UUID
+		createdAt  time.Time
+		key        string
+	)
+	err := row.Scan(&id, &attachment.Name, &attachment.ContentType, &attachment.Size, &attachment.Sha256,
+		&attachment.UploadedBy, &createdAt, &key)
+	attachment.Id = id.String()
+	attachment.CreatedAt = createdAt.Format(time.RFC3339)
+	return attachment, key, err
+}
@@ -0,0 +1,218 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// AuctionAPIController binds auction requests to the auction service and writes the service results to the http response
+type AuctionAPIController struct {
+	service      AuctionAPIServicer
+	errorHandler ErrorHandler
+}
+
+// AuctionAPIOption for how the controller is set up.
+type AuctionAPIOption func(*AuctionAPIController)
+
+// WithAuctionAPIErrorHandler inject ErrorHandler into controller
+func WithAuctionAPIErrorHandler(h ErrorHandler) AuctionAPIOption {
+	return func(c *AuctionAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewAuctionAPIController creates an auction api controller
+func NewAuctionAPIController(s AuctionAPIServicer, opts ...AuctionAPIOption) *AuctionAPIController {
+	controller := &AuctionAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the AuctionAPIController
+func (c *AuctionAPIController) Routes() Routes {
+	return Routes{
+		"GetTenderAuction": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/auction",
+			c.GetTenderAuction,
+		},
+		"CreateTenderAuction": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/{tenderId}/auction",
+			c.CreateTenderAuction,
+		},
+		"PlaceAuctionOffer": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/{tenderId}/auction/offers",
+			c.PlaceAuctionOffer,
+		},
+		"FollowTenderAuction": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/auction/events",
+			c.FollowTenderAuction,
+		},
+	}
+}
+
+// GetTenderAuction - Текущее состояние аукциона
+func (c *AuctionAPIController) GetTenderAuction(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, false)
+	if !ok {
+		return
+	}
+	result, err := c.service.GetTenderAuction(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// CreateTenderAuction - Назначение аукциона на понижение цены по тендеру
+func (c *AuctionAPIController) CreateTenderAuction(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	createAuctionRequestParam := CreateAuctionRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&createAuctionRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertCreateAuctionRequestRequired(createAuctionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertCreateAuctionRequestConstraints(createAuctionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.CreateTenderAuction(r.Context(), tenderIdParam, usernameParam, createAuctionRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// PlaceAuctionOffer - Новая цена участника аукциона
+func (c *AuctionAPIController) PlaceAuctionOffer(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	auctionOfferRequestParam := AuctionOfferRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&auctionOfferRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertAuctionOfferRequestRequired(auctionOfferRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertAuctionOfferRequestConstraints(auctionOfferRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PlaceAuctionOffer(r.Context(), tenderIdParam, usernameParam, auctionOfferRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// FollowTenderAuction - Поток обновлений аукциона для участников
+func (c *AuctionAPIController) FollowTenderAuction(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	result, err := c.service.FollowTenderAuction(r.Context(), tenderIdParam, usernameParam)
+	if err == nil {
+		if feed, ok := result.Body.(*AuctionFeed); ok {
+			writeAuctionFeed(w, r, feed)
+			return
+		}
+	}
+	c.writeResult(w, r, result, err)
+}
+
+// writeAuctionFeed streams the auction as server-sent events: the current state first, then
+// every update until the auction finishes or the client goes away.
+func writeAuctionFeed(w http.ResponseWriter, r *http.Request, feed *AuctionFeed) {
+	defer feed.Stop()
+
+	rc := http.NewResponseController(w)
+	// The write timeout of the server is meant for ordinary responses, not for a stream
+	// that lasts as long as the auction.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(auction Auction) bool {
+		data, err := json.Marshal(auction)
+		if err != nil {
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: auction\ndata: %s\n\n", data); err != nil {
+			return false
+		}
+		return rc.Flush() == nil && auction.Status != AUCTION_FINISHED
+	}
+	if !send(feed.Current) {
+		return
+	}
+
+	heartbeat := time.NewTicker(auctionHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case auction, ok := <-feed.Updates:
+			if !ok || !send(auction) {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+// tenderParams reads the id of the tender from the path and the username from the query.
+func (c *AuctionAPIController) tenderParams(w http.ResponseWriter, r *http.Request, usernameRequired bool) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	tenderId := mux.Vars(r)["tenderId"]
+	if tenderId == "" {
+		c.errorHandler(w, r, &RequiredError{"tenderId"}, nil)
+		return "", "", false
+	}
+	if usernameRequired && !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return tenderId, query.Get("username"), true
+}
+
+func (c *AuctionAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
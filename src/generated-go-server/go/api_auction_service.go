@@ -0,0 +1,426 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AuctionAPIService runs reverse auctions on Delivery tenders. During the auction window the
+// authors of published bids offer successively lower prices; the end of the auction is a
+// Scheduler job that records the best offer as the winner and closes the tender. Every
+// accepted offer is published to the participants following the auction.
+type AuctionAPIService struct {
+	*DefaultAPIService
+	clock     Clock
+	scheduler *Scheduler
+	hub       *AuctionHub
+}
+
+// NewAuctionAPIService creates an auction api service
+func NewAuctionAPIService(pg *Postgres, clock Clock, scheduler *Scheduler, hub *AuctionHub, log *slog.Logger) *AuctionAPIService {
+	return &AuctionAPIService{
+		DefaultAPIService: NewDefaultAPIService(pg, log),
+		clock:             clock,
+		scheduler:         scheduler,
+		hub:               hub,
+	}
+}
+
+// CreateTenderAuction - Назначение аукциона на понижение цены по тендеру
+func (s *AuctionAPIService) CreateTenderAuction(ctx context.Context, tenderId string, username string, req CreateAuctionRequest) (ImplResponse, error) {
+	const op = "AuctionAPIService.CreateTenderAuction"
+	log := s.log.With(slog.String("op", op))
+
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	switch {
+	case tender.ServiceType != DELIVERY:
+		return errorDetailResult(ErrCodeValidationFailed, MsgAuctionDelivery)
+	case tender.Sealed:
+		return errorDetailResult(ErrCodeValidationFailed, MsgAuctionSealed)
+	case tender.Status == CLOSED:
+		return errorDetailResult(ErrCodeTenderClosed, MsgTenderClosed)
+	}
+
+	startsAt, endsAt := parseOptionalTime(req.StartsAt), parseOptionalTime(req.EndsAt)
+	if !startsAt.After(s.clock.Now()) {
+		return errorDetailResult(ErrCodeValidationFailed, MsgAuctionStartPast)
+	}
+	currency := req.MinDecrement.Currency
+	if tender.Budget != nil && tender.Budget.Currency != currency {
+		return errorDetailResult(ErrCodeValidationFailed, MsgPriceCurrencyMismatch, tender.Budget.Currency)
+	}
+	if req.StartPrice != nil {
+		if apiErr := checkBidPrice(*req.StartPrice, tender.Budget); apiErr != nil {
+			return apiErrorResult(apiErr)
+		}
+	}
+	window, extension := req.ExtensionWindowSeconds, req.ExtensionSeconds
+	if window == 0 {
+		window = defaultAuctionExtension
+	}
+	if extension == 0 {
+		extension = defaultAuctionExtension
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockTender(ctx, tx, tenderIdUUID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	hasLots, err := tenderHasLots(ctx, tx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if hasLots {
+		return errorDetailResult(ErrCodeValidationFailed, MsgAuctionLots)
+	}
+
+	var startPrice any
+	if req.StartPrice != nil {
+		startPrice = req.StartPrice.Amount.String()
+	}
+	auction, err := scanAuction(tx.QueryRow(ctx, `
+	INSERT INTO auctions (tender_id, starts_at, ends_at, currency, start_price, min_decrement,
+		extension_window_seconds, extension_seconds)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (tender_id) DO NOTHING
+	RETURNING `+auctionColumns,
+		tenderIdUUID, *startsAt, *endsAt, currency, startPrice, req.MinDecrement.Amount.String(), window, extension))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errorDetailResult(ErrCodeAlreadyExists, MsgAuctionExists)
+	}
+	if err != nil {
+		log.Error("failed to save the auction", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	s.scheduler.Schedule(auctionJobKey(tenderIdUUID), auction.EndsAt, s.finishJob(tenderIdUUID))
+	log.Info("auction scheduled", slog.String("tender_id", tender.Id), slog.Time("ends_at", auction.EndsAt))
+	return Response(http.StatusCreated, auction.toAuction(s.clock.Now())), nil
+}
+
+// GetTenderAuction - Текущее состояние аукциона
+func (s *AuctionAPIService) GetTenderAuction(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	auction, err := s.getAuction(ctx, tenderIdUUID)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return Response(http.StatusOK, auction.toAuction(s.clock.Now())), nil
+}
+
+// PlaceAuctionOffer - Новая цена участника аукциона
+func (s *AuctionAPIService) PlaceAuctionOffer(ctx context.Context, tenderId string, username string, req AuctionOfferRequest) (ImplResponse, error) {
+	const op = "AuctionAPIService.PlaceAuctionOffer"
+	log := s.log.With(slog.String("op", op))
+
+	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bid, err := s.loadBid(ctx, req.BidId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if bid.TenderId != tenderIdUUID.String() {
+		return errorDetailResult(ErrCodeInvalidParameter, MsgAuctionBidTender)
+	}
+	if err := s.checkBidAuthor(ctx, user, bid); err != nil {
+		return apiErrorResult(err)
+	}
+	if bid.Status != PUBLISHED_BID {
+		return errorDetailResult(ErrCodeInvalidStatus, MsgAuctionBidNotPublished)
+	}
+	tender, err := s.loadTender(ctx, bid.TenderId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bidIdUUID, _ := s.ConvertIntoUUID(bid.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The tender lock orders offers with each other and with the end of the auction.
+	tenderStatus, err := lockTender(ctx, tx, tenderIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	if tenderStatus == CLOSED {
+		return errorDetailResult(ErrCodeTenderClosed, MsgTenderClosed)
+	}
+	auction, err := scanAuction(tx.QueryRow(ctx, `SELECT `+auctionColumns+` FROM auctions WHERE tender_id = $1`, tenderIdUUID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errorResult(ErrCodeAuctionNotFound, err)
+	}
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	now := s.clock.Now()
+	if apiErr := auction.checkOffer(req.Price, now); apiErr != nil {
+		return apiErrorResult(apiErr)
+	}
+	if apiErr := checkBidPrice(req.Price, tender.Budget); apiErr != nil {
+		return apiErrorResult(apiErr)
+	}
+
+	price := req.Price.Amount.String()
+	if _, err := tx.Exec(ctx, `
+	INSERT INTO auction_offers (tender_id, bid_id, price, placed_by, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		tenderIdUUID, bidIdUUID, price, user.Id, now); err != nil {
+		log.Error("failed to save the offer", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	// The bid takes the price without a new version: the offers are its price history.
+	if _, err := tx.Exec(ctx, `
+	UPDATE bids SET price_amount = $2, price_currency = $3 WHERE bid_id = $1`,
+		bidIdUUID, price, req.Price.Currency); err != nil {
+		log.Error("failed to update the bid price", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	endsAt := auction.extendedEnd(now)
+	auction, err = scanAuction(tx.QueryRow(ctx, `
+	UPDATE auctions SET best_price = $2, best_bid_id = $3, offer_count = offer_count + 1, ends_at = $4
+	WHERE tender_id = $1
+	RETURNING `+auctionColumns, tenderIdUUID, price, bidIdUUID, endsAt))
+	if err != nil {
+		log.Error("failed to update the auction", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	key := auctionJobKey(tenderIdUUID)
+	if next, ok := s.scheduler.Next(key); !ok || !next.Equal(auction.EndsAt) {
+		s.scheduler.Schedule(key, auction.EndsAt, s.finishJob(tenderIdUUID))
+		log.Info("auction extended", slog.String("tender_id", tender.Id), slog.Time("ends_at", auction.EndsAt))
+	}
+
+	state := auction.toAuction(now)
+	s.hub.Publish(state)
+	return Response(http.StatusOK, state), nil
+}
+
+// FollowTenderAuction - Поток обновлений аукциона для участников
+func (s *AuctionAPIService) FollowTenderAuction(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	if rightsErr := s.checkTenderRights(ctx, user, tender); rightsErr != nil {
+		bidder, err := s.isTenderBidder(ctx, tenderIdUUID, user.Id)
+		if err != nil {
+			return errorResult(ErrCodeInternal, err)
+		}
+		if !bidder {
+			return errorDetailResult(ErrCodeForbiddenNotAuthor, MsgAuctionNotParticipant)
+		}
+	}
+
+	// Following before reading the state loses no offer made in between.
+	updates, stop := s.hub.Follow(tender.Id)
+	auction, err := s.getAuction(ctx, tenderIdUUID)
+	if err != nil {
+		stop()
+		return apiErrorResult(err)
+	}
+	return Response(http.StatusOK, &AuctionFeed{
+		Current: auction.toAuction(s.clock.Now()),
+		Updates: updates,
+		Stop:    stop,
+	}), nil
+}
+
+// ScheduleAuctions schedules the end of every auction that has not finished. The schedule is
+// kept in memory, so it is rebuilt on start; auctions that ended while the server was down
+// are finished right away.
+func (s *AuctionAPIService) ScheduleAuctions(ctx context.Context) error {
+	rows, err := s.pg.Pool.Query(ctx, `SELECT `+auctionColumns+` FROM auctions WHERE finished_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	auctions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (auctionRow, error) {
+		return scanAuction(row)
+	})
+	if err != nil {
+		return err
+	}
+	for _, auction := range auctions {
+		s.scheduler.Schedule(auctionJobKey(auction.TenderId), auction.EndsAt, s.finishJob(auction.TenderId))
+	}
+	s.log.Info("auctions scheduled", slog.Int("count", len(auctions)))
+	return nil
+}
+
+func (s *AuctionAPIService) finishJob(tenderId uuid.UUID) JobFunc {
+	return func(ctx context.Context) error {
+		return s.finishAuction(ctx, tenderId)
+	}
+}
+
+// finishAuction records the result of the auction once it has ended. The lowest offer wins:
+// its bid is approved and the tender closed. An auction without offers finishes without a winner
+// and leaves the tender open for the usual decisions.
+func (s *AuctionAPIService) finishAuction(ctx context.Context, tenderId uuid.UUID) error {
+	log := s.log.With(slog.String("op", "AuctionAPIService.finishAuction"), slog.String("tender_id", tenderId.String()))
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockTender(ctx, tx, tenderId); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	auction, err := scanAuction(tx.QueryRow(ctx, `SELECT `+auctionColumns+` FROM auctions WHERE tender_id = $1`, tenderId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if auction.FinishedAt != nil {
+		return nil
+	}
+	now := s.clock.Now()
+	if now.Before(auction.EndsAt) {
+		// Extended by an offer this scheduler has not seen, e.g. one placed on another instance.
+		s.scheduler.Schedule(auctionJobKey(tenderId), auction.EndsAt, s.finishJob(tenderId))
+		return nil
+	}
+
+	// The best offer of a bid that is still published wins: a bid withdrawn during the
+	// auction gives way to the next best one.
+	var winner *uuid.UUID
+	err = tx.QueryRow(ctx, `
+	SELECT o.bid_id FROM auction_offers o JOIN bids b ON b.bid_id = o.bid_id
+	WHERE o.tender_id = $1 AND b.status = $2
+	ORDER BY o.price, o.id
+	LIMIT 1`, tenderId, string(PUBLISHED_BID)).Scan(&winner)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	auction, err = scanAuction(tx.QueryRow(ctx, `
+	UPDATE auctions SET finished_at = $2, winning_bid_id = $3 WHERE tender_id = $1
+	RETURNING `+auctionColumns, tenderId, now, winner))
+	if err != nil {
+		return err
+	}
+	if winner != nil {
+		if err := approveAuctionWinner(ctx, tx, tenderId, *winner); err != nil {
+			return err
+		}
+	}
+
+	state := auction.toAuction(now)
+	if err := recordEvent(ctx, tx, eventAuctionFinished, tenderId, map[string]any{
+		"tenderId":     tenderId,
+		"winningBidId": state.WinningBidId,
+		"bestPrice":    state.BestPrice,
+		"offerCount":   state.OfferCount,
+	}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	log.Info("auction finished", slog.String("winning_bid_id", state.WinningBidId), slog.Int("offers", int(state.OfferCount)))
+	s.hub.Publish(state)
+	return nil
+}
+
+// approveAuctionWinner approves the winning bid the way a decision of a responsible would, in
+// the transaction that finishes the auction: it is counted in the reputation of the author,
+// the author is notified, and the tender is closed.
+func approveAuctionWinner(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID, bidId uuid.UUID) error {
+	var authorType BidAuthorType
+	var authorId uuid.UUID
+	if err := tx.QueryRow(ctx, `SELECT author_type, author_id FROM bids WHERE bid_id = $1`, bidId).Scan(&authorType, &authorId); err != nil {
+		return err
+	}
+
+	// decided_by stays empty: the decision is made by the auction, not by a responsible.
+	if _, err := tx.Exec(ctx, `
+	INSERT INTO bid_decisions (bid_id, decision) VALUES ($1, $2)`, bidId, string(APPROVED)); err != nil {
+		return err
+	}
+	if err := refreshReputation(ctx, tx, bidId); err != nil {
+		return err
+	}
+	if err := recordEvent(ctx, tx, eventBidApproved, bidId, bidEventPayload(tenderId, bidId, authorType, authorId.String())); err != nil {
+		return err
+	}
+	_, err := closeTenderIfSettled(ctx, tx, tenderId)
+	return err
+}
+
+// getAuction returns the auction of the tender or an AUCTION_NOT_FOUND error.
+func (s *AuctionAPIService) getAuction(ctx context.Context, tenderId uuid.UUID) (*auctionRow, error) {
+	auction, err := scanAuction(s.pg.Pool.QueryRow(ctx, `SELECT `+auctionColumns+` FROM auctions WHERE tender_id = $1`, tenderId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, NewAPIError(ErrCodeAuctionNotFound, ErrNotFound)
+	}
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return &auction, nil
+}
+
+// isTenderBidder reports whether the user wrote a bid on the tender, alone or for an
+// organization.
+func (s *AuctionAPIService) isTenderBidder(ctx context.Context, tenderId uuid.UUID, userId uuid.UUID) (bool, error) {
+	var bidder bool
+	err := s.pg.Pool.QueryRow(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM bids
+		WHERE tender_id = $1 AND (author_id = $2 OR author_id IN (
+			SELECT organization_id FROM organization_responsible WHERE user_id = $2)))`,
+		tenderId, userId).Scan(&bidder)
+	return bidder, err
+}
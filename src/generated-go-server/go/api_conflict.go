@@ -0,0 +1,119 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// ConflictAPIController binds conflict of interest requests to the conflict service and writes the service results to the http response
+type ConflictAPIController struct {
+	service      ConflictAPIServicer
+	errorHandler ErrorHandler
+}
+
+// ConflictAPIOption for how the controller is set up.
+type ConflictAPIOption func(*ConflictAPIController)
+
+// WithConflictAPIErrorHandler inject ErrorHandler into controller
+func WithConflictAPIErrorHandler(h ErrorHandler) ConflictAPIOption {
+	return func(c *ConflictAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewConflictAPIController creates a conflict api controller
+func NewConflictAPIController(s ConflictAPIServicer, opts ...ConflictAPIOption) *ConflictAPIController {
+	controller := &ConflictAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the ConflictAPIController
+func (c *ConflictAPIController) Routes() Routes {
+	return Routes{
+		"GetTenderConflicts": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/conflicts",
+			c.GetTenderConflicts,
+		},
+		"SetTenderConflictPolicy": Route{
+			strings.ToUpper("Put"),
+			"/api/tenders/{tenderId}/conflicts/policy",
+			c.SetTenderConflictPolicy,
+		},
+	}
+}
+
+// GetTenderConflicts - Отмеченные конфликты интересов по тендеру
+func (c *ConflictAPIController) GetTenderConflicts(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r)
+	if !ok {
+		return
+	}
+	result, err := c.service.GetTenderConflicts(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// SetTenderConflictPolicy - Правило проверки конфликта интересов по тендеру
+func (c *ConflictAPIController) SetTenderConflictPolicy(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r)
+	if !ok {
+		return
+	}
+	tenderConflictPolicyParam := TenderConflictPolicy{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&tenderConflictPolicyParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertTenderConflictPolicyRequired(tenderConflictPolicyParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertTenderConflictPolicyConstraints(tenderConflictPolicyParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.SetTenderConflictPolicy(r.Context(), tenderIdParam, usernameParam, tenderConflictPolicyParam)
+	c.writeResult(w, r, result, err)
+}
+
+// tenderParams reads the tender id from the path and the required username from the query.
+func (c *ConflictAPIController) tenderParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	tenderIdParam := mux.Vars(r)["tenderId"]
+	if tenderIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"tenderId"}, nil)
+		return "", "", false
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return tenderIdParam, query.Get("username"), true
+}
+
+func (c *ConflictAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ConflictAPIService exposes the conflict of interest checks of a tender to its responsibles:
+// the policy that CreateBid and SubmitBidDecision apply, and the conflicts flagged under it.
+type ConflictAPIService struct {
+	*DefaultAPIService
+}
+
+// NewConflictAPIService creates a conflict api service
+func NewConflictAPIService(pg *Postgres, log *slog.Logger) *ConflictAPIService {
+	return &ConflictAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// GetTenderConflicts - Отмеченные конфликты интересов по тендеру
+func (s *ConflictAPIService) GetTenderConflicts(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+conflictColumns+` FROM tender_conflicts c
+	WHERE c.tender_id = $1
+	ORDER BY c.created_at, c.id`, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	locale := LocaleFromContext(ctx)
+	conflicts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (TenderConflict, error) {
+		return scanConflict(row, locale)
+	})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if conflicts == nil {
+		conflicts = []TenderConflict{}
+	}
+	return Response(http.StatusOK, conflicts), nil
+}
+
+// SetTenderConflictPolicy - Правило проверки конфликта интересов по тендеру
+func (s *ConflictAPIService) SetTenderConflictPolicy(ctx context.Context, tenderId string, username string, req TenderConflictPolicy) (ImplResponse, error) {
+	const op = "ConflictAPIService.SetTenderConflictPolicy"
+	log := s.log.With(slog.String("op", op))
+
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	// The policy is a setting of the tender rather than part of its terms: changing it does
+	// not make a new version.
+	var policy TenderConflictPolicy
+	if err := s.pg.Pool.QueryRow(ctx, `
+	UPDATE tenders SET conflict_policy = $2 WHERE id = $1 RETURNING conflict_policy`,
+		tenderIdUUID, string(req.Policy)).Scan(&policy.Policy); err != nil {
+		log.Error("failed to save the policy", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	log.Info("conflict policy changed", slog.String("tender_id", tender.Id), slog.String("policy", string(policy.Policy)))
+	return Response(http.StatusOK, policy), nil
+}
@@ -469,7 +469,17 @@
 		 var param int32 = 0
 		 offsetParam = param
 	 }
-	 result, err := c.service.GetBidsForTender(r.Context(), tenderIdParam, usernameParam, limitParam, offsetParam)
+	 sortParam := BID_SORT_CREATED_AT
+	 if query.Has("sort") {
+		 param, err := NewBidSortFromValue(query.Get("sort"))
+		 if err != nil {
+			 c.errorHandler(w, r, &ParsingError{Param: "sort", Err: NewLocalizedError(MsgOneOf, bidSortValues)}, nil)
+			 return
+		 }
+ 
+		 sortParam = param
+	 }
+	 result, err := c.service.GetBidsForTender(r.Context(), tenderIdParam, usernameParam, limitParam, offsetParam, sortParam)
 	 // If an error occurred, encode the error with the status code
 	 if err != nil {
 		 c.errorHandler(w, r, err, &result)
@@ -553,7 +563,10 @@
 	 }
 	 var serviceTypeParam []TenderServiceType
 	 if query.Has("service_type") {
-		 paramSplits := strings.Split(query.Get("service_type"), ",")
+		 var paramSplits []string
+		 for _, value := range query["service_type"] {
+			 paramSplits = append(paramSplits, strings.Split(value, ",")...)
+		 }
 		 serviceTypeParam = make([]TenderServiceType, 0, len(paramSplits))
 		 for _, param := range paramSplits {
 			 paramEnum, err := NewTenderServiceTypeFromValue(param)
@@ -808,7 +821,11 @@
 		 c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
 		 return
 	 }
-	 result, err := c.service.SubmitBidDecision(r.Context(), bidIdParam, decisionParam, usernameParam)
+	 var lotIdParam string
+	 if query.Has("lotId") {
+		 lotIdParam = query.Get("lotId")
+	 }
+	 result, err := c.service.SubmitBidDecision(r.Context(), bidIdParam, decisionParam, usernameParam, lotIdParam)
 	 // If an error occurred, encode the error with the status code
 	 if err != nil {
 		 c.errorHandler(w, r, err, &result)
@@ -840,6 +857,10 @@
 		 c.errorHandler(w, r, &RequiredError{Field: "bidFeedback"}, nil)
 		 return
 	 }
+	 if len([]rune(bidFeedbackParam)) > maxFeedbackLength {
+		 c.errorHandler(w, r, &ParsingError{Param: "bidFeedback", Err: NewLocalizedError(MsgMaxLength, maxFeedbackLength)}, nil)
+		 return
+	 }
 	 var usernameParam string
 	 if query.Has("username") {
 		 param := query.Get("username")
@@ -849,7 +870,34 @@
 		 c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
 		 return
 	 }
-	 result, err := c.service.SubmitBidFeedback(r.Context(), bidIdParam, bidFeedbackParam, usernameParam)
+	 var ratingParam int32
+	 if query.Has("rating") {
+		 param, err := parseNumericParameter[int32](
+			 query.Get("rating"),
+			 WithParse[int32](parseInt32),
+			 WithMinimum[int32](1),
+			 WithMaximum[int32](5),
+		 )
+		 if err != nil {
+			 c.errorHandler(w, r, &ParsingError{Param: "rating", Err: NewLocalizedError(MsgFeedbackRating)}, nil)
+			 return
+		 }
+ 
+		 ratingParam = param
+	 }
+	 var tagsParam []FeedbackTag
+	 if query.Has("tags") {
+		 for _, value := range query["tags"] {
+			 for _, param := range strings.Split(value, ",") {
+				 tagsParam = append(tagsParam, FeedbackTag(param))
+			 }
+		 }
+		 if err := checkFeedbackTags(tagsParam); err != nil {
+			 c.errorHandler(w, r, &ParsingError{Param: "tags", Err: err}, nil)
+			 return
+		 }
+	 }
+	 result, err := c.service.SubmitBidFeedback(r.Context(), bidIdParam, bidFeedbackParam, usernameParam, ratingParam, tagsParam)
 	 // If an error occurred, encode the error with the status code
 	 if err != nil {
 		 c.errorHandler(w, r, err, &result)
@@ -14,8 +14,9 @@ import (
 	"context"
 	"errors"
 	"github.com/Masterminds/squirrel"
-	"github.com/jackc/pgx/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"log/slog"
 	"net/http"
 	"time"
@@ -28,21 +29,38 @@ type DefaultAPIService struct {
 	pg      *Postgres
 	log     *slog.Logger
 	builder squirrel.StatementBuilderType
+	sealer  *Sealer
+}
+
+// DefaultAPIServiceOption for how the service is set up.
+type DefaultAPIServiceOption func(*DefaultAPIService)
+
+// WithSealer enables sealed tenders, whose bids are stored encrypted by sealer.
+func WithSealer(sealer *Sealer) DefaultAPIServiceOption {
+	return func(s *DefaultAPIService) {
+		s.sealer = sealer
+	}
 }
 
 // NewDefaultAPIService creates a default api service
-func NewDefaultAPIService(pg *Postgres, log *slog.Logger) *DefaultAPIService {
-	return &DefaultAPIService{
+func NewDefaultAPIService(pg *Postgres, log *slog.Logger, opts ...DefaultAPIServiceOption) *DefaultAPIService {
+	service := &DefaultAPIService{
 		pg:      pg,
 		log:     log,
 		builder: pg.Builder,
 	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
 }
 
 // CheckServer - Проверка доступности сервера (good)
 func (s *DefaultAPIService) CheckServer(ctx context.Context) (ImplResponse, error) {
 	if err := s.pg.Pool.Ping(ctx); err != nil {
-		return Response(http.StatusInternalServerError, nil), nil
+		return errorResult(ErrCodeServiceUnavailable, err)
 	}
 	return Response(http.StatusOK, "ok"), nil
 }
@@ -56,78 +74,124 @@ func (s *DefaultAPIService) CreateBid(ctx context.Context, createBidRequest Crea
 	tenderId, _ := s.ConvertIntoUUID(createBidRequest.TenderId)
 	authorId, _ := s.ConvertIntoUUID(createBidRequest.AuthorId)
 
-	_, err := s.getTenderById(ctx, tenderId)
+	tender, err := s.getTenderById(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Тендер не найден"}), nil
+			return errorResult(ErrCodeTenderNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), nil
+		return errorResult(ErrCodeInternal, err)
 	}
 
-	if createBidRequest.AuthorType == USER {
-		_, err := s.getUserById(ctx, authorId)
-		if err != nil {
-			if errors.Is(err, ErrNoUser) {
-				return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
-			}
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
-		}
-		err1, err2 := s.userHasRights(ctx, authorId, tenderId)
-		if err1 != nil {
-			if errors.Is(err1, ErrUserNoRightsTender) {
-				return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для выполнения действия"}), nil
-			}
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err2.Error()}), err2
-		}
+	lots, err := s.getTenderLots(ctx, tenderId)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if apiErr := checkBidLots(lots, createBidRequest.LotIds, createBidRequest.Price, tender.Budget); apiErr != nil {
+		return apiErrorResult(apiErr)
+	}
 
-	} else if createBidRequest.AuthorType == ORGANIZATION {
-		_, err := s.getOrganizationById(ctx, authorId)
-		if err != nil {
-			if errors.Is(err, ErrNoOrganization) {
-				return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Организация не существует или некорректна"}), nil
-			}
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+	if apiErr := s.checkBidder(ctx, tender, createBidRequest.AuthorType, authorId); apiErr != nil {
+		return apiErrorResult(apiErr)
+	}
+
+	// The id is chosen here since sealed contents are bound to it.
+	newBidID := uuid.New()
+	name, description := createBidRequest.Name, createBidRequest.Description
+	if tender.Sealed {
+		if s.sealer == nil {
+			return errorDetailResult(ErrCodeValidationFailed, MsgSealingDisabled)
 		}
-		if err := s.organizationHasRights(ctx, authorId, tenderId); err != nil {
-			if errors.Is(err, ErrOrgNoRightsTender) {
-				return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для выполнения действия"}), nil
-			}
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		if err := s.sealer.sealBid(newBidID, &name, &description); err != nil {
+			log.Error("Failed to seal bid", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
 		}
-
 	}
 
 	currentTime := time.Now()
 	rfc3339Time := currentTime.Format(time.RFC3339)
+	priceAmount, priceCurrency := moneyValues(&createBidRequest.Price)
 
 	sql, args, err := s.builder.
 		Insert("bids").
-		Columns("name", "description", "status", "tender_id", "author_type", "author_id", "created_at").
-		Values(createBidRequest.Name, createBidRequest.Description, CREATED, createBidRequest.TenderId, createBidRequest.AuthorType, createBidRequest.AuthorId, rfc3339Time).
-		Suffix("RETURNING bid_id, created_at").
+		Columns("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "created_at", "price_amount", "price_currency").
+		Values(newBidID, name, description, CREATED, createBidRequest.TenderId, createBidRequest.AuthorType, createBidRequest.AuthorId, rfc3339Time, priceAmount, priceCurrency).
+		Suffix("RETURNING created_at").
 		ToSql()
 
 	if err != nil {
 		log.Error("SQL generation failed", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Internal server error"}), nil
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkSubmissionOpen(ctx, tx, tenderId); err != nil {
+		return apiErrorResult(err)
+	}
+	conflicts, apiErr := checkBidConflicts(ctx, tx, tender, createBidRequest.AuthorType, authorId)
+	if apiErr != nil {
+		return apiErrorResult(apiErr)
 	}
 
-	var newBidID uuid.UUID
 	var createdAt time.Time
-	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&newBidID, &createdAt)
+	err = tx.QueryRow(ctx, sql, args...).Scan(&createdAt)
 	if err != nil {
 		log.Error("Database execution failed", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Internal server error"}), nil
+		return errorResult(ErrCodeInternal, err)
 	}
 
-	bidResponse:= Bid{
-		Id:          s.ConvertFromUUID(newBidID),
-		Name:        createBidRequest.Name,
-		Status:      CREATED_BID,
-		AuthorType:  createBidRequest.AuthorType,
-		AuthorId:    createBidRequest.AuthorId,
-		Version:     1,
-		CreatedAt:   rfc3339Time,
+	if len(createBidRequest.LotIds) > 0 {
+		lotsBuilder := s.builder.Insert("bid_lots").Columns("bid_id", "lot_id")
+		for _, lotId := range createBidRequest.LotIds {
+			lotsBuilder = lotsBuilder.Values(newBidID, lotId)
+		}
+		sql, args, err := lotsBuilder.ToSql()
+		if err != nil {
+			return errorResult(ErrCodeInternal, err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			log.Error("Failed to save bid lots", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+	}
+
+	if err := refreshReputation(ctx, tx, newBidID); err != nil {
+		log.Error("Failed to count the bid in the reputation", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if err := recordConflicts(ctx, tx, tenderId, newBidID, conflicts); err != nil {
+		log.Error("Failed to flag the conflicts of interest", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if len(conflicts) > 0 {
+		log.Warn("bid flagged for a conflict of interest", slog.String("bid_id", newBidID.String()), slog.Int("conflicts", len(conflicts)))
+	}
+
+	if err := recordEvent(ctx, tx, eventBidCreated, newBidID,
+		bidEventPayload(tenderId, newBidID, createBidRequest.AuthorType, createBidRequest.AuthorId)); err != nil {
+		log.Error("Failed to record the event", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	bidResponse := Bid{
+		Id:         s.ConvertFromUUID(newBidID),
+		Name:       createBidRequest.Name,
+		Status:     CREATED_BID,
+		AuthorType: createBidRequest.AuthorType,
+		AuthorId:   createBidRequest.AuthorId,
+		Price:      &createBidRequest.Price,
+		LotIds:     createBidRequest.LotIds,
+		Version:    1,
+		CreatedAt:  rfc3339Time,
 	}
 
 	return Response(http.StatusOK, bidResponse), nil
@@ -140,35 +204,48 @@ func (s *DefaultAPIService) CreateTender(ctx context.Context, createTenderReques
 
 	orgId, _ := s.ConvertIntoUUID(createTenderRequest.OrganizationId)
 
+	if createTenderRequest.Sealed && s.sealer == nil {
+		return errorDetailResult(ErrCodeValidationFailed, MsgSealingDisabled)
+	}
+	if deadlinePassed(createTenderRequest.SubmissionDeadline, time.Now()) {
+		return errorDetailResult(ErrCodeValidationFailed, MsgDeadlinePast)
+	}
+	deadline := parseOptionalTime(createTenderRequest.SubmissionDeadline)
+	conflictPolicy := createTenderRequest.ConflictPolicy
+	if conflictPolicy == "" {
+		conflictPolicy = CONFLICT_POLICY_OFF
+	}
+
 	_, err := s.getUserByName(ctx, createTenderRequest.CreatorUsername)
 	if err != nil {
 		log.Error("no user by name", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	if err := s.userBelongsToOrganization(ctx, createTenderRequest.CreatorUsername, orgId); err != nil {
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для выполнения действия"}), nil
+			return errorResult(ErrCodeForbiddenNotResponsible, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	currentTime := time.Now()
 	rfc3339Time := currentTime.Format(time.RFC3339)
+	budgetAmount, budgetCurrency := moneyValues(createTenderRequest.Budget)
 
 	sql, args, err := s.builder.
 		Insert("tenders").
-		Columns("name", "description", "status", "service_type", "organization_id", "version", "creator_username", "created_at").
-		Values(createTenderRequest.Name, createTenderRequest.Description, CREATED, createTenderRequest.ServiceType, orgId, 1, createTenderRequest.CreatorUsername, rfc3339Time).
+		Columns("name", "description", "status", "service_type", "organization_id", "version", "creator_username", "created_at", "budget_amount", "budget_currency", "sealed", "submission_deadline", "private", "conflict_policy").
+		Values(createTenderRequest.Name, createTenderRequest.Description, CREATED, createTenderRequest.ServiceType, orgId, 1, createTenderRequest.CreatorUsername, rfc3339Time, budgetAmount, budgetCurrency, createTenderRequest.Sealed, deadline, createTenderRequest.Private, conflictPolicy).
 		Suffix("RETURNING id, created_at").
 		ToSql()
 
 	if err != nil {
 		log.Error("Failed to build SQL", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	var id uuid.UUID
@@ -176,23 +253,28 @@ func (s *DefaultAPIService) CreateTender(ctx context.Context, createTenderReques
 	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&id, &createdAt)
 	if err != nil {
 		log.Error("Failed to execute SQL", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to create tender"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	tenderResponse := Tender{
-		Id:             s.ConvertFromUUID(id),
-		Name:           createTenderRequest.Name,
-		Description:    createTenderRequest.Description,
-		ServiceType:    createTenderRequest.ServiceType,
-		Status:         CREATED,
-		OrganizationId: createTenderRequest.OrganizationId,
-		Version:        1,
-		CreatedAt:      rfc3339Time,
+		Id:                 s.ConvertFromUUID(id),
+		Name:               createTenderRequest.Name,
+		Description:        createTenderRequest.Description,
+		ServiceType:        createTenderRequest.ServiceType,
+		Status:             CREATED,
+		OrganizationId:     createTenderRequest.OrganizationId,
+		Budget:             createTenderRequest.Budget,
+		Sealed:             createTenderRequest.Sealed,
+		SubmissionDeadline: formatOptionalTime(deadline),
+		Private:            createTenderRequest.Private,
+		ConflictPolicy:     conflictPolicy,
+		Version:            1,
+		CreatedAt:          rfc3339Time,
 	}
 
 	if err = s.addVersionTableTender(ctx, createTenderRequest.CreatorUsername, &tenderResponse); err != nil {
 		log.Error("Failed to add tender to the version table", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to add tender to the version table "}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 	return Response(http.StatusOK, tenderResponse), nil
 }
@@ -205,23 +287,23 @@ func (s *DefaultAPIService) EditBid(ctx context.Context, bidId string, username
 	user, err := s.getUserByName(ctx, username)
 	if err != nil {
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	bidIdUUID, _ := s.ConvertIntoUUID(bidId)
 	bid, err := s.getBidById(ctx, bidIdUUID)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Предложение не найдено"}), err
+			return errorResult(ErrCodeBidNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	if err = s.addVersionTableBid(ctx, *bid); err != nil {
 		log.Error("Failed to add tender to the version table", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to add tender to the version table "}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	if bid.AuthorType == USER {
@@ -230,40 +312,86 @@ func (s *DefaultAPIService) EditBid(ctx context.Context, bidId string, username
 				slog.Any("user", s.ConvertFromUUID(user.Id)),
 				slog.Any("bid", bid.AuthorId))
 
-			return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для выполнения действия"}), err
+			return errorResult(ErrCodeForbiddenNotAuthor, err)
 		}
 	} else {
 		orgIdUUID, _ := s.ConvertIntoUUID(bid.AuthorId)
 		if err := s.userBelongsToOrganization(ctx, user.Username, orgIdUUID); err != nil {
 			if errors.Is(err, ErrNotFound) {
-				return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для выполнения действия"}), err
+				return errorResult(ErrCodeForbiddenNotAuthor, err)
 			}
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+			return errorResult(ErrCodeInternal, err)
 		}
 	}
 
+	tenderIdUUID, _ := s.ConvertIntoUUID(bid.TenderId)
+	tender, err := s.getTenderById(ctx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
 	sqlBuilder := s.builder.Update("bids").Set("version", bid.Version+1)
 
-	if editBidRequest.Name != "" {
-		sqlBuilder = sqlBuilder.Set("name", editBidRequest.Name)
+	name, description := editBidRequest.Name, editBidRequest.Description
+	if tender.Sealed {
+		if s.sealer == nil {
+			return errorDetailResult(ErrCodeValidationFailed, MsgSealingDisabled)
+		}
+		if err := s.sealer.sealBid(bidIdUUID, &name, &description); err != nil {
+			log.Error("Failed to seal bid", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+	}
+	if name != "" {
+		sqlBuilder = sqlBuilder.Set("name", name)
 	}
-	if editBidRequest.Description != "" {
-		sqlBuilder = sqlBuilder.Set("description", editBidRequest.Description)
+	if description != "" {
+		sqlBuilder = sqlBuilder.Set("description", description)
+	}
+	if editBidRequest.Price != nil {
+		if apiErr := checkBidPrice(*editBidRequest.Price, tender.Budget); apiErr != nil {
+			return apiErrorResult(apiErr)
+		}
+		// On an auctioned tender the price moves only through offers, which enforce the decrement.
+		var auctioned bool
+		if err := s.pg.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auctions WHERE tender_id = $1)`, tenderIdUUID).Scan(&auctioned); err != nil {
+			return errorResult(ErrCodeInternal, err)
+		}
+		if auctioned {
+			return errorDetailResult(ErrCodeValidationFailed, MsgAuctionPriceEdit)
+		}
+		amount, currency := moneyValues(editBidRequest.Price)
+		sqlBuilder = sqlBuilder.Set("price_amount", amount).Set("price_currency", currency)
 	}
 
 	sql, args, err := sqlBuilder.Where(squirrel.Eq{"bid_id": bidIdUUID}).ToSql()
 	if err != nil {
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to edit bid."}), nil
+		return errorResult(ErrCodeInternal, err)
 	}
 
-	_, err = s.pg.Pool.Exec(ctx, sql, args...)
+	tx, err := s.pg.Pool.Begin(ctx)
 	if err != nil {
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to edit bid."}), nil
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkSubmissionOpen(ctx, tx, tenderIdUUID); err != nil {
+		return apiErrorResult(err)
+	}
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	updatedBid, err := s.getBidById(ctx, bidIdUUID)
 	if err != nil {
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get updated bid"}), nil
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := s.unsealBid(updatedBid); err != nil {
+		log.Error("Failed to unseal bid", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	return Response(http.StatusOK, updatedBid), nil
@@ -274,44 +402,43 @@ func (s *DefaultAPIService) EditTender(ctx context.Context, tenderId string, use
 	const op = "EditTender"
 	log := s.log.With(slog.String("op", op))
 
-
 	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
 	if err != nil {
 		log.Error("Invalid tenderId format", slog.Any("error", err))
-		return Response(http.StatusBadRequest, ErrorResponse{Reason: "Invalid ID format. Must be UUID formatted"}), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
 	user, err := s.getUserByName(ctx, username)
 	if err != nil {
 		log.Error("no user by name", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
 	if err1 != nil {
 		if errors.Is(err1, ErrUserNoRightsTender) {
 			log.Error("user does not have rights for the tender", slog.Any("error", err2))
-			return Response(http.StatusForbidden, ErrorResponse{"Недостаточно прав для выполнения действия"}), err2
+			return errorResult(ErrCodeForbiddenNotResponsible, err1)
 		}
 		log.Error("error to find a user sql error", slog.Any("error", err2))
-		return Response(http.StatusInternalServerError, ErrorResponse{"couldn't process an sql query"}), err2
+		return errorResult(ErrCodeInternal, err2)
 	}
 
 	oldTender, err := s.getTenderById(ctx, tenderIdUUID)
 	if err != nil {
 		log.Error("Failed to get oldTender", slog.Any("error", err))
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), err
+			return errorResult(ErrCodeTenderNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get oldTender."}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	if err = s.addVersionTableTender(ctx, username, oldTender); err != nil {
 		log.Error("Failed to add tender to the version table", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to add tender to the version table "}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	queryBuilder := s.builder.
@@ -329,26 +456,30 @@ func (s *DefaultAPIService) EditTender(ctx context.Context, tenderId string, use
 	if editTenderRequest.ServiceType != "" {
 		queryBuilder = queryBuilder.Set("service_type", editTenderRequest.ServiceType)
 	}
+	if editTenderRequest.Budget != nil {
+		amount, currency := moneyValues(editTenderRequest.Budget)
+		queryBuilder = queryBuilder.Set("budget_amount", amount).Set("budget_currency", currency)
+	}
 
 	query, args, err := queryBuilder.Suffix("RETURNING id, name, description, service_type, status, organization_id, creator_username, created_at").ToSql()
 	if err != nil {
 		log.Error("error to build a query", slog.Any("error", err))
-		return Response(http.StatusNotFound, ErrorResponse{err.Error()}), ErrSQLQuery
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	_, err = s.pg.Pool.Exec(ctx, query, args...)
 	if err != nil {
 		log.Error("error to build a query pool", slog.Any("error", err))
-		return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), ErrSQLQuery
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	newTender, err := s.getTenderById(ctx, tenderIdUUID)
 	if err != nil {
 		log.Error("Failed to get New Tender", slog.Any("error", err))
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), nil
+			return errorResult(ErrCodeTenderNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get oldTender."}), nil
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	return Response(http.StatusOK, newTender), nil
@@ -363,32 +494,32 @@ func (s *DefaultAPIService) GetBidReviews(ctx context.Context, tenderId string,
 	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
 	if err != nil {
 		log.Error("Invalid tenderId format", slog.Any("error", err))
-		return Response(http.StatusBadRequest, ErrorResponse{Reason: "Invalid ID format. Must be UUID formatted"}), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
 	_, err = s.getUserByName(ctx, requesterUsername)
 	if err != nil {
 		log.Error("No user found with provided requesterUsername", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Requester user does not exist or is incorrect"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	author, err := s.getUserByName(ctx, authorUsername)
 	if err != nil {
 		log.Error("No user found with provided authorUsername", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Author user does not exist or is incorrect"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	sqlBuilder := s.builder.
-		Select("f.feedback_id", "f.feedback", "f.created_at").
+		Select(feedbackColumns).
 		From("bid_feedback  f").
 		Join("bids ON bids.bid_id = f.bid_id").
-		Where(squirrel.Eq{"bids.tender_id": tenderIdUUID, "bids.author_id": author.Id}).
+		Where(squirrel.Eq{"bids.tender_id": tenderIdUUID, "bids.author_id": author.Id, "f.retracted_at": nil}).
 		OrderBy("f.created_at DESC").
 		Limit(uint64(limit)).
 		Offset(uint64(offset))
@@ -396,34 +527,41 @@ func (s *DefaultAPIService) GetBidReviews(ctx context.Context, tenderId string,
 	q, args, err := sqlBuilder.ToSql()
 	if err != nil {
 		log.Error("error to build query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	// Execute query
 	rows, err := s.pg.Pool.Query(ctx, q, args...)
 	if err != nil {
 		log.Error("error to execute query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 	defer rows.Close()
 
 	var reviews []BidReview
-	var createdTime time.Time
 
 	for rows.Next() {
-		var review BidReview
-		err := rows.Scan(&review.Id, &review.Description, &createdTime)
+		feedback, err := scanFeedback(rows)
 		if err != nil {
 			log.Error("error parsing review data", slog.Any("error", err))
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+			return errorResult(ErrCodeInternal, err)
 		}
-		review.CreatedAt = createdTime.Format(time.RFC3339)
-		reviews = append(reviews, review)
+		reviews = append(reviews, BidReview{
+			Id:               feedback.Id,
+			Description:      feedback.Feedback,
+			BidId:            feedback.BidId,
+			ReviewerUsername: feedback.Username,
+			Rating:           feedback.Rating,
+			Tags:             feedback.Tags,
+			Version:          feedback.Version,
+			CreatedAt:        feedback.CreatedAt,
+			UpdatedAt:        feedback.UpdatedAt,
+		})
 	}
 
 	if len(reviews) == 0 {
 		log.Error("no reviews found", slog.Any("tender_id", tenderId))
-		return Response(http.StatusNotFound, ErrorResponse{Reason: "No reviews found for the provided tender and author"}), nil
+		return errorResult(ErrCodeReviewsNotFound, nil)
 	}
 
 	log.Info("successfully got bid reviews")
@@ -438,23 +576,23 @@ func (s *DefaultAPIService) GetBidStatus(ctx context.Context, bidId string, user
 	bidIdUUID, err := s.ConvertIntoUUID(bidId)
 	if err != nil {
 		log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest , ErrorResponse{Reason: "Ivalid ID parameter. Must be UUID formatted"}), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
 	user, err := s.getUserByName(ctx, username)
 	if err != nil {
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	bid, err := s.getBidById(ctx, bidIdUUID)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Предложение не найдено"}), nil
+			return errorResult(ErrCodeBidNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	if bid.AuthorType == USER {
@@ -463,15 +601,15 @@ func (s *DefaultAPIService) GetBidStatus(ctx context.Context, bidId string, user
 				slog.Any("user", s.ConvertFromUUID(user.Id)),
 				slog.Any("bid", bid.AuthorId))
 
-			return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для выполнения действия"}), nil
+			return errorResult(ErrCodeForbiddenNotAuthor, nil)
 		}
 	} else {
 		orgIdUUID, _ := s.ConvertIntoUUID(bid.AuthorId)
 		if err := s.userBelongsToOrganization(ctx, user.Username, orgIdUUID); err != nil {
 			if errors.Is(err, ErrNotFound) {
-				return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для выполнения действия"}), nil
+				return errorResult(ErrCodeForbiddenNotAuthor, err)
 			}
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+			return errorResult(ErrCodeInternal, err)
 		}
 	}
 
@@ -482,21 +620,21 @@ func (s *DefaultAPIService) GetBidStatus(ctx context.Context, bidId string, user
 	q, args, err := builder.ToSql()
 	if err != nil {
 		log.Error("error to build a query", slog.Any("error", err))
-		return Response(http.StatusNotFound, ErrorResponse{err.Error()}), ErrSQLQuery
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	var status BidStatus
 	err = s.pg.Pool.QueryRow(ctx, q, args...).Scan(&status)
 	if err != nil {
 		log.Error("error to build a query pool", slog.Any("error", err))
-		return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), ErrSQLQuery
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	return Response(http.StatusOK, status), nil
 }
 
 // GetBidsForTender - Получение списка предложений для тендера (good)
-func (s *DefaultAPIService) GetBidsForTender(ctx context.Context, tenderId string, username string, limit int32, offset int32) (ImplResponse, error) {
+func (s *DefaultAPIService) GetBidsForTender(ctx context.Context, tenderId string, username string, limit int32, offset int32, sort BidSort) (ImplResponse, error) {
 	const op = "DefaultAPIService.GetBidsForTender"
 	log := s.log.With(slog.String("op", op))
 
@@ -504,41 +642,54 @@ func (s *DefaultAPIService) GetBidsForTender(ctx context.Context, tenderId strin
 	if err != nil {
 		log.Error("couldn't find user", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
 	if err != nil {
 		log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest , ErrorResponse{Reason: "Ivalid ID parameter. Must be UUID formatted"}), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
-	_, err = s.getTenderById(ctx, tenderIdUUID)
+	tender, err := s.getTenderById(ctx, tenderIdUUID)
 	if err != nil {
 		log.Error("Failed to get New Tender", slog.Any("error", err))
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), err
+			return errorResult(ErrCodeTenderNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get oldTender."}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
-
 	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
 	if err1 != nil {
 		if errors.Is(err1, ErrUserNoRightsTender) {
 			log.Error("user does not have rights for the tender", slog.Any("error", err2))
-			return Response(http.StatusForbidden, ErrorResponse{"Недостаточно прав для выполнения действия"}), err2
+			return errorResult(ErrCodeForbiddenNotResponsible, err1)
 		}
 		log.Error("error to find a user sql error", slog.Any("error", err2))
-		return Response(http.StatusInternalServerError, ErrorResponse{"couldn't process an sql query"}), err2
+		return errorResult(ErrCodeInternal, err2)
+	}
+
+	if sort == "" {
+		sort = BID_SORT_CREATED_AT
+	}
+	orderBy, ok := bidSortOrderBy[sort]
+	if !ok {
+		return errorDetailResult(ErrCodeInvalidParameter, MsgOneOf, bidSortValues)
+	}
+	sealed := tender.bidsSealed()
+	if sealed && sort != BID_SORT_CREATED_AT_DESC {
+		// Ordering by name or price would give the hidden contents away.
+		orderBy = bidSortOrderBy[BID_SORT_CREATED_AT]
 	}
 
 	query := `
-    SELECT bid_id, name, status, author_type, author_id, version, created_at 
+    SELECT bid_id, name, status, author_type, author_id, version, created_at, price_amount, price_currency, ` + bidLotIdsColumn + `
     FROM bids 
     WHERE tender_id = $1 
+    ORDER BY ` + orderBy + `
     LIMIT $2 
     OFFSET $3`
 
@@ -546,7 +697,7 @@ func (s *DefaultAPIService) GetBidsForTender(ctx context.Context, tenderId strin
 	rows, err := s.pg.Pool.Query(ctx, query, tenderIdUUID, limit, offset)
 	if err != nil {
 		log.Error("failed to fetch bids", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 	defer rows.Close()
 
@@ -554,18 +705,33 @@ func (s *DefaultAPIService) GetBidsForTender(ctx context.Context, tenderId strin
 	for rows.Next() {
 		var bid Bid
 		var createdTime time.Time
-		err := rows.Scan(&bid.Id, &bid.Name, &bid.Status, &bid.AuthorType, &bid.AuthorId, &bid.Version ,&createdTime)
+		var price nullMoney
+		err := rows.Scan(&bid.Id, &bid.Name, &bid.Status, &bid.AuthorType, &bid.AuthorId, &bid.Version, &createdTime, &price.Amount, &price.Currency, &bid.LotIds)
 		if err != nil {
 			log.Error("failed to scan bid", slog.Any("error", err))
-			return Response(http.StatusInternalServerError, ErrorResponse{"failed to scan bid"}), err
+			return errorResult(ErrCodeInternal, err)
 		}
 		bid.CreatedAt = createdTime.Format(time.RFC3339)
+		bid.Price = price.Money()
+		if sealed {
+			maskSealedBid(&bid)
+		}
 		bids = append(bids, bid)
 	}
 
 	if rows.Err() != nil {
 		log.Error("error iterating over rows", slog.Any("error", rows.Err()))
-		return Response(http.StatusInternalServerError, ErrorResponse{"Ошибка при обработке данных"}), rows.Err()
+		return errorResult(ErrCodeInternal, rows.Err())
+	}
+
+	// The caller is responsible for the tender, so the reputation of every bidder is shown.
+	reputations, err := s.bidReputations(ctx, bids)
+	if err != nil {
+		log.Error("failed to read the reputation of the bidders", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	for i := range bids {
+		bids[i].Reputation = reputations[reputationKey(bids[i].AuthorType, bids[i].AuthorId)]
 	}
 
 	return Response(http.StatusOK, bids), nil
@@ -579,16 +745,21 @@ func (s *DefaultAPIService) GetTenderStatus(ctx context.Context, tenderId string
 	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
 	if err != nil {
 		log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest, ErrorResponse{Reason:"Ivalid ID parameter. Must be UUID formatted" }), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
-	_, err = s.getTenderById(ctx, tenderIdUUID)
+	tender, err := s.getTenderById(ctx, tenderIdUUID)
 	if err != nil {
 		log.Error("Failed to get New Tender", slog.Any("error", err))
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), nil
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	if tender.Private {
+		if _, err := s.tenderForRead(ctx, tenderId, username); err != nil {
+			return apiErrorResult(err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get oldTender."}), nil
 	}
 
 	builder := s.builder.Select("t.status").
@@ -600,9 +771,9 @@ func (s *DefaultAPIService) GetTenderStatus(ctx context.Context, tenderId string
 		if err != nil {
 			log.Error("couldn't find user by username", slog.Any("error", err))
 			if errors.Is(err, ErrNoUser) {
-				return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+				return errorResult(ErrCodeUserNotFound, err)
 			}
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+			return errorResult(ErrCodeInternal, err)
 		}
 		builder.Join("organization_responsible or ON t.organization_id = or.organization_id").
 			Join("employee e ON e.id = or.user_id").
@@ -612,7 +783,7 @@ func (s *DefaultAPIService) GetTenderStatus(ctx context.Context, tenderId string
 	q, args, err := builder.ToSql()
 	if err != nil {
 		log.Error("failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	var status TenderStatus
@@ -621,9 +792,9 @@ func (s *DefaultAPIService) GetTenderStatus(ctx context.Context, tenderId string
 	if err != nil {
 		log.Error("error to build a query pool", slog.Any("error", err))
 		if errors.Is(pgx.ErrNoRows, err) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: err.Error()}), err
+			return errorResult(ErrCodeTenderNotFound, err)
 		}
-		return  Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	return Response(http.StatusOK, status), nil
@@ -634,83 +805,88 @@ func (s *DefaultAPIService) GetTenderStatus(ctx context.Context, tenderId string
 func (s *DefaultAPIService) GetTenders(ctx context.Context, limit int32, offset int32, serviceType []TenderServiceType) (ImplResponse, error) {
 	s.log.Info("Request received in GetTenders", slog.Int("limit", int(limit)), slog.Int("offset", int(offset)), slog.Any("serviceType", serviceType))
 
-    for i := range serviceType {
-        if !serviceType[i].IsValid() {
-            return Response(http.StatusBadRequest, ErrorResponse{Reason: "service type not valid."}), nil
-        }
-    }
+	for i := range serviceType {
+		if !serviceType[i].IsValid() {
+			return errorDetailResult(ErrCodeInvalidParameter, MsgUnknownServiceType)
+		}
+	}
 
-    queryBuilder := s.builder.
-        Select("id, name, description, service_type, status, organization_id, version, created_at").
-        From("tenders").
-        Limit(uint64(limit)).
-        Offset(uint64(offset))
+	queryBuilder := s.builder.
+		Select("id, name, description, service_type, status, organization_id, version, created_at, budget_amount, budget_currency", tenderSealingColumns).
+		From("tenders").
+		Where(squirrel.Eq{"private": false}).
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
 
-    if len(serviceType) > 0 {
-        queryBuilder = queryBuilder.Where(squirrel.Eq{"service_type": serviceType})
-    }
+	if len(serviceType) > 0 {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"service_type": serviceType})
+	}
 
 	if limit > 0 {
-        queryBuilder = queryBuilder.Limit(uint64(limit))
-    }
+		queryBuilder = queryBuilder.Limit(uint64(limit))
+	}
 
-    if offset > 0 {
-        queryBuilder = queryBuilder.Offset(uint64(offset))
-    }
+	if offset > 0 {
+		queryBuilder = queryBuilder.Offset(uint64(offset))
+	}
 
-    sql, args, err := queryBuilder.ToSql()
-    if err != nil {
-        s.log.Error("Failed to build SQL", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
-    }
+	sql, args, err := queryBuilder.ToSql()
+	if err != nil {
+		s.log.Error("Failed to build SQL", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
 
-    s.log.Info("SQL query built", slog.String("sql", sql), slog.Any("args", args))
+	s.log.Info("SQL query built", slog.String("sql", sql), slog.Any("args", args))
 
-    rows, err := s.pg.Pool.Query(ctx, sql, args...)
-    if err != nil {
-        s.log.Error("Failed to execute query", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Database query failed"}), err
-    }
-    defer rows.Close()
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		s.log.Error("Failed to execute query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer rows.Close()
 
 	var timeInTender time.Time
 
-    var tenders []Tender
-    for rows.Next() {
-        var tender Tender
-        err := rows.Scan(&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.Version, &timeInTender)
-        if err != nil {
-            s.log.Error("Failed to scan row", slog.Any("error", err))
-            return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to parse database result"}), err
-        }
+	var tenders []Tender
+	for rows.Next() {
+		var tender Tender
+		var budget nullMoney
+		var sealing tenderSealing
+		err := rows.Scan(&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.Version, &timeInTender, &budget.Amount, &budget.Currency, &sealing.Sealed, &sealing.Deadline, &sealing.RevealedAt)
+		if err != nil {
+			s.log.Error("Failed to scan row", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
 		tender.CreatedAt = timeInTender.Format(time.RFC3339)
-        tenders = append(tenders, tender)
-    }
+		tender.Budget = budget.Money()
+		sealing.apply(&tender)
+		tenders = append(tenders, tender)
+	}
 
-    if err = rows.Err(); err != nil {
-        s.log.Error("Error during rows iteration", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Error while fetching tenders"}), err
-    }
+	if err = rows.Err(); err != nil {
+		s.log.Error("Error during rows iteration", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
 
-    s.log.Info("Successfully fetched tenders", slog.Any("tenders", tenders))
+	s.log.Info("Successfully fetched tenders", slog.Any("tenders", tenders))
 
 	if tenders == nil {
-		return ImplResponse {Code: http.StatusOK, Body: "Rows are empty."}, nil
+		tenders = []Tender{}
 	}
 
-    return ImplResponse{
-        Code: http.StatusOK,
-        Body: tenders,
-    }, nil
+	return ImplResponse{
+		Code: http.StatusOK,
+		Body: tenders,
+	}, nil
 }
 
 // GetUserBids - Получение списка ваших предложений (протестил)
 // Request: GET
 func (s *DefaultAPIService) GetUserBids(ctx context.Context, limit int32, offset int32, username string) (ImplResponse, error) {
 	if username == "" {
-		return Response(http.StatusBadRequest, ErrorResponse{Reason: "username dose nor exist."}), nil
+		return errorDetailResult(ErrCodeInvalidParameter, MsgUsernameRequired)
 	}
-	
+
 	var userId uuid.UUID
 	sql, args, err := s.builder.
 		Select("id").
@@ -720,17 +896,17 @@ func (s *DefaultAPIService) GetUserBids(ctx context.Context, limit int32, offset
 
 	if err != nil {
 		s.log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&userId)
 	if err != nil {
 		s.log.Error("Failed to execute SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Database query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	sql, args, err = s.builder.
-		Select("bid_id, name, status, author_type, author_id, version, created_at").
+		Select("bid_id, name, status, author_type, author_id, version, created_at, price_amount, price_currency", bidLotIdsColumn).
 		From("bids").
 		Where(squirrel.Eq{"author_id": userId}).
 		Limit(uint64(limit)).
@@ -739,13 +915,13 @@ func (s *DefaultAPIService) GetUserBids(ctx context.Context, limit int32, offset
 
 	if err != nil {
 		s.log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	rows, err := s.pg.Pool.Query(ctx, sql, args...)
 	if err != nil {
 		s.log.Error("Failed to execute SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Database query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 	defer rows.Close()
 
@@ -754,35 +930,66 @@ func (s *DefaultAPIService) GetUserBids(ctx context.Context, limit int32, offset
 	var bids []Bid
 	for rows.Next() {
 		var bid Bid
-		if err := rows.Scan(&bid.Id, &bid.Name, &bid.Status, &bid.AuthorType, &bid.AuthorId, &bid.Version, &timeInBid); err != nil {
+		var price nullMoney
+		if err := rows.Scan(&bid.Id, &bid.Name, &bid.Status, &bid.AuthorType, &bid.AuthorId, &bid.Version, &timeInBid, &price.Amount, &price.Currency, &bid.LotIds); err != nil {
 			s.log.Error("Failed to scan row", slog.Any("error", err))
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Data scan error"}), err
+			return errorResult(ErrCodeInternal, err)
 		}
 		bid.CreatedAt = timeInBid.Format(time.RFC3339)
+		bid.Price = price.Money()
+		if err := s.unsealBid(&bid); err != nil {
+			s.log.Error("Failed to unseal bid", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
 		bids = append(bids, bid)
 	}
 
 	if err := rows.Err(); err != nil {
 		s.log.Error("Error occurred during rows iteration", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Rows iteration error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	if bids == nil {
-		return ImplResponse {Code: http.StatusOK, Body: "Rows are empty."}, nil
+		bids = []Bid{}
 	}
 
 	return Response(http.StatusOK, bids), nil
 }
 
+// userTenderColumns are the columns read by scanUserTender.
+var userTenderColumns = []string{
+	"id", "name", "description", "status", "service_type", "version", "created_at", "budget_amount", "budget_currency",
+	tenderSealingColumns, "private", "conflict_policy",
+}
+
+func scanUserTender(row pgx.Row) (Tender, error) {
+	var tender Tender
+	var createdAt time.Time
+	var budget nullMoney
+	var sealing tenderSealing
+
+	err := row.Scan(&tender.Id, &tender.Name, &tender.Description, &tender.Status, &tender.ServiceType, &tender.Version,
+		&createdAt, &budget.Amount, &budget.Currency, &sealing.Sealed, &sealing.Deadline, &sealing.RevealedAt,
+		&tender.Private, &tender.ConflictPolicy)
+	if err != nil {
+		return Tender{}, err
+	}
+
+	tender.CreatedAt = createdAt.Format(time.RFC3339)
+	tender.Budget = budget.Money()
+	sealing.apply(&tender)
+	return tender, nil
+}
+
 // GetUserTenders - Получить тендеры пользователя (протестил)
 // Request: Get
 func (s *DefaultAPIService) GetUserTenders(ctx context.Context, limit int32, offset int32, username string) (ImplResponse, error) {
 	if username == "" {
-		return Response(http.StatusBadRequest, ErrorResponse{Reason: "username dose nor exist."}), nil
+		return errorDetailResult(ErrCodeInvalidParameter, MsgUsernameRequired)
 	}
 
 	sql, args, err := s.builder.
-		Select("id, name, description, status, service_type, version, created_at").
+		Select(userTenderColumns...).
 		From("tenders").
 		Where(squirrel.Eq{"creator_username": username}).
 		Limit(uint64(limit)).
@@ -791,35 +998,33 @@ func (s *DefaultAPIService) GetUserTenders(ctx context.Context, limit int32, off
 
 	if err != nil {
 		s.log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	rows, err := s.pg.Pool.Query(ctx, sql, args...)
 	if err != nil {
 		s.log.Error("Failed to execute SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Database query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 	defer rows.Close()
 
 	var tenders []Tender
-	var tendersTime time.Time
 	for rows.Next() {
-		var tender Tender
-		if err := rows.Scan(&tender.Id, &tender.Name, &tender.Description, &tender.Status, &tender.ServiceType, &tender.Version, &tendersTime); err != nil {
+		tender, err := scanUserTender(rows)
+		if err != nil {
 			s.log.Error("Failed to scan row", slog.Any("error", err))
-			return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Data scan error"}), err
+			return errorResult(ErrCodeInternal, err)
 		}
-		tender.CreatedAt = tendersTime.Format(time.RFC3339)
 		tenders = append(tenders, tender)
 	}
 
 	if err := rows.Err(); err != nil {
 		s.log.Error("Error occurred during rows iteration", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Rows iteration error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	if tenders == nil {
-		return ImplResponse {Code: http.StatusOK, Body: "Rows are empty."}, nil
+		tenders = []Tender{}
 	}
 
 	return Response(http.StatusOK, tenders), nil
@@ -827,76 +1032,113 @@ func (s *DefaultAPIService) GetUserTenders(ctx context.Context, limit int32, off
 
 // RollbackBid - Откат версии предложения (not)
 func (s *DefaultAPIService) RollbackBid(ctx context.Context, bidId string, version int32, username string) (ImplResponse, error) {
-    query, args, err := s.pg.Builder.
-        Select("bid_id, name, description, status, tender_id, author_type, author_id, version, created_at").
-        From("bids").
-        Where(squirrel.Eq{"bid_id": bidId}).
-        ToSql()
+	// Only the author may roll back: the response carries the contents of sealed bids.
+	if _, err := s.bidForWrite(ctx, bidId, username); err != nil {
+		return apiErrorResult(err)
+	}
 
-    if err != nil {
-        s.log.Error("Failed to build SQL for bid lookup", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Internal server error"}), err
-    }
+	query, args, err := s.pg.Builder.
+		Select("bid_id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		From("bids").
+		Where(squirrel.Eq{"bid_id": bidId}).
+		ToSql()
+
+	if err != nil {
+		s.log.Error("Failed to build SQL for bid lookup", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
 
 	var currentTimeBid time.Time
-    var currentBid Bid
-    err = s.pg.Pool.QueryRow(ctx, query, args...).Scan(
-        &currentBid.Id, &currentBid.Name, &currentBid.Description, &currentBid.Status, 
-        &currentBid.TenderId, &currentBid.AuthorType, &currentBid.AuthorId, &currentBid.Version, 
-        &currentTimeBid)
-    
-    if err != nil {
-        s.log.Error("Bid not found", slog.Any("error", err))
-        return Response(http.StatusNotFound, ErrorResponse{Reason: "Bid not found"}), err
-    }
-
-    query, args, err = s.pg.Builder.
-        Select("bid_id, name, description, status, tender_id, author_type, author_id, version, created_at").
-        From("bids_versions").
-        Where(squirrel.Eq{"bid_id": bidId, "version": version}).
-        ToSql()
-
-    if err != nil {
-        s.log.Error("Failed to build SQL for version lookup", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Internal server error"}), err
-    }
+	var currentBid Bid
+	err = s.pg.Pool.QueryRow(ctx, query, args...).Scan(
+		&currentBid.Id, &currentBid.Name, &currentBid.Description, &currentBid.Status,
+		&currentBid.TenderId, &currentBid.AuthorType, &currentBid.AuthorId, &currentBid.Version,
+		&currentTimeBid)
+
+	if err != nil {
+		s.log.Error("Bid not found", slog.Any("error", err))
+		return errorResult(ErrCodeBidNotFound, err)
+	}
+
+	query, args, err = s.pg.Builder.
+		Select("bid_id, name, description, status, tender_id, author_type, author_id, version, created_at, price_amount, price_currency").
+		From("bids_versions").
+		Where(squirrel.Eq{"bid_id": bidId, "version": version}).
+		ToSql()
+
+	if err != nil {
+		s.log.Error("Failed to build SQL for version lookup", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
 
 	var rollbackTimeBid time.Time
-    var rollbackVersion Bid
-    err = s.pg.Pool.QueryRow(ctx, query, args...).Scan(
-        &rollbackVersion.Id, &rollbackVersion.Name, &rollbackVersion.Description, &rollbackVersion.Status, 
-        &rollbackVersion.TenderId, &rollbackVersion.AuthorType, &rollbackVersion.AuthorId, &rollbackVersion.Version, 
-        &rollbackTimeBid)
-    
-    if err != nil {
-        s.log.Error("Version not found", slog.Any("error", err))
-        return Response(http.StatusNotFound, ErrorResponse{Reason: "Version not found"}), err
-    }
-
-    query, args, err = s.pg.Builder.
-        Update("bids").
-        Set("name", rollbackVersion.Name).
-        Set("description", rollbackVersion.Description).
-        Set("status", rollbackVersion.Status).
-        Set("tender_id", rollbackVersion.TenderId).
-        Set("author_type", rollbackVersion.AuthorType).
-        Set("author_id", rollbackVersion.AuthorId).
-        Set("version", rollbackVersion.Version).
-        Where(squirrel.Eq{"bid_id": bidId}).
-        ToSql()
-
-    if err != nil {
-        s.log.Error("Failed to build SQL for bid update", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Internal server error"}), err
-    }
-
-    _, err = s.pg.Pool.Exec(ctx, query, args...)
-    if err != nil {
-        s.log.Error("Failed to update bid", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to update bid"}), err
-    }
-
-    return Response(http.StatusOK, "Откатил..."), nil
+	var rollbackVersion Bid
+	var rollbackPrice nullMoney
+	err = s.pg.Pool.QueryRow(ctx, query, args...).Scan(
+		&rollbackVersion.Id, &rollbackVersion.Name, &rollbackVersion.Description, &rollbackVersion.Status,
+		&rollbackVersion.TenderId, &rollbackVersion.AuthorType, &rollbackVersion.AuthorId, &rollbackVersion.Version,
+		&rollbackTimeBid, &rollbackPrice.Amount, &rollbackPrice.Currency)
+
+	if err != nil {
+		s.log.Error("Version not found", slog.Any("error", err))
+		return errorResult(ErrCodeVersionNotFound, err)
+	}
+	rollbackVersion.Price = rollbackPrice.Money()
+	priceAmount, priceCurrency := moneyValues(rollbackVersion.Price)
+
+	query, args, err = s.pg.Builder.
+		Update("bids").
+		Set("name", rollbackVersion.Name).
+		Set("description", rollbackVersion.Description).
+		Set("status", rollbackVersion.Status).
+		Set("tender_id", rollbackVersion.TenderId).
+		Set("author_type", rollbackVersion.AuthorType).
+		Set("author_id", rollbackVersion.AuthorId).
+		Set("version", rollbackVersion.Version).
+		Set("price_amount", priceAmount).
+		Set("price_currency", priceCurrency).
+		Where(squirrel.Eq{"bid_id": bidId}).
+		ToSql()
+
+	if err != nil {
+		s.log.Error("Failed to build SQL for bid update", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	tenderIdUUID, _ := s.ConvertIntoUUID(currentBid.TenderId)
+	if err := checkSubmissionOpen(ctx, tx, tenderIdUUID); err != nil {
+		return apiErrorResult(err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		s.log.Error("Failed to update bid", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	bidIdUUID, _ := s.ConvertIntoUUID(currentBid.Id)
+	if err := refreshReputation(ctx, tx, bidIdUUID); err != nil {
+		s.log.Error("Failed to count the rollback in the reputation", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if err := s.restoreAttachments(ctx, attachmentEntityBid, bidId, version); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	rollbackVersion.CreatedAt = currentTimeBid.Format(time.RFC3339)
+	if err := s.unsealBid(&rollbackVersion); err != nil {
+		s.log.Error("Failed to unseal bid", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	return Response(http.StatusOK, rollbackVersion), nil
 }
 
 // RollbackTender - Откат версии тендера (good)
@@ -906,44 +1148,44 @@ func (s *DefaultAPIService) RollbackTender(ctx context.Context, tenderId string,
 
 	if version < 1 {
 		log.Error("Version cannot be less than 1")
-		return Response(http.StatusBadRequest, ErrorResponse{Reason: "Version cannot be less than 1"}), errors.New("Version cannot be less than 1")
+		return errorDetailResult(ErrCodeInvalidParameter, MsgVersionMin)
 	}
 	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
 	if err != nil {
 		log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest , ErrorResponse{Reason: "Ivalid ID parameter. Must be UUID formatted"}), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
 	oldTender, err := s.getTenderById(ctx, tenderIdUUID)
 	if err != nil {
 		log.Error("Failed to get New Tender", slog.Any("error", err))
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), err
+			return errorResult(ErrCodeTenderNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get oldTender."}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	user, err := s.getUserByName(ctx, username)
 	if err != nil {
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), err
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
 	if err1 != nil {
 		if errors.Is(err1, ErrUserNoRightsTender) {
 			log.Error("user does not have rights for the tender", slog.Any("error", err1))
-			return Response(http.StatusForbidden, ErrorResponse{"Недостаточно прав для выполнения действия"}), err2
+			return errorResult(ErrCodeForbiddenNotResponsible, err1)
 		}
 		log.Error("error to find a user sql error", slog.Any("error", err2))
-		return Response(http.StatusInternalServerError, ErrorResponse{"couldn't process an sql query"}), err2
+		return errorResult(ErrCodeInternal, err2)
 	}
 
 	if err := s.addVersionTableTender(ctx, user.Username, oldTender); err != nil {
 		log.Error("Failed to add tender to the version table", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to add tender to the version table "}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	sql := `DELETE FROM tenders WHERE id = $1`
@@ -952,25 +1194,24 @@ func (s *DefaultAPIService) RollbackTender(ctx context.Context, tenderId string,
 
 	if err != nil {
 		log.Error("failed to execute delete query", slog.Any("err", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	sql, args, err := s.builder.
-		Select("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "updated_at").
+		Select("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "updated_at", "budget_amount", "budget_currency").
 		From("tender_versions").
 		Where(squirrel.Eq{"tender_id": tenderIdUUID, "version": version}).
 		ToSql()
 
-
-
 	if err != nil {
 		s.log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	var createdTime time.Time
 	var oldIdUUID uuid.UUID
 	var orgIdUUID uuid.UUID
+	var budget nullMoney
 
 	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(
 		&oldIdUUID,
@@ -981,61 +1222,68 @@ func (s *DefaultAPIService) RollbackTender(ctx context.Context, tenderId string,
 		&orgIdUUID,
 		&oldTender.Version,
 		&createdTime,
+		&budget.Amount,
+		&budget.Currency,
 	)
 
 	if err != nil {
 		log.Error("error to build a query pool", slog.Any("error", err))
 		if errors.Is(pgx.ErrNoRows, err) {
 
-			return Response(http.StatusOK, "empty return body, there is no data"), nil
+			return Response(http.StatusOK, T(ctx, MsgTenderVersionAbsent)), nil
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	oldTender.CreatedAt = createdTime.Format(time.RFC3339)
 	oldTender.Id = oldIdUUID.String()
 	oldTender.OrganizationId = orgIdUUID.String()
-
+	oldTender.Budget = budget.Money()
+	budgetAmount, budgetCurrency := moneyValues(oldTender.Budget)
 
 	sql, args, err = s.builder.
 		Insert("tenders").
-		Columns("id", "name", "description", "status", "service_type", "organization_id", "creator_username", "version", "created_at").
-		Values(oldIdUUID, oldTender.Name, oldTender.Description, oldTender.Status, oldTender.ServiceType, orgIdUUID, username, oldTender.Version , oldTender.CreatedAt).
+		Columns("id", "name", "description", "status", "service_type", "organization_id", "creator_username", "version", "created_at", "budget_amount", "budget_currency", "sealed", "submission_deadline", "revealed_at", "private", "conflict_policy").
+		Values(oldIdUUID, oldTender.Name, oldTender.Description, oldTender.Status, oldTender.ServiceType, orgIdUUID, username, oldTender.Version, oldTender.CreatedAt, budgetAmount, budgetCurrency, oldTender.Sealed, parseOptionalTime(oldTender.SubmissionDeadline), parseOptionalTime(oldTender.RevealedAt), oldTender.Private, oldTender.ConflictPolicy).
 		Suffix("RETURNING id, created_at").
 		ToSql()
 
 	if err != nil {
 		log.Error("Failed to build SQL", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	_, err = s.pg.Pool.Exec(ctx, sql, args...)
 	if err != nil {
 		log.Error("Failed to execute SQL", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to create tender"}), err
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if err := s.restoreAttachments(ctx, attachmentEntityTender, oldTender.Id, version); err != nil {
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	return Response(http.StatusOK, oldTender), nil
 }
 
 // SubmitBidDecision - Отправка решения по предложению (good)
-func (s *DefaultAPIService) SubmitBidDecision(ctx context.Context, bidId string, decision BidDecision, username string) (ImplResponse, error) {
+func (s *DefaultAPIService) SubmitBidDecision(ctx context.Context, bidId string, decision BidDecision, username string, lotId string) (ImplResponse, error) {
 	const op = "SubmitBidDecision"
 	log := s.log.With(slog.String("op", op))
 
 	bidIdUUID, err := s.ConvertIntoUUID(bidId)
 	if err != nil {
 		log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest, ErrorResponse{Reason:"Ivalid ID parameter. Must be UUID formatted" }), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
 	user, err := s.getUserByName(ctx, username)
 	if err != nil {
 		log.Error("no user by name", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	sqlCheck, argsCheck, err := s.builder.Select("1").
@@ -1043,7 +1291,7 @@ func (s *DefaultAPIService) SubmitBidDecision(ctx context.Context, bidId string,
 		Join("tenders ON tenders.id = bids.tender_id").
 		Join("organization_responsible ON organization_responsible.organization_id = tenders.organization_id").
 		Where(squirrel.Eq{
-			"bids.bid_id":           bidIdUUID,
+			"bids.bid_id":                      bidIdUUID,
 			"organization_responsible.user_id": user.Id,
 		}).
 		Limit(1).
@@ -1051,192 +1299,314 @@ func (s *DefaultAPIService) SubmitBidDecision(ctx context.Context, bidId string,
 
 	if err != nil {
 		log.Error("failed to build SQL query for rights check", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Не удалось построить SQL запрос"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	var hasRights int
 	err = s.pg.Pool.QueryRow(ctx, sqlCheck, argsCheck...).Scan(&hasRights)
 	if err != nil || hasRights == 0 {
 		log.Error("user has no permission to edit this bid", slog.Any("user", user.Id), slog.Any("bid", bidIdUUID))
-		return Response(http.StatusForbidden, ErrorResponse{Reason: "Недостаточно прав для изменения статуса бида"}), nil
+		return errorResult(ErrCodeForbiddenNotResponsible, err)
+	}
+
+	bid, err := s.getBidById(ctx, bidIdUUID)
+	if err != nil {
+		log.Error("Failed to get bid", slog.Any("error", err))
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeBidNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	tender, err := s.loadTender(ctx, bid.TenderId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if tender.bidsSealed() {
+		return apiErrorResult(sealedBidsError(tender))
+	}
+
+	lotIdUUID, apiErr := decisionLot(bid, lotId)
+	if apiErr != nil {
+		return apiErrorResult(apiErr)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(bid.TenderId)
+
+	var conflicts []conflictFinding
+	if tender.ConflictPolicy != "" && tender.ConflictPolicy != CONFLICT_POLICY_OFF {
+		findings, err := s.decisionConflicts(ctx, tender, bid, user)
+		if err != nil {
+			return apiErrorResult(err)
+		}
+		if conflicts, apiErr = screenConflicts(tender.ConflictPolicy, findings); apiErr != nil {
+			return apiErrorResult(apiErr)
+		}
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	tenderStatus, err := lockTender(ctx, tx, tenderIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	if decision == APPROVED && tenderStatus == CLOSED {
+		return errorDetailResult(ErrCodeTenderClosed, MsgTenderClosed)
 	}
 
-	createdAt := time.Now().Format(time.RFC3339)
 	sql, args, err := s.builder.
-		Insert("bid_feedback").
-		Columns("bid_id", "feedback", "username", "created_at").
-		Values(bidIdUUID, decision, username, createdAt).
+		Insert("bid_decisions").
+		Columns("bid_id", "decision", "decided_by", "lot_id").
+		Values(bidIdUUID, decision, user.Id, lotIdUUID).
 		ToSql()
 
 	if err != nil {
 		log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
-	_, err = s.pg.Pool.Exec(ctx, sql, args...)
-	if err != nil {
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
 		log.Error("Failed to execute SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
-
-	oldBid, err := s.getBidById (ctx, bidIdUUID)
-	if err != nil {
-		log.Error("Failed to get bid", slog.Any("error", err))
-		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "bid not found"}), err
+	if decision == APPROVED {
+		closed, err := awardLot(ctx, tx, tenderIdUUID, bidIdUUID, lotIdUUID)
+		if err != nil {
+			log.Error("Failed to award the lot", slog.Any("error", err))
+			return apiErrorResult(err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get old bid."}), err
+		if closed {
+			log.Info("tender closed", slog.String("tender_id", bid.TenderId))
+		}
+	}
+
+	if err := recordConflicts(ctx, tx, tenderIdUUID, bidIdUUID, conflicts); err != nil {
+		log.Error("Failed to flag the conflicts of interest", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := refreshReputation(ctx, tx, bidIdUUID); err != nil {
+		log.Error("Failed to count the decision in the reputation", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	event, payload := eventBidRejected, bidEventPayload(tenderIdUUID, bidIdUUID, bid.AuthorType, bid.AuthorId)
+	if decision == APPROVED {
+		event = eventBidApproved
+	}
+	if lotIdUUID != nil {
+		payload["lotId"] = *lotIdUUID
+	}
+	if err := recordEvent(ctx, tx, event, bidIdUUID, payload); err != nil {
+		log.Error("Failed to record the event", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	log.Info("Bid decision successfully submitted")
-	return Response(http.StatusOK, oldBid), nil
+	return Response(http.StatusOK, bid), nil
 }
 
 // SubmitBidFeedback - Отправка отзыва по предложению (good)
-func (s *DefaultAPIService) SubmitBidFeedback(ctx context.Context, bidId string, bidFeedback string, username string) (ImplResponse, error) {
+func (s *DefaultAPIService) SubmitBidFeedback(ctx context.Context, bidId string, bidFeedback string, username string, rating int32, tags []FeedbackTag) (ImplResponse, error) {
 	const op = "SubmitBidFeedback"
 	log := s.log.With(slog.String("op", op))
 
 	bidIdUUID, err := s.ConvertIntoUUID(bidId)
 	if err != nil {
 		log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest, ErrorResponse{Reason:"Ivalid ID parameter. Must be UUID formatted" }), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
-	_, err = s.getUserByName(ctx, username)
+	user, err := s.getUserByName(ctx, username)
 	if err != nil {
 		log.Error("no user by name", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
-	oldBid, err := s.getBidById (ctx, bidIdUUID)
+	oldBid, err := s.getBidById(ctx, bidIdUUID)
 
 	if err != nil {
 		log.Error("Failed to get bid", slog.Any("error", err))
 		if errors.Is(err, ErrNotFound) {
-			return Response(http.StatusNotFound, ErrorResponse{Reason: "bid not found"}), err
+			return errorResult(ErrCodeBidNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Failed to get old bid."}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
-	createdAt := time.Now().Format(time.RFC3339)
-
-	query, args, err := s.builder.Insert("bid_feedback").
-		Columns("bid_id", "feedback", "username", "created_at").
-		Values(oldBid.Id, bidFeedback, username, createdAt).ToSql()
+	tender, err := s.loadTender(ctx, oldBid.TenderId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	// Feedback is left by the responsibles of the tender, once per bid each.
+	if err := s.checkTenderRights(ctx, user, tender); err != nil {
+		return apiErrorResult(err)
+	}
+	if tender.bidsSealed() {
+		return apiErrorResult(sealedBidsError(tender))
+	}
 
+	tx, err := s.pg.Pool.Begin(ctx)
 	if err != nil {
-		log.Error("Failed to build SQL", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
+	defer tx.Rollback(ctx)
 
-	_, err = s.pg.Pool.Exec(ctx, query, args...)
+	feedback, err := scanFeedback(tx.QueryRow(ctx, `
+	INSERT INTO bid_feedback AS f (feedback_id, bid_id, feedback, username, rating, tags, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING `+feedbackColumns,
+		uuid.New(), bidIdUUID, bidFeedback, user.Username, nullableRating(rating), feedbackTagStrings(tags)))
 	if err != nil {
+		var pgErr *pgconn.PgError
+		// unique_violation on the current feedback of the responsible
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return errorDetailResult(ErrCodeFeedbackExists, MsgFeedbackExists)
+		}
 		log.Error("error to build a query pool", slog.Any("error", err))
-		return Response(http.StatusNotFound, ErrorResponse{Reason: "Tender not found"}), ErrSQLQuery
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := recordFeedbackVersion(ctx, tx, feedback); err != nil {
+		log.Error("Failed to save the feedback history", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := refreshReputation(ctx, tx, bidIdUUID); err != nil {
+		log.Error("Failed to count the feedback in the reputation", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
 	}
 	respondBid := Bid{
-		Id: oldBid.Id,
-		Name: oldBid.Name,
-		Status: oldBid.Status,
+		Id:         oldBid.Id,
+		Name:       oldBid.Name,
+		Status:     oldBid.Status,
 		AuthorType: oldBid.AuthorType,
-		AuthorId: oldBid.AuthorId,
-		Version: oldBid.Version,
-		CreatedAt: oldBid.CreatedAt,
+		AuthorId:   oldBid.AuthorId,
+		Version:    oldBid.Version,
+		CreatedAt:  oldBid.CreatedAt,
 	}
 	return Response(http.StatusOK, respondBid), nil
 }
 
 // UpdateBidStatus - Изменение статуса предложения (протестил)
 func (s *DefaultAPIService) UpdateBidStatus(ctx context.Context, bidId string, status BidStatus, username string) (ImplResponse, error) {
-    if !status.IsValid() {
-        return Response(http.StatusBadRequest, ErrorResponse{Reason: "Invalid bid status"}), nil
-    }
+	if !status.IsValid() {
+		return errorResult(ErrCodeInvalidStatus, nil)
+	}
 
 	bidIdUUID, err := s.ConvertIntoUUID(bidId)
 	if err != nil {
 		s.log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest , ErrorResponse{Reason: "Ivalid ID parameter. Must be UUID formatted"}), err
-	}
-
-    var userId uuid.UUID
-    sql, args, err := s.builder.
-        Select("id").
-        From("employee").
-        Where(squirrel.Eq{"username": username}).
-        ToSql()
-
-    if err != nil {
-        s.log.Error("Failed to build SQL query", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
-    }
-
-    err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&userId)
-    if err != nil {
-        s.log.Error("Failed to execute SQL query to get user id", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Database query error"}), err
-    }
-
-    var existingAuthorId uuid.UUID
-    sql, args, err = s.builder.
-        Select("author_id").
-        From("bids").
-        Where(squirrel.Eq{"bid_id": bidIdUUID}).
-        ToSql()
-
-    if err != nil {
-        s.log.Error("Failed to build SQL query to check bid author", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
-    }
-
-    err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&existingAuthorId)
-    if err != nil {
-        s.log.Error("Failed to execute SQL query to check bid author", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Database query error"}), err
-    }
-
-    if existingAuthorId != userId {
-        return Response(http.StatusForbidden, ErrorResponse{Reason: "You are not the author of this bid"}), nil
-    }
+		return errorResult(ErrCodeInvalidID, err)
+	}
+
+	var userId uuid.UUID
+	sql, args, err := s.builder.
+		Select("id").
+		From("employee").
+		Where(squirrel.Eq{"username": username}).
+		ToSql()
+
+	if err != nil {
+		s.log.Error("Failed to build SQL query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&userId)
+	if err != nil {
+		s.log.Error("Failed to execute SQL query to get user id", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	var existingAuthorId uuid.UUID
+	sql, args, err = s.builder.
+		Select("author_id").
+		From("bids").
+		Where(squirrel.Eq{"bid_id": bidIdUUID}).
+		ToSql()
+
+	if err != nil {
+		s.log.Error("Failed to build SQL query to check bid author", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(&existingAuthorId)
+	if err != nil {
+		s.log.Error("Failed to execute SQL query to check bid author", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if existingAuthorId != userId {
+		return errorResult(ErrCodeForbiddenNotAuthor, nil)
+	}
 
 	if status.IsValid() == false {
-		return Response(http.StatusBadRequest, ErrorResponse{"Status not correct."}), nil
+		return errorResult(ErrCodeInvalidStatus, nil)
 	}
 
-    sql, args, err = s.builder.
-        Update("bids").
-        Set("status", status).
-        Where(squirrel.Eq{"bid_id": bidIdUUID}).
-        ToSql()
+	sql, args, err = s.builder.
+		Update("bids").
+		Set("status", status).
+		Where(squirrel.Eq{"bid_id": bidIdUUID}).
+		ToSql()
 
-    if err != nil {
-        s.log.Error("Failed to build SQL query to update bid status", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
-    }
+	if err != nil {
+		s.log.Error("Failed to build SQL query to update bid status", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
 
-    cmdTag, err := s.pg.Pool.Exec(ctx, sql, args...)
-    if err != nil {
-        s.log.Error("Failed to execute SQL query to update bid status", slog.Any("error", err))
-        return Response(http.StatusInternalServerError, ErrorResponse{Reason: "Database query error"}), err
-    }
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	cmdTag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		s.log.Error("Failed to execute SQL query to update bid status", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
 
-    if cmdTag.RowsAffected() == 0 {
-        return Response(http.StatusNotFound, ErrorResponse{Reason: "Bid not found or not updated"}), nil
-    }
+	if cmdTag.RowsAffected() == 0 {
+		return errorResult(ErrCodeBidNotFound, nil)
+	}
+
+	// A canceled bid counts against its author.
+	if err := refreshReputation(ctx, tx, bidIdUUID); err != nil {
+		s.log.Error("Failed to count the status in the reputation", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
 
 	newBid, err := s.getBidById(ctx, bidIdUUID)
 	if err != nil {
 		s.log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error (bidID)"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	s.log.Info(newBid.Name)
 
-    return Response(http.StatusOK, newBid), nil
+	if err := s.unsealBid(newBid); err != nil {
+		s.log.Error("Failed to unseal bid", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	return Response(http.StatusOK, newBid), nil
 }
 
 // UpdateTenderStatus - Изменение статуса тендера (протестил)
@@ -1247,30 +1617,30 @@ func (s *DefaultAPIService) UpdateTenderStatus(ctx context.Context, tenderId str
 	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
 	if err != nil {
 		log.Error("tenderid is not uuid", slog.Any("error", err))
-		return Response(http.StatusBadRequest , ErrorResponse{Reason: "Ivalid ID parameter. Must be UUID formatted"}), err
+		return errorResult(ErrCodeInvalidID, err)
 	}
 
 	user, err := s.getUserByName(ctx, username)
 	if err != nil {
 		log.Error("no user by name", slog.Any("error", err))
 		if errors.Is(err, ErrNoUser) {
-			return Response(http.StatusUnauthorized, ErrorResponse{Reason: "Пользователь не существует или некорректен"}), nil
+			return errorResult(ErrCodeUserNotFound, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: err.Error()}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
 	if err1 != nil {
 		if errors.Is(err1, ErrUserNoRightsTender) {
 			log.Error("user does not have rights for the tender", slog.Any("error", err2))
-			return Response(http.StatusForbidden, ErrorResponse{"Недостаточно прав для выполнения действия"}), err2
+			return errorResult(ErrCodeForbiddenNotResponsible, err1)
 		}
 		log.Error("error to find a user sql error", slog.Any("error", err2))
-		return Response(http.StatusInternalServerError, ErrorResponse{"couldn't process an sql query"}), err2
+		return errorResult(ErrCodeInternal, err2)
 	}
 
 	if status.IsValid() == false {
-		return Response(http.StatusBadRequest, ErrorResponse{"Status not correct."}), nil
+		return errorResult(ErrCodeInvalidStatus, nil)
 	}
 
 	query, args, err := s.builder.Update("tenders").
@@ -1280,22 +1650,44 @@ func (s *DefaultAPIService) UpdateTenderStatus(ctx context.Context, tenderId str
 
 	if err != nil {
 		s.log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
-	_, err = s.pg.Pool.Exec(ctx, query, args...)
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	previous, err := lockTender(ctx, tx, tenderIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
 	if err != nil {
 		log.Error("error to build a query pool", slog.Any("error", err))
-		if errors.Is(pgx.ErrNoRows, err) {
-			return Response(http.StatusNotFound, "empty return body, there is no data"), nil
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if status == CLOSED && previous != CLOSED {
+		if err := recordEvent(ctx, tx, eventTenderClosed, tenderIdUUID, map[string]any{"tenderId": tenderIdUUID}); err != nil {
+			log.Error("Failed to record the event", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
 		}
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	newTender, err := s.getTenderById(ctx, tenderIdUUID)
 	if err != nil {
 		s.log.Error("Failed to build SQL query", slog.Any("error", err))
-		return Response(http.StatusInternalServerError, ErrorResponse{Reason: "SQL query error"}), err
+		return errorResult(ErrCodeInternal, err)
 	}
 
 	s.log.Info(newTender.Name)
@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed docs/index.html
+var docsPage []byte
+
+// DocsAPIController serves the OpenAPI document the server implements and the API explorer page.
+type DocsAPIController struct {
+	specYAML []byte
+	specJSON []byte
+}
+
+// NewDocsAPIController creates a docs api controller for the given YAML spec.
+func NewDocsAPIController(spec []byte) (*DocsAPIController, error) {
+	var doc any
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("NewDocsAPIController: parse spec: %w", err)
+	}
+	specJSON, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("NewDocsAPIController: convert spec to json: %w", err)
+	}
+
+	return &DocsAPIController{
+		specYAML: spec,
+		specJSON: specJSON,
+	}, nil
+}
+
+// Routes returns all the api routes for the DocsAPIController
+func (c *DocsAPIController) Routes() Routes {
+	return Routes{
+		"GetOpenAPIYAML": Route{
+			strings.ToUpper("Get"),
+			"/api/openapi.yaml",
+			c.GetOpenAPIYAML,
+		},
+		"GetOpenAPIJSON": Route{
+			strings.ToUpper("Get"),
+			"/api/openapi.json",
+			c.GetOpenAPIJSON,
+		},
+		"GetDocs": Route{
+			strings.ToUpper("Get"),
+			"/api/docs",
+			c.GetDocs,
+		},
+	}
+}
+
+// GetOpenAPIYAML - Спецификация API в формате YAML
+func (c *DocsAPIController) GetOpenAPIYAML(w http.ResponseWriter, r *http.Request) {
+	writeStatic(w, "application/yaml", c.specYAML)
+}
+
+// GetOpenAPIJSON - Спецификация API в формате JSON
+func (c *DocsAPIController) GetOpenAPIJSON(w http.ResponseWriter, r *http.Request) {
+	writeStatic(w, "application/json", c.specJSON)
+}
+
+// GetDocs - Документация API
+func (c *DocsAPIController) GetDocs(w http.ResponseWriter, r *http.Request) {
+	writeStatic(w, "text/html; charset=utf-8", docsPage)
+}
+
+func writeStatic(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// EvaluationAPIController binds evaluation requests to the evaluation service and writes the service results to the http response
+type EvaluationAPIController struct {
+	service      EvaluationAPIServicer
+	errorHandler ErrorHandler
+}
+
+// EvaluationAPIOption for how the controller is set up.
+type EvaluationAPIOption func(*EvaluationAPIController)
+
+// WithEvaluationAPIErrorHandler inject ErrorHandler into controller
+func WithEvaluationAPIErrorHandler(h ErrorHandler) EvaluationAPIOption {
+	return func(c *EvaluationAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewEvaluationAPIController creates an evaluation api controller
+func NewEvaluationAPIController(s EvaluationAPIServicer, opts ...EvaluationAPIOption) *EvaluationAPIController {
+	controller := &EvaluationAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the EvaluationAPIController
+func (c *EvaluationAPIController) Routes() Routes {
+	return Routes{
+		"ListTenderCriteria": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/criteria",
+			c.ListTenderCriteria,
+		},
+		"CreateTenderCriterion": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/{tenderId}/criteria",
+			c.CreateTenderCriterion,
+		},
+		"DeleteTenderCriterion": Route{
+			strings.ToUpper("Delete"),
+			"/api/tenders/{tenderId}/criteria/{criterionId}",
+			c.DeleteTenderCriterion,
+		},
+		"GetTenderRanking": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/ranking",
+			c.GetTenderRanking,
+		},
+		"SubmitBidScores": Route{
+			strings.ToUpper("Put"),
+			"/api/bids/{bidId}/scores",
+			c.SubmitBidScores,
+		},
+	}
+}
+
+// ListTenderCriteria - Критерии оценки тендера
+func (c *EvaluationAPIController) ListTenderCriteria(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", false)
+	if !ok {
+		return
+	}
+	result, err := c.service.ListTenderCriteria(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// CreateTenderCriterion - Добавление критерия оценки
+func (c *EvaluationAPIController) CreateTenderCriterion(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", true)
+	if !ok {
+		return
+	}
+	createCriterionRequestParam := CreateCriterionRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&createCriterionRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertCreateCriterionRequestRequired(createCriterionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertCreateCriterionRequestConstraints(createCriterionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.CreateTenderCriterion(r.Context(), tenderIdParam, usernameParam, createCriterionRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// DeleteTenderCriterion - Удаление критерия оценки
+func (c *EvaluationAPIController) DeleteTenderCriterion(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", true)
+	if !ok {
+		return
+	}
+	criterionIdParam := mux.Vars(r)["criterionId"]
+	if criterionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"criterionId"}, nil)
+		return
+	}
+	result, err := c.service.DeleteTenderCriterion(r.Context(), tenderIdParam, criterionIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// GetTenderRanking - Рейтинг предложений тендера
+func (c *EvaluationAPIController) GetTenderRanking(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId", true)
+	if !ok {
+		return
+	}
+	result, err := c.service.GetTenderRanking(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// SubmitBidScores - Оценка предложения по критериям
+func (c *EvaluationAPIController) SubmitBidScores(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.entityParams(w, r, "bidId", true)
+	if !ok {
+		return
+	}
+	submitBidScoresRequestParam := SubmitBidScoresRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&submitBidScoresRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertSubmitBidScoresRequestRequired(submitBidScoresRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertSubmitBidScoresRequestConstraints(submitBidScoresRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.SubmitBidScores(r.Context(), bidIdParam, usernameParam, submitBidScoresRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// entityParams reads the id of the tender or bid from the path and the username from the query.
+func (c *EvaluationAPIController) entityParams(w http.ResponseWriter, r *http.Request, idParam string, usernameRequired bool) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	id := mux.Vars(r)[idParam]
+	if id == "" {
+		c.errorHandler(w, r, &RequiredError{idParam}, nil)
+		return "", "", false
+	}
+	if usernameRequired && !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return id, query.Get("username"), true
+}
+
+func (c *EvaluationAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	if result.Code == http.StatusNoContent {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,312 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// EvaluationAPIService manages the evaluation criteria of tenders and the scores responsibles
+// give to bids. Criteria are public for published tenders; scores and the ranking are seen
+// only by the responsibles of the tender organization.
+type EvaluationAPIService struct {
+	*DefaultAPIService
+}
+
+// NewEvaluationAPIService creates an evaluation api service
+func NewEvaluationAPIService(pg *Postgres, log *slog.Logger) *EvaluationAPIService {
+	return &EvaluationAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// ListTenderCriteria - Критерии оценки тендера
+func (s *EvaluationAPIService) ListTenderCriteria(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	criteria, err := s.getTenderCriteria(ctx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, criteria), nil
+}
+
+// CreateTenderCriterion - Добавление критерия оценки, пока предложения не оценивались
+func (s *EvaluationAPIService) CreateTenderCriterion(ctx context.Context, tenderId string, username string, req CreateCriterionRequest) (ImplResponse, error) {
+	const op = "EvaluationAPIService.CreateTenderCriterion"
+	log := s.log.With(slog.String("op", op))
+
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockTender(ctx, tx, tenderIdUUID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	started, err := evaluationStarted(ctx, tx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if started {
+		return errorDetailResult(ErrCodeEvaluationStarted, MsgEvaluationStarted)
+	}
+
+	sql, args, err := s.builder.
+		Insert("evaluation_criteria").
+		Columns("id", "tender_id", "name", "description", "weight").
+		Values(uuid.New(), tenderIdUUID, req.Name, req.Description, req.Weight).
+		Suffix("RETURNING " + strings.Join(criterionColumns, ", ")).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	criterion, err := scanCriterion(tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		log.Error("failed to save the criterion", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusCreated, criterion), nil
+}
+
+// DeleteTenderCriterion - Удаление критерия оценки, пока предложения не оценивались
+func (s *EvaluationAPIService) DeleteTenderCriterion(ctx context.Context, tenderId string, criterionId string, username string) (ImplResponse, error) {
+	const op = "EvaluationAPIService.DeleteTenderCriterion"
+	log := s.log.With(slog.String("op", op))
+
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	criterionIdUUID, err := s.ConvertIntoUUID(criterionId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockTender(ctx, tx, tenderIdUUID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	started, err := evaluationStarted(ctx, tx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if started {
+		return errorDetailResult(ErrCodeEvaluationStarted, MsgEvaluationStarted)
+	}
+
+	sql, args, err := s.builder.
+		Delete("evaluation_criteria").
+		Where(squirrel.Eq{"id": criterionIdUUID, "tender_id": tenderIdUUID}).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to delete the criterion", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errorResult(ErrCodeCriterionNotFound, ErrNotFound)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusNoContent, nil), nil
+}
+
+// SubmitBidScores - Оценка опубликованного предложения ответственным за тендер. Повторная
+// оценка по критерию заменяет прежнюю
+func (s *EvaluationAPIService) SubmitBidScores(ctx context.Context, bidId string, username string, req SubmitBidScoresRequest) (ImplResponse, error) {
+	const op = "EvaluationAPIService.SubmitBidScores"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bid, err := s.loadBid(ctx, bidId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tender, err := s.loadTender(ctx, bid.TenderId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if err := s.checkTenderRights(ctx, user, tender); err != nil {
+		return apiErrorResult(err)
+	}
+	if tender.bidsSealed() {
+		return apiErrorResult(sealedBidsError(tender))
+	}
+	if bid.Status != PUBLISHED_BID {
+		return errorDetailResult(ErrCodeInvalidStatus, MsgBidNotPublished)
+	}
+	bidIdUUID, _ := s.ConvertIntoUUID(bid.Id)
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The lock keeps the criteria from being deleted until the scores are saved.
+	if _, err := lockTender(ctx, tx, tenderIdUUID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	criteria, err := s.getTenderCriteria(ctx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if len(criteria) == 0 {
+		return errorDetailResult(ErrCodeValidationFailed, MsgCriteriaAbsent)
+	}
+
+	insert := s.builder.
+		Insert("bid_scores").
+		Columns("bid_id", "criterion_id", "evaluator_id", "score", "comment")
+	for _, sc := range req.Scores {
+		criterionIdUUID, _ := s.ConvertIntoUUID(sc.CriterionId)
+		if !slices.ContainsFunc(criteria, func(c EvaluationCriterion) bool { return c.Id == criterionIdUUID.String() }) {
+			return errorDetailResult(ErrCodeValidationFailed, MsgCriterionUnknown, sc.CriterionId)
+		}
+		insert = insert.Values(bidIdUUID, criterionIdUUID, user.Id, sc.Score, sc.Comment)
+	}
+
+	sql, args, err := insert.
+		Suffix("ON CONFLICT (bid_id, criterion_id, evaluator_id) DO UPDATE SET score = EXCLUDED.score, comment = EXCLUDED.comment, updated_at = CURRENT_TIMESTAMP").
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		log.Error("failed to save the scores", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	scores, err := s.getScores(ctx, squirrel.Eq{"s.bid_id": bidIdUUID, "s.evaluator_id": user.Id})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	result := make([]BidScore, 0, len(scores))
+	for _, sc := range scores {
+		result = append(result, sc.BidScore)
+	}
+	return Response(http.StatusOK, result), nil
+}
+
+// GetTenderRanking - Рейтинг опубликованных предложений тендера по взвешенной оценке
+func (s *EvaluationAPIService) GetTenderRanking(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if tender.bidsSealed() {
+		return apiErrorResult(sealedBidsError(tender))
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	criteria, err := s.getTenderCriteria(ctx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	bids, err := s.publishedBids(ctx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	scores, err := s.getScores(ctx, squirrel.Eq{"c.tender_id": tenderIdUUID})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	return Response(http.StatusOK, TenderRanking{
+		TenderId: tender.Id,
+		Criteria: criteria,
+		Bids:     rankBids(criteria, bids, scores),
+	}), nil
+}
+
+// publishedBids returns the ids and names of the published bids of a tender in the order
+// they were created.
+func (s *EvaluationAPIService) publishedBids(ctx context.Context, tenderId uuid.UUID) ([]Bid, error) {
+	const op = "EvaluationAPIService.publishedBids"
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := s.builder.
+		Select("bid_id", "name").
+		From("bids").
+		Where(squirrel.Eq{"tender_id": tenderId, "status": string(PUBLISHED_BID)}).
+		OrderBy("created_at", "bid_id").
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to fetch bids", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	defer rows.Close()
+
+	var bids []Bid
+	for rows.Next() {
+		var bid Bid
+		var id uuid.UUID
+		if err := rows.Scan(&id, &bid.Name); err != nil {
+			log.Error("failed to scan bid", slog.Any("error", err))
+			return nil, ErrSQLQuery
+		}
+		bid.Id = id.String()
+		bids = append(bids, bid)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating over rows", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	return bids, nil
+}
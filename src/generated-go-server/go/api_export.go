@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// ExportAPIController binds export requests to the export service and streams the exported files
+type ExportAPIController struct {
+	service      ExportAPIServicer
+	errorHandler ErrorHandler
+}
+
+// ExportAPIOption for how the controller is set up.
+type ExportAPIOption func(*ExportAPIController)
+
+// WithExportAPIErrorHandler inject ErrorHandler into controller
+func WithExportAPIErrorHandler(h ErrorHandler) ExportAPIOption {
+	return func(c *ExportAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewExportAPIController creates an export api controller
+func NewExportAPIController(s ExportAPIServicer, opts ...ExportAPIOption) *ExportAPIController {
+	controller := &ExportAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the ExportAPIController
+func (c *ExportAPIController) Routes() Routes {
+	return Routes{
+		"ExportTenders": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/export",
+			c.ExportTenders,
+		},
+		"ExportBids": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/bids/export",
+			c.ExportBids,
+		},
+	}
+}
+
+// ExportTenders - Выгрузка тендеров в CSV или XLSX
+func (c *ExportAPIController) ExportTenders(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	formatParam, err := parseExportFormat(query.Get("format"))
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	var serviceTypeParam []TenderServiceType
+	for _, value := range query["service_type"] {
+		for _, param := range strings.Split(value, ",") {
+			paramEnum, err := NewTenderServiceTypeFromValue(param)
+			if err != nil {
+				c.errorHandler(w, r, &ParsingError{Param: "service_type", Err: err}, nil)
+				return
+			}
+			serviceTypeParam = append(serviceTypeParam, paramEnum)
+		}
+	}
+	result, err := c.service.ExportTenders(r.Context(), formatParam, serviceTypeParam)
+	c.writeExport(w, r, result, err)
+}
+
+// ExportBids - Выгрузка предложений тендера в CSV или XLSX
+func (c *ExportAPIController) ExportBids(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	tenderIdParam := params["tenderId"]
+	if tenderIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"tenderId"}, nil)
+		return
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return
+	}
+	usernameParam := query.Get("username")
+	formatParam, err := parseExportFormat(query.Get("format"))
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	sortParam := BID_SORT_CREATED_AT
+	if query.Has("sort") {
+		param, err := NewBidSortFromValue(query.Get("sort"))
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "sort", Err: NewLocalizedError(MsgOneOf, bidSortValues)}, nil)
+			return
+		}
+		sortParam = param
+	}
+	result, err := c.service.ExportBids(r.Context(), tenderIdParam, usernameParam, formatParam, sortParam)
+	c.writeExport(w, r, result, err)
+}
+
+func (c *ExportAPIController) writeExport(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	if export, ok := result.Body.(*Export); ok {
+		if err := WriteExport(w, r, export); err != nil {
+			c.errorHandler(w, r, err, nil)
+		}
+		return
+	}
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// parseExportFormat parses the format query parameter, csv by default.
+func parseExportFormat(v string) (ExportFormat, error) {
+	if v == "" {
+		return EXPORT_CSV, nil
+	}
+	format := ExportFormat(v)
+	if !format.IsValid() {
+		return "", &ParsingError{Param: "format", Err: NewLocalizedError(MsgOneOf, "'csv', 'xlsx'")}
+	}
+	return format, nil
+}
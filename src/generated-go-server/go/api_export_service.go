@@ -0,0 +1,201 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Columns of the exported files. Names match the JSON fields of the list endpoints.
+var (
+	tenderExportColumns = []string{
+		"id", "name", "description", "serviceType", "status", "organizationId", "budgetAmount", "budgetCurrency",
+		"version", "createdAt", "bids", "approvals", "rejections", "feedback",
+	}
+	bidExportColumns = []string{
+		"id", "name", "description", "status", "authorType", "authorId", "priceAmount", "priceCurrency",
+		"version", "versions", "approvals", "rejections", "feedback", "createdAt",
+	}
+)
+
+// Per-bid tallies of decisions and reviews, correlated with the bids row aliased b.
+const (
+	bidApprovalsColumn  = "(SELECT count(*) FROM bid_decisions d WHERE d.bid_id = b.bid_id AND d.decision = 'Approved')"
+	bidRejectionsColumn = "(SELECT count(*) FROM bid_decisions d WHERE d.bid_id = b.bid_id AND d.decision = 'Rejected')"
+	bidFeedbackColumn   = "(SELECT count(*) FROM bid_feedback f WHERE f.bid_id = b.bid_id AND f.retracted_at IS NULL)"
+)
+
+// ExportAPIService streams tenders and bids as CSV or XLSX files for reporting.
+type ExportAPIService struct {
+	*DefaultAPIService
+}
+
+// NewExportAPIService creates an export api service
+func NewExportAPIService(pg *Postgres, log *slog.Logger) *ExportAPIService {
+	return &ExportAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// ExportTenders - Выгрузка тендеров с числом предложений, решений и отзывов
+func (s *ExportAPIService) ExportTenders(ctx context.Context, format ExportFormat, serviceType []TenderServiceType) (ImplResponse, error) {
+	for i := range serviceType {
+		if !serviceType[i].IsValid() {
+			return errorDetailResult(ErrCodeInvalidParameter, MsgUnknownServiceType)
+		}
+	}
+
+	query := s.builder.
+		Select(
+			"t.id::text", "t.name", "COALESCE(t.description, '')", "t.service_type::text", "COALESCE(t.status, '')",
+			"COALESCE(t.organization_id::text, '')", "COALESCE(t.budget_amount::text, '')", "COALESCE(t.budget_currency, '')",
+			"t.version", "t.created_at",
+			"(SELECT count(*) FROM bids b WHERE b.tender_id = t.id)",
+			"(SELECT count(*) FROM bid_decisions d JOIN bids b ON b.bid_id = d.bid_id WHERE b.tender_id = t.id AND d.decision = 'Approved')",
+			"(SELECT count(*) FROM bid_decisions d JOIN bids b ON b.bid_id = d.bid_id WHERE b.tender_id = t.id AND d.decision = 'Rejected')",
+			"(SELECT count(*) FROM bid_feedback f JOIN bids b ON b.bid_id = f.bid_id WHERE b.tender_id = t.id AND f.retracted_at IS NULL)",
+		).
+		From("tenders t").
+		Where(squirrel.Eq{"t.private": false}).
+		OrderBy("t.created_at", "t.id")
+
+	if len(serviceType) > 0 {
+		query = query.Where(squirrel.Eq{"t.service_type": serviceType})
+	}
+
+	return Response(http.StatusOK, &Export{
+		Format:  format,
+		Name:    "tenders",
+		Columns: tenderExportColumns,
+		Rows: func(ctx context.Context, emit func([]any) error) error {
+			var (
+				id, name, description, tenderServiceType, status, organizationId string
+				budgetAmount, budgetCurrency                                     string
+				version                                                          int32
+				createdAt                                                        time.Time
+				bids, approvals, rejections, feedback                            int64
+			)
+			return s.streamRows(ctx, query, []any{
+				&id, &name, &description, &tenderServiceType, &status, &organizationId, &budgetAmount, &budgetCurrency,
+				&version, &createdAt, &bids, &approvals, &rejections, &feedback,
+			}, func() error {
+				return emit([]any{
+					id, name, description, tenderServiceType, status, organizationId, budgetAmount, budgetCurrency,
+					version, createdAt.Format(time.RFC3339), bids, approvals, rejections, feedback,
+				})
+			})
+		},
+	}), nil
+}
+
+// ExportBids - Выгрузка предложений тендера с версиями, решениями и отзывами
+func (s *ExportAPIService) ExportBids(ctx context.Context, tenderId string, username string, format ExportFormat, sort BidSort) (ImplResponse, error) {
+	const op = "ExportAPIService.ExportBids"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.getUserByName(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrNoUser) {
+			return errorResult(ErrCodeUserNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+
+	tender, err := s.getTenderById(ctx, tenderIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
+	if err1 != nil {
+		if errors.Is(err1, ErrUserNoRightsTender) {
+			log.Error("user does not have rights for the tender", slog.Any("error", err2))
+			return errorResult(ErrCodeForbiddenNotResponsible, err1)
+		}
+		return errorResult(ErrCodeInternal, err2)
+	}
+	if sort == "" {
+		sort = BID_SORT_CREATED_AT
+	}
+	orderBy, ok := bidSortOrderBy[sort]
+	if !ok {
+		return errorDetailResult(ErrCodeInvalidParameter, MsgOneOf, bidSortValues)
+	}
+	sealed := tender.bidsSealed()
+	if sealed && sort != BID_SORT_CREATED_AT_DESC {
+		// Ordering by name or price would give the hidden contents away.
+		orderBy = bidSortOrderBy[BID_SORT_CREATED_AT]
+	}
+
+	query := s.builder.
+		Select(
+			"b.bid_id::text", "b.name", "COALESCE(b.description, '')", "COALESCE(b.status, '')", "b.author_type",
+			"b.author_id::text", "COALESCE(b.price_amount::text, '')", "COALESCE(b.price_currency, '')", "COALESCE(b.version, 1)",
+			"(SELECT count(*) FROM bids_versions v WHERE v.bid_id = b.bid_id)",
+			bidApprovalsColumn, bidRejectionsColumn, bidFeedbackColumn, "b.created_at",
+		).
+		From("bids b").
+		Where(squirrel.Eq{"b.tender_id": tenderIdUUID}).
+		OrderBy(orderBy)
+
+	return Response(http.StatusOK, &Export{
+		Format:  format,
+		Name:    "bids",
+		Columns: bidExportColumns,
+		Rows: func(ctx context.Context, emit func([]any) error) error {
+			var (
+				id, name, description, status, authorType, authorId string
+				priceAmount, priceCurrency                          string
+				version                                             int32
+				versions, approvals, rejections, feedback           int64
+				createdAt                                           time.Time
+			)
+			return s.streamRows(ctx, query, []any{
+				&id, &name, &description, &status, &authorType, &authorId, &priceAmount, &priceCurrency, &version,
+				&versions, &approvals, &rejections, &feedback, &createdAt,
+			}, func() error {
+				if sealed {
+					name, description, priceAmount, priceCurrency = "", "", "", ""
+				}
+				return emit([]any{
+					id, name, description, status, authorType, authorId, priceAmount, priceCurrency, version,
+					versions, approvals, rejections, feedback, createdAt.Format(time.RFC3339),
+				})
+			})
+		},
+	}), nil
+}
+
+// streamRows scans the rows of query one by one into dest and calls row after each scan.
+func (s *ExportAPIService) streamRows(ctx context.Context, query squirrel.SelectBuilder, dest []any, row func() error) error {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := row(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
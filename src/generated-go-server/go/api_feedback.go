@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// FeedbackAPIController binds feedback requests to the feedback service and writes the service results to the http response
+type FeedbackAPIController struct {
+	service      FeedbackAPIServicer
+	errorHandler ErrorHandler
+}
+
+// FeedbackAPIOption for how the controller is set up.
+type FeedbackAPIOption func(*FeedbackAPIController)
+
+// WithFeedbackAPIErrorHandler inject ErrorHandler into controller
+func WithFeedbackAPIErrorHandler(h ErrorHandler) FeedbackAPIOption {
+	return func(c *FeedbackAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewFeedbackAPIController creates a feedback api controller
+func NewFeedbackAPIController(s FeedbackAPIServicer, opts ...FeedbackAPIOption) *FeedbackAPIController {
+	controller := &FeedbackAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the FeedbackAPIController
+func (c *FeedbackAPIController) Routes() Routes {
+	return Routes{
+		"EditBidFeedback": Route{
+			strings.ToUpper("Patch"),
+			"/api/bids/{bidId}/feedback",
+			c.EditBidFeedback,
+		},
+		"RetractBidFeedback": Route{
+			strings.ToUpper("Delete"),
+			"/api/bids/{bidId}/feedback",
+			c.RetractBidFeedback,
+		},
+		"GetBidFeedbackHistory": Route{
+			strings.ToUpper("Get"),
+			"/api/bids/{bidId}/feedback/history",
+			c.GetBidFeedbackHistory,
+		},
+		"GetBidReviewSummary": Route{
+			strings.ToUpper("Get"),
+			"/api/bids/{tenderId}/reviews/summary",
+			c.GetBidReviewSummary,
+		},
+	}
+}
+
+// EditBidFeedback - Изменение своего отзыва о предложении
+func (c *FeedbackAPIController) EditBidFeedback(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.bidParams(w, r)
+	if !ok {
+		return
+	}
+	editBidFeedbackRequestParam := EditBidFeedbackRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&editBidFeedbackRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertEditBidFeedbackRequestRequired(editBidFeedbackRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertEditBidFeedbackRequestConstraints(editBidFeedbackRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.EditBidFeedback(r.Context(), bidIdParam, usernameParam, editBidFeedbackRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// RetractBidFeedback - Отзыв своего отзыва о предложении
+func (c *FeedbackAPIController) RetractBidFeedback(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.bidParams(w, r)
+	if !ok {
+		return
+	}
+	result, err := c.service.RetractBidFeedback(r.Context(), bidIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// GetBidFeedbackHistory - История своих отзывов о предложении
+func (c *FeedbackAPIController) GetBidFeedbackHistory(w http.ResponseWriter, r *http.Request) {
+	bidIdParam, usernameParam, ok := c.bidParams(w, r)
+	if !ok {
+		return
+	}
+	result, err := c.service.GetBidFeedbackHistory(r.Context(), bidIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// GetBidReviewSummary - Сводка отзывов на предложения автора по тендеру
+func (c *FeedbackAPIController) GetBidReviewSummary(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	tenderIdParam := mux.Vars(r)["tenderId"]
+	if tenderIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"tenderId"}, nil)
+		return
+	}
+	for _, field := range []string{"authorUsername", "requesterUsername"} {
+		if !query.Has(field) {
+			c.errorHandler(w, r, &RequiredError{Field: field}, nil)
+			return
+		}
+	}
+	result, err := c.service.GetBidReviewSummary(r.Context(), tenderIdParam, query.Get("authorUsername"), query.Get("requesterUsername"))
+	c.writeResult(w, r, result, err)
+}
+
+// bidParams reads the bid id from the path and the required username from the query.
+func (c *FeedbackAPIController) bidParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	bidIdParam := mux.Vars(r)["bidId"]
+	if bidIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"bidId"}, nil)
+		return "", "", false
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return bidIdParam, query.Get("username"), true
+}
+
+func (c *FeedbackAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
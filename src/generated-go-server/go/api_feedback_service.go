@@ -0,0 +1,205 @@
+package openapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// FeedbackAPIService lets the responsibles of a tender edit and retract the feedback they left
+// on its bids with SubmitBidFeedback. Every change is a new version of the feedback, kept in
+// its history, and is counted in the reputation of the bid author.
+type FeedbackAPIService struct {
+	*DefaultAPIService
+}
+
+// NewFeedbackAPIService creates a feedback api service
+func NewFeedbackAPIService(pg *Postgres, log *slog.Logger) *FeedbackAPIService {
+	return &FeedbackAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// EditBidFeedback - Изменение своего отзыва о предложении
+func (s *FeedbackAPIService) EditBidFeedback(ctx context.Context, bidId string, username string, req EditBidFeedbackRequest) (ImplResponse, error) {
+	const op = "FeedbackAPIService.EditBidFeedback"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bid, err := s.loadBid(ctx, bidId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bidIdUUID, _ := s.ConvertIntoUUID(bid.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	feedback, err := currentFeedback(ctx, tx, bidIdUUID, user.Username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if req.Feedback != "" {
+		feedback.Feedback = req.Feedback
+	}
+	if req.Rating != 0 {
+		feedback.Rating = req.Rating
+	}
+	if req.Tags != nil {
+		feedback.Tags = req.Tags
+	}
+
+	feedback, err = scanFeedback(tx.QueryRow(ctx, `
+	UPDATE bid_feedback AS f SET feedback = $2, rating = $3, tags = $4, version = f.version + 1, updated_at = CURRENT_TIMESTAMP
+	WHERE f.feedback_id = $1
+	RETURNING `+feedbackColumns,
+		feedback.Id, feedback.Feedback, nullableRating(feedback.Rating), feedbackTagStrings(feedback.Tags)))
+	if err != nil {
+		log.Error("failed to save the feedback", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := s.saveFeedbackChange(ctx, tx, feedback); err != nil {
+		log.Error("failed to save the feedback change", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, feedback), nil
+}
+
+// RetractBidFeedback - Отзыв своего отзыва о предложении
+func (s *FeedbackAPIService) RetractBidFeedback(ctx context.Context, bidId string, username string) (ImplResponse, error) {
+	const op = "FeedbackAPIService.RetractBidFeedback"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bid, err := s.loadBid(ctx, bidId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bidIdUUID, _ := s.ConvertIntoUUID(bid.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	feedback, err := currentFeedback(ctx, tx, bidIdUUID, user.Username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	feedback, err = scanFeedback(tx.QueryRow(ctx, `
+	UPDATE bid_feedback AS f SET retracted_at = CURRENT_TIMESTAMP, version = f.version + 1, updated_at = CURRENT_TIMESTAMP
+	WHERE f.feedback_id = $1
+	RETURNING `+feedbackColumns, feedback.Id))
+	if err != nil {
+		log.Error("failed to retract the feedback", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := s.saveFeedbackChange(ctx, tx, feedback); err != nil {
+		log.Error("failed to save the feedback change", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	log.Info("feedback retracted", slog.String("feedback_id", feedback.Id), slog.String("bid_id", feedback.BidId))
+	return Response(http.StatusNoContent, nil), nil
+}
+
+// saveFeedbackChange adds the new version of the feedback to its history, counts it in the
+// reputation and commits.
+func (s *FeedbackAPIService) saveFeedbackChange(ctx context.Context, tx pgx.Tx, feedback BidFeedback) error {
+	if err := recordFeedbackVersion(ctx, tx, feedback); err != nil {
+		return err
+	}
+	bidIdUUID, _ := s.ConvertIntoUUID(feedback.BidId)
+	if err := refreshReputation(ctx, tx, bidIdUUID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetBidFeedbackHistory - История своих отзывов о предложении
+func (s *FeedbackAPIService) GetBidFeedbackHistory(ctx context.Context, bidId string, username string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bid, err := s.loadBid(ctx, bidId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	bidIdUUID, _ := s.ConvertIntoUUID(bid.Id)
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+feedbackVersionColumns+` FROM bid_feedback_versions v
+	JOIN bid_feedback f ON f.feedback_id = v.feedback_id
+	WHERE f.bid_id = $1 AND f.username = $2
+	ORDER BY f.created_at, f.feedback_id, v.version`, bidIdUUID, user.Username)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	versions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (BidFeedbackVersion, error) {
+		return scanFeedbackVersion(row)
+	})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if len(versions) == 0 {
+		return errorDetailResult(ErrCodeFeedbackNotFound, MsgFeedbackNotFound)
+	}
+	return Response(http.StatusOK, versions), nil
+}
+
+// GetBidReviewSummary - Сводка отзывов на предложения автора по тендеру
+func (s *FeedbackAPIService) GetBidReviewSummary(ctx context.Context, tenderId string, authorUsername string, requesterUsername string) (ImplResponse, error) {
+	const op = "FeedbackAPIService.GetBidReviewSummary"
+	log := s.log.With(slog.String("op", op))
+
+	// The same access as GetBidReviews, whose reviews are summed up here.
+	tenderIdUUID, err := s.ConvertIntoUUID(tenderId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+	if _, err := s.loadUser(ctx, requesterUsername); err != nil {
+		return apiErrorResult(err)
+	}
+	author, err := s.loadUser(ctx, authorUsername)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	summary := BidReviewSummary{TenderId: tenderIdUUID.String(), AuthorUsername: author.Username, Tags: map[FeedbackTag]int32{}}
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT COALESCE(f.rating, 0), f.tags FROM bid_feedback f
+	JOIN bids b ON b.bid_id = f.bid_id
+	WHERE b.tender_id = $1 AND b.author_id = $2 AND f.retracted_at IS NULL`, tenderIdUUID, author.Id)
+	if err != nil {
+		log.Error("failed to read the reviews", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer rows.Close()
+	var ratingSum int32
+	for rows.Next() {
+		var rating int32
+		var tags []string
+		if err := rows.Scan(&rating, &tags); err != nil {
+			return errorResult(ErrCodeInternal, err)
+		}
+		summary.addReview(rating, feedbackTags(tags))
+		ratingSum += rating
+	}
+	if err := rows.Err(); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if summary.Ratings > 0 {
+		summary.AverageRating = roundedRatio(ratingSum, summary.Ratings, 100)
+	}
+	return Response(http.StatusOK, summary), nil
+}
@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// HealthAPIController binds http requests to the health service and writes the service results to the http response
+type HealthAPIController struct {
+	service      HealthAPIServicer
+	errorHandler ErrorHandler
+}
+
+// HealthAPIOption for how the controller is set up.
+type HealthAPIOption func(*HealthAPIController)
+
+// WithHealthAPIErrorHandler inject ErrorHandler into controller
+func WithHealthAPIErrorHandler(h ErrorHandler) HealthAPIOption {
+	return func(c *HealthAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewHealthAPIController creates a health api controller
+func NewHealthAPIController(s HealthAPIServicer, opts ...HealthAPIOption) *HealthAPIController {
+	controller := &HealthAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the HealthAPIController
+func (c *HealthAPIController) Routes() Routes {
+	return Routes{
+		"LiveCheck": Route{
+			strings.ToUpper("Get"),
+			"/api/health/live",
+			c.LiveCheck,
+		},
+		"ReadyCheck": Route{
+			strings.ToUpper("Get"),
+			"/api/health/ready",
+			c.ReadyCheck,
+		},
+	}
+}
+
+// LiveCheck - Проверка, что процесс жив
+func (c *HealthAPIController) LiveCheck(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.LiveCheck(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// ReadyCheck - Проверка готовности сервиса и его зависимостей
+func (c *HealthAPIController) ReadyCheck(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.ReadyCheck(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,140 @@
+package openapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+// HealthAPIService reports liveness and readiness of the service for container orchestrators.
+type HealthAPIService struct {
+	pg      *Postgres
+	workers *Workers
+	log     *slog.Logger
+
+	shuttingDown atomic.Bool
+}
+
+// NewHealthAPIService creates a health api service
+func NewHealthAPIService(pg *Postgres, workers *Workers, log *slog.Logger) *HealthAPIService {
+	return &HealthAPIService{
+		pg:      pg,
+		workers: workers,
+		log:     log,
+	}
+}
+
+// SetShuttingDown makes readiness fail so that no new traffic is routed to the instance.
+func (s *HealthAPIService) SetShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// LiveCheck - Процесс жив и обрабатывает запросы
+func (s *HealthAPIService) LiveCheck(ctx context.Context) (ImplResponse, error) {
+	return Response(http.StatusOK, HealthReport{Status: HealthStatusOK}), nil
+}
+
+// ReadyCheck - Сервис готов принимать трафик
+func (s *HealthAPIService) ReadyCheck(ctx context.Context) (ImplResponse, error) {
+	report := HealthReport{
+		Status: HealthStatusOK,
+		Checks: map[string]DependencyHealth{
+			"database":   s.checkDatabase(ctx),
+			"migrations": s.checkMigrations(ctx),
+			"workers":    s.checkWorkers(),
+			"outbox":     s.checkOutbox(ctx),
+			"mail":       s.checkMail(ctx),
+		},
+	}
+
+	if s.shuttingDown.Load() {
+		report.Checks["shutdown"] = DependencyHealth{Status: HealthStatusFail, Error: "server is shutting down"}
+	}
+
+	for _, check := range report.Checks {
+		if check.Status != HealthStatusOK {
+			report.Status = HealthStatusFail
+			return Response(http.StatusServiceUnavailable, report), nil
+		}
+	}
+
+	return Response(http.StatusOK, report), nil
+}
+
+func (s *HealthAPIService) checkDatabase(ctx context.Context) DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := s.pg.Pool.Ping(ctx)
+	check := DependencyHealth{
+		Status:    HealthStatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		s.log.Error("database health check failed", slog.Any("error", err))
+		check.Status = HealthStatusFail
+		check.Error = err.Error()
+	}
+	return check
+}
+
+func (s *HealthAPIService) checkMigrations(ctx context.Context) DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	state, err := GetMigrationState(ctx, s.pg)
+	check := DependencyHealth{Status: HealthStatusOK, Migrations: &state}
+	switch {
+	case err != nil:
+		check.Status = HealthStatusFail
+		check.Error = err.Error()
+	case state.Pending > 0:
+		check.Status = HealthStatusFail
+		check.Error = "migrations are pending"
+	}
+	return check
+}
+
+func (s *HealthAPIService) checkWorkers() DependencyHealth {
+	check := DependencyHealth{Status: HealthStatusOK, Workers: s.workers.Status()}
+	for _, w := range check.Workers {
+		if !w.Running {
+			check.Status = HealthStatusFail
+			check.Error = "worker " + w.Name + " is not running"
+			break
+		}
+	}
+	return check
+}
+
+func (s *HealthAPIService) checkOutbox(ctx context.Context) DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var backlog int64
+	err := s.pg.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM outbox_events WHERE processed_at IS NULL`).Scan(&backlog)
+	if err != nil {
+		return DependencyHealth{Status: HealthStatusFail, Error: err.Error()}
+	}
+	return DependencyHealth{Status: HealthStatusOK, Backlog: &backlog}
+}
+
+// checkMail reports how many emails wait to be sent; the queue grows while the mail server
+// is unreachable.
+func (s *HealthAPIService) checkMail(ctx context.Context) DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var backlog int64
+	err := s.pg.Pool.QueryRow(ctx, `
+	SELECT COUNT(*) FROM email_outbox WHERE sent_at IS NULL AND failed_at IS NULL`).Scan(&backlog)
+	if err != nil {
+		return DependencyHealth{Status: HealthStatusFail, Error: err.Error()}
+	}
+	return DependencyHealth{Status: HealthStatusOK, Backlog: &backlog}
+}
@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxImportBodySize limits the size of an uploaded import file.
+const maxImportBodySize = 10 << 20
+
+// ImportAPIController binds bulk import requests to the import service and writes the service results to the http response
+type ImportAPIController struct {
+	service      ImportAPIServicer
+	errorHandler ErrorHandler
+}
+
+// ImportAPIOption for how the controller is set up.
+type ImportAPIOption func(*ImportAPIController)
+
+// WithImportAPIErrorHandler inject ErrorHandler into controller
+func WithImportAPIErrorHandler(h ErrorHandler) ImportAPIOption {
+	return func(c *ImportAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewImportAPIController creates an import api controller
+func NewImportAPIController(s ImportAPIServicer, opts ...ImportAPIOption) *ImportAPIController {
+	controller := &ImportAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the ImportAPIController
+func (c *ImportAPIController) Routes() Routes {
+	return Routes{
+		"ImportTenders": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/import",
+			c.ImportTenders,
+		},
+	}
+}
+
+// ImportTenders - Массовое создание тендеров из CSV или NDJSON
+func (c *ImportAPIController) ImportTenders(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var dryRunParam bool
+	if query.Has("dryRun") {
+		param, err := parseBoolParameter(
+			query.Get("dryRun"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "dryRun", Err: err}, nil)
+			return
+		}
+
+		dryRunParam = param
+	}
+
+	var parse func(io.Reader) ([]TenderImportRow, error)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case importContentTypeCSV:
+		parse = ParseTenderImportCSV
+	case importContentTypeNDJSON:
+		parse = ParseTenderImportNDJSON
+	default:
+		c.errorHandler(w, r, &ParsingError{
+			Param: "Content-Type",
+			Err:   NewLocalizedError(MsgOneOf, "'"+importContentTypeCSV+"', '"+importContentTypeNDJSON+"'"),
+		}, nil)
+		return
+	}
+
+	rows, err := parse(http.MaxBytesReader(w, r.Body, maxImportBodySize))
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.ImportTenders(r.Context(), rows, dryRunParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
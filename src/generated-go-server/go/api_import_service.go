@@ -0,0 +1,229 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportAPIService creates tenders in bulk. All rows are checked before anything is written,
+// and either every row is inserted or none is.
+type ImportAPIService struct {
+	*DefaultAPIService
+}
+
+// NewImportAPIService creates an import api service. It takes the options of the default
+// service so that sealed tenders are imported only where they can be created.
+func NewImportAPIService(pg *Postgres, log *slog.Logger, opts ...DefaultAPIServiceOption) *ImportAPIService {
+	return &ImportAPIService{DefaultAPIService: NewDefaultAPIService(pg, log, opts...)}
+}
+
+// ImportTenders - Массовое создание тендеров с проверкой всех строк до записи
+func (s *ImportAPIService) ImportTenders(ctx context.Context, rows []TenderImportRow, dryRun bool) (ImplResponse, error) {
+	const op = "ImportAPIService.ImportTenders"
+	log := s.log.With(slog.String("op", op))
+
+	violations := validateTenderImportRows(LocaleFromContext(ctx), rows, s.sealer != nil)
+	checked, err := s.checkTenderImportRights(ctx, rows)
+	if err != nil {
+		log.Error("failed to check import rights", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	violations = append(violations, checked...)
+
+	if len(violations) > 0 {
+		apiErr := NewAPIError(ErrCodeValidationFailed, nil).WithViolations(violations...)
+		return Response(apiErr.Status(), nil), apiErr
+	}
+
+	if dryRun {
+		return Response(http.StatusOK, TenderImportResult{DryRun: true, Count: int32(len(rows)), Tenders: []Tender{}}), nil
+	}
+
+	tenders, err := s.insertTenders(ctx, rows)
+	if err != nil {
+		log.Error("failed to insert tenders", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, TenderImportResult{Count: int32(len(tenders)), Tenders: tenders}), nil
+}
+
+// validateTenderImportRows reports the rows that cannot be parsed or break the constraints of
+// CreateTenderRequest, and sealed rows unless sealing is enabled. It does not touch the database.
+func validateTenderImportRows(locale Locale, rows []TenderImportRow, sealing bool) []Violation {
+	var violations []Violation
+	for _, row := range rows {
+		violation := Violation{In: "body", Line: row.Line}
+
+		if row.Err != nil {
+			violation.Message = Translate(locale, MsgImportMalformedRow, row.Err.Error())
+			violations = append(violations, violation)
+			continue
+		}
+
+		var requiredErr *RequiredError
+		var parsingErr *ParsingError
+		if err := AssertCreateTenderRequestRequired(row.Request); errors.As(err, &requiredErr) {
+			violation.Field, violation.Message = requiredErr.Field, Translate(locale, MsgRequired)
+		} else if err := AssertCreateTenderRequestConstraints(row.Request); errors.As(err, &parsingErr) {
+			violation.Field, violation.Message = parsingErr.Param, parsingErr.Err.Error()
+			var locErr *LocalizedError
+			if errors.As(parsingErr.Err, &locErr) {
+				violation.Message = locErr.Localize(locale)
+			}
+		} else if row.Request.Sealed && !sealing {
+			violation.Field, violation.Message = "sealed", Translate(locale, MsgSealingDisabled)
+		} else if deadlinePassed(row.Request.SubmissionDeadline, time.Now()) {
+			violation.Field, violation.Message = "submissionDeadline", Translate(locale, MsgDeadlinePast)
+		} else {
+			continue
+		}
+		violations = append(violations, violation)
+	}
+	return violations
+}
+
+// checkTenderImportRights verifies that the creators of the valid rows exist and are responsible
+// for the organizations. Lookups are cached since an import usually repeats the same pair.
+func (s *ImportAPIService) checkTenderImportRights(ctx context.Context, rows []TenderImportRow) ([]Violation, error) {
+	locale := LocaleFromContext(ctx)
+	users := map[string]bool{}
+	responsible := map[[2]string]bool{}
+
+	var violations []Violation
+	for _, row := range rows {
+		if row.Err != nil || AssertCreateTenderRequestRequired(row.Request) != nil || AssertCreateTenderRequestConstraints(row.Request) != nil {
+			continue
+		}
+		username := row.Request.CreatorUsername
+
+		exists, ok := users[username]
+		if !ok {
+			_, err := s.getUserByName(ctx, username)
+			if err != nil && !errors.Is(err, ErrNoUser) {
+				return nil, err
+			}
+			exists = err == nil
+			users[username] = exists
+		}
+		if !exists {
+			violations = append(violations, Violation{
+				In: "body", Line: row.Line, Field: "creatorUsername",
+				Message: Translate(locale, MsgImportUserNotFound, username),
+			})
+			continue
+		}
+
+		key := [2]string{username, row.Request.OrganizationId}
+		allowed, ok := responsible[key]
+		if !ok {
+			orgId, _ := s.ConvertIntoUUID(row.Request.OrganizationId)
+			err := s.userBelongsToOrganization(ctx, username, orgId)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return nil, err
+			}
+			allowed = err == nil
+			responsible[key] = allowed
+		}
+		if !allowed {
+			violations = append(violations, Violation{
+				In: "body", Line: row.Line, Field: "organizationId",
+				Message: Translate(locale, MsgImportNotResponsible, username),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// Columns copied by insertTenders, in the order of the values of tenderImportRecords.
+var (
+	tenderCopyColumns = []string{"id", "name", "description", "status", "service_type", "organization_id", "version",
+		"creator_username", "created_at", "budget_amount", "budget_currency", "sealed", "submission_deadline", "private", "conflict_policy"}
+	tenderVersionCopyColumns = []string{"tender_id", "name", "description", "service_type", "status", "organization_id",
+		"creator_username", "version", "updated_at", "budget_amount", "budget_currency"}
+)
+
+// insertTenders copies the tenders and their first versions in one transaction.
+func (s *ImportAPIService) insertTenders(ctx context.Context, rows []TenderImportRow) ([]Tender, error) {
+	conn, err := s.pg.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	// COPY sends values in the binary format, so the enum has to be known to the connection.
+	serviceType, err := conn.Conn().LoadType(ctx, "tender_service_type")
+	if err != nil {
+		return nil, err
+	}
+	conn.Conn().TypeMap().RegisterType(serviceType)
+
+	tenders, tenderRows, versionRows := s.tenderImportRecords(rows, time.Now().UTC().Truncate(time.Second))
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"tenders"}, tenderCopyColumns, pgx.CopyFromRows(tenderRows)); err != nil {
+		return nil, err
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"tender_versions"}, tenderVersionCopyColumns, pgx.CopyFromRows(versionRows)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return tenders, nil
+}
+
+// tenderImportRecords assigns ids to the rows and returns the created tenders together with the
+// values copied into tenders and tender_versions.
+func (s *ImportAPIService) tenderImportRecords(rows []TenderImportRow, createdAt time.Time) ([]Tender, [][]any, [][]any) {
+	tenders := make([]Tender, len(rows))
+	tenderRows := make([][]any, len(rows))
+	versionRows := make([][]any, len(rows))
+	for i, row := range rows {
+		id := uuid.New()
+		orgId, _ := s.ConvertIntoUUID(row.Request.OrganizationId)
+		request := row.Request
+		deadline := parseOptionalTime(request.SubmissionDeadline)
+		conflictPolicy := request.ConflictPolicy
+		if conflictPolicy == "" {
+			conflictPolicy = CONFLICT_POLICY_OFF
+		}
+
+		tenders[i] = Tender{
+			Id:                 s.ConvertFromUUID(id),
+			Name:               request.Name,
+			Description:        request.Description,
+			ServiceType:        request.ServiceType,
+			Status:             CREATED,
+			OrganizationId:     request.OrganizationId,
+			Sealed:             request.Sealed,
+			SubmissionDeadline: formatOptionalTime(deadline),
+			Private:            request.Private,
+			ConflictPolicy:     conflictPolicy,
+			Version:            1,
+			CreatedAt:          createdAt.Format(time.RFC3339),
+		}
+		budgetAmount, budgetCurrency := moneyValues(request.Budget)
+		tenderRows[i] = []any{
+			id, request.Name, request.Description, string(CREATED), string(request.ServiceType), orgId, int32(1),
+			request.CreatorUsername, createdAt, budgetAmount, budgetCurrency, request.Sealed, deadline,
+			request.Private, string(conflictPolicy),
+		}
+		versionRows[i] = []any{
+			id, request.Name, request.Description, string(request.ServiceType), string(CREATED), orgId,
+			request.CreatorUsername, int32(1), createdAt, budgetAmount, budgetCurrency,
+		}
+	}
+	return tenders, tenderRows, versionRows
+}
@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// InvitationAPIController binds invitation requests to the invitation service and writes the service results to the http response
+type InvitationAPIController struct {
+	service      InvitationAPIServicer
+	errorHandler ErrorHandler
+}
+
+// InvitationAPIOption for how the controller is set up.
+type InvitationAPIOption func(*InvitationAPIController)
+
+// WithInvitationAPIErrorHandler inject ErrorHandler into controller
+func WithInvitationAPIErrorHandler(h ErrorHandler) InvitationAPIOption {
+	return func(c *InvitationAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewInvitationAPIController creates an invitation api controller
+func NewInvitationAPIController(s InvitationAPIServicer, opts ...InvitationAPIOption) *InvitationAPIController {
+	controller := &InvitationAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the InvitationAPIController
+func (c *InvitationAPIController) Routes() Routes {
+	return Routes{
+		"ListTenderInvitations": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/invitations",
+			c.ListTenderInvitations,
+		},
+		"CreateTenderInvitation": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/{tenderId}/invitations",
+			c.CreateTenderInvitation,
+		},
+		"GetTenderInvitationHistory": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/invitations/history",
+			c.GetTenderInvitationHistory,
+		},
+		"RevokeTenderInvitation": Route{
+			strings.ToUpper("Put"),
+			"/api/tenders/{tenderId}/invitations/{invitationId}/revoke",
+			c.RevokeTenderInvitation,
+		},
+		"GetUserInvitations": Route{
+			strings.ToUpper("Get"),
+			"/api/invitations/my",
+			c.GetUserInvitations,
+		},
+		"AcceptInvitation": Route{
+			strings.ToUpper("Put"),
+			"/api/invitations/{invitationId}/accept",
+			c.AcceptInvitation,
+		},
+		"DeclineInvitation": Route{
+			strings.ToUpper("Put"),
+			"/api/invitations/{invitationId}/decline",
+			c.DeclineInvitation,
+		},
+	}
+}
+
+// ListTenderInvitations - Приглашения к тендеру
+func (c *InvitationAPIController) ListTenderInvitations(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId")
+	if !ok {
+		return
+	}
+	result, err := c.service.ListTenderInvitations(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// CreateTenderInvitation - Приглашение к тендеру
+func (c *InvitationAPIController) CreateTenderInvitation(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId")
+	if !ok {
+		return
+	}
+	createInvitationRequestParam := CreateInvitationRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&createInvitationRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertCreateInvitationRequestRequired(createInvitationRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertCreateInvitationRequestConstraints(createInvitationRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.CreateTenderInvitation(r.Context(), tenderIdParam, usernameParam, createInvitationRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// GetTenderInvitationHistory - История списка приглашений
+func (c *InvitationAPIController) GetTenderInvitationHistory(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId")
+	if !ok {
+		return
+	}
+	result, err := c.service.GetTenderInvitationHistory(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// RevokeTenderInvitation - Отзыв приглашения
+func (c *InvitationAPIController) RevokeTenderInvitation(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId")
+	if !ok {
+		return
+	}
+	invitationIdParam := mux.Vars(r)["invitationId"]
+	if invitationIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"invitationId"}, nil)
+		return
+	}
+	result, err := c.service.RevokeTenderInvitation(r.Context(), tenderIdParam, invitationIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// GetUserInvitations - Приглашения пользователя
+func (c *InvitationAPIController) GetUserInvitations(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return
+	}
+	result, err := c.service.GetUserInvitations(r.Context(), query.Get("username"))
+	c.writeResult(w, r, result, err)
+}
+
+// AcceptInvitation - Принятие приглашения
+func (c *InvitationAPIController) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationIdParam, usernameParam, ok := c.entityParams(w, r, "invitationId")
+	if !ok {
+		return
+	}
+	result, err := c.service.AcceptInvitation(r.Context(), invitationIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// DeclineInvitation - Отказ от приглашения
+func (c *InvitationAPIController) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationIdParam, usernameParam, ok := c.entityParams(w, r, "invitationId")
+	if !ok {
+		return
+	}
+	result, err := c.service.DeclineInvitation(r.Context(), invitationIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// entityParams reads the id named idParam from the path and the required username from the query.
+func (c *InvitationAPIController) entityParams(w http.ResponseWriter, r *http.Request, idParam string) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	id := mux.Vars(r)[idParam]
+	if id == "" {
+		c.errorHandler(w, r, &RequiredError{idParam}, nil)
+		return "", "", false
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return id, query.Get("username"), true
+}
+
+func (c *InvitationAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,336 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// InvitationAPIService manages the invitation lists of private tenders. The responsibles of the
+// tender invite organizations or single employees and may revoke an invitation; the invitees
+// accept or decline it. Every change is appended to the history of the list.
+type InvitationAPIService struct {
+	*DefaultAPIService
+}
+
+// NewInvitationAPIService creates an invitation api service
+func NewInvitationAPIService(pg *Postgres, log *slog.Logger) *InvitationAPIService {
+	return &InvitationAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// ListTenderInvitations - Приглашения к тендеру
+func (s *InvitationAPIService) ListTenderInvitations(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+invitationColumns+` FROM tender_invitations i
+	WHERE i.tender_id = $1
+	ORDER BY i.created_at, i.id`, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	invitations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Invitation, error) {
+		return scanInvitation(row)
+	})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if invitations == nil {
+		invitations = []Invitation{}
+	}
+	return Response(http.StatusOK, invitations), nil
+}
+
+// CreateTenderInvitation - Приглашение организации или сотрудника к тендеру
+func (s *InvitationAPIService) CreateTenderInvitation(ctx context.Context, tenderId string, username string, req CreateInvitationRequest) (ImplResponse, error) {
+	const op = "InvitationAPIService.CreateTenderInvitation"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if !tender.Private {
+		return errorDetailResult(ErrCodeValidationFailed, MsgInvitationPublicTender)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	var organizationId, userId *uuid.UUID
+	if req.OrganizationId != "" {
+		orgIdUUID, _ := s.ConvertIntoUUID(req.OrganizationId)
+		if _, err := s.getOrganizationById(ctx, orgIdUUID); err != nil {
+			if errors.Is(err, ErrNoOrganization) {
+				return errorResult(ErrCodeOrganizationNotFound, err)
+			}
+			return errorResult(ErrCodeInternal, err)
+		}
+		if orgIdUUID.String() == tender.OrganizationId {
+			return errorDetailResult(ErrCodeValidationFailed, MsgInvitationOwnOrg)
+		}
+		organizationId = &orgIdUUID
+	} else {
+		invitee, err := s.loadUser(ctx, req.Username)
+		if err != nil {
+			return apiErrorResult(err)
+		}
+		userId = &invitee.Id
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	status, err := lockTender(ctx, tx, tenderIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+	if status == CLOSED {
+		return errorDetailResult(ErrCodeTenderClosed, MsgTenderClosed)
+	}
+
+	invitation, err := scanInvitation(tx.QueryRow(ctx, `
+	INSERT INTO tender_invitations AS i (id, tender_id, organization_id, user_id, status, invited_by)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT DO NOTHING
+	RETURNING `+invitationColumns, uuid.New(), tenderIdUUID, organizationId, userId, string(INVITATION_PENDING), user.Id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errorDetailResult(ErrCodeAlreadyExists, MsgInvitationExists)
+	}
+	if err != nil {
+		log.Error("failed to save the invitation", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	invitationIdUUID, _ := s.ConvertIntoUUID(invitation.Id)
+	if err := recordInvitationChange(ctx, tx, tenderIdUUID, invitationIdUUID, INVITATION_PENDING, user.Id); err != nil {
+		log.Error("failed to record the change", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusCreated, invitation), nil
+}
+
+// RevokeTenderInvitation - Отзыв приглашения ответственным
+func (s *InvitationAPIService) RevokeTenderInvitation(ctx context.Context, tenderId string, invitationId string, username string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	invitationIdUUID, err := s.ConvertIntoUUID(invitationId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	invitation, err := s.changeInvitation(ctx, invitationIdUUID, INVITATION_REVOKED, user.Id, func(stored storedInvitation) *APIError {
+		if stored.TenderId != tenderIdUUID {
+			return NewAPIError(ErrCodeInvitationNotFound, ErrNotFound)
+		}
+		return nil
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return Response(http.StatusOK, invitation), nil
+}
+
+// GetTenderInvitationHistory - История списка приглашений тендера
+func (s *InvitationAPIService) GetTenderInvitationHistory(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT c.version, c.invitation_id, i.organization_id,
+		COALESCE((SELECT username FROM employee WHERE id = i.user_id), ''), c.status,
+		COALESCE((SELECT username FROM employee WHERE id = c.changed_by), ''), c.changed_at
+	FROM tender_invitation_changes c JOIN tender_invitations i ON i.id = c.invitation_id
+	WHERE c.tender_id = $1
+	ORDER BY c.version`, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	changes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (InvitationChange, error) {
+		var change InvitationChange
+		var invitationId uuid.UUID
+		var organizationId *uuid.UUID
+		var changedAt time.Time
+		err := row.Scan(&change.Version, &invitationId, &organizationId, &change.Username, &change.Status, &change.ChangedBy, &changedAt)
+		change.InvitationId = invitationId.String()
+		if organizationId != nil {
+			change.OrganizationId = organizationId.String()
+		}
+		change.ChangedAt = changedAt.UTC().Format(time.RFC3339)
+		return change, err
+	})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if changes == nil {
+		changes = []InvitationChange{}
+	}
+	return Response(http.StatusOK, changes), nil
+}
+
+// GetUserInvitations - Приглашения пользователя и его организаций
+func (s *InvitationAPIService) GetUserInvitations(ctx context.Context, username string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+invitationColumns+` FROM tender_invitations i
+	WHERE i.user_id = $1 OR i.organization_id IN (
+		SELECT organization_id FROM organization_responsible WHERE user_id = $1)
+	ORDER BY i.created_at DESC, i.id`, user.Id)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	invitations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Invitation, error) {
+		return scanInvitation(row)
+	})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if invitations == nil {
+		invitations = []Invitation{}
+	}
+	return Response(http.StatusOK, invitations), nil
+}
+
+// AcceptInvitation - Принятие приглашения к тендеру
+func (s *InvitationAPIService) AcceptInvitation(ctx context.Context, invitationId string, username string) (ImplResponse, error) {
+	return s.respond(ctx, invitationId, username, INVITATION_ACCEPTED)
+}
+
+// DeclineInvitation - Отказ от приглашения к тендеру
+func (s *InvitationAPIService) DeclineInvitation(ctx context.Context, invitationId string, username string) (ImplResponse, error) {
+	return s.respond(ctx, invitationId, username, INVITATION_DECLINED)
+}
+
+// respond records the answer of the invitee: the invited employee or a responsible of the
+// invited organization.
+func (s *InvitationAPIService) respond(ctx context.Context, invitationId string, username string, status InvitationStatus) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	invitationIdUUID, err := s.ConvertIntoUUID(invitationId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+
+	invitation, err := s.changeInvitation(ctx, invitationIdUUID, status, user.Id, func(stored storedInvitation) *APIError {
+		switch {
+		case stored.UserId != nil:
+			if *stored.UserId != user.Id {
+				return NewAPIError(ErrCodeForbiddenNotInvited, nil).WithDetail(MsgInvitationNotAddressee)
+			}
+		case stored.OrganizationId != nil:
+			if err := s.userBelongsToOrganization(ctx, user.Username, *stored.OrganizationId); err != nil {
+				if errors.Is(err, ErrNotFound) {
+					return NewAPIError(ErrCodeForbiddenNotInvited, nil).WithDetail(MsgInvitationNotAddressee)
+				}
+				return NewAPIError(ErrCodeInternal, err)
+			}
+		}
+		if status == INVITATION_ACCEPTED && stored.TenderStatus == CLOSED {
+			return NewAPIError(ErrCodeTenderClosed, nil).WithDetail(MsgTenderClosed)
+		}
+		return nil
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	return Response(http.StatusOK, invitation), nil
+}
+
+// storedInvitation is what changeInvitation reads before a change, under the tender lock.
+type storedInvitation struct {
+	TenderId       uuid.UUID
+	TenderStatus   TenderStatus
+	OrganizationId *uuid.UUID
+	UserId         *uuid.UUID
+	Status         InvitationStatus
+}
+
+// changeInvitation moves the invitation to status and records the change, if check allows it.
+func (s *InvitationAPIService) changeInvitation(ctx context.Context, invitationId uuid.UUID, status InvitationStatus, changedBy uuid.UUID, check func(storedInvitation) *APIError) (*Invitation, error) {
+	log := s.log.With(slog.String("op", "InvitationAPIService.changeInvitation"))
+
+	var stored storedInvitation
+	err := s.pg.Pool.QueryRow(ctx, `SELECT tender_id FROM tender_invitations WHERE id = $1`, invitationId).Scan(&stored.TenderId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, NewAPIError(ErrCodeInvitationNotFound, ErrNotFound)
+	}
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The tender lock orders the changes of its invitation list.
+	if stored.TenderStatus, err = lockTender(ctx, tx, stored.TenderId); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, NewAPIError(ErrCodeTenderNotFound, err)
+		}
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	if err := tx.QueryRow(ctx, `
+	SELECT organization_id, user_id, status FROM tender_invitations WHERE id = $1`, invitationId).
+		Scan(&stored.OrganizationId, &stored.UserId, &stored.Status); err != nil {
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	if apiErr := check(stored); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := checkInvitationTransition(stored.Status, status); apiErr != nil {
+		return nil, apiErr
+	}
+
+	invitation, err := scanInvitation(tx.QueryRow(ctx, `
+	UPDATE tender_invitations i SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE i.id = $1
+	RETURNING `+invitationColumns, invitationId, string(status)))
+	if err != nil {
+		log.Error("failed to update the invitation", slog.Any("error", err))
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	if err := recordInvitationChange(ctx, tx, stored.TenderId, invitationId, status, changedBy); err != nil {
+		log.Error("failed to record the change", slog.Any("error", err))
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return &invitation, nil
+}
@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// LotAPIController binds lot requests to the lot service and writes the service results to the http response
+type LotAPIController struct {
+	service      LotAPIServicer
+	errorHandler ErrorHandler
+}
+
+// LotAPIOption for how the controller is set up.
+type LotAPIOption func(*LotAPIController)
+
+// WithLotAPIErrorHandler inject ErrorHandler into controller
+func WithLotAPIErrorHandler(h ErrorHandler) LotAPIOption {
+	return func(c *LotAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewLotAPIController creates a lot api controller
+func NewLotAPIController(s LotAPIServicer, opts ...LotAPIOption) *LotAPIController {
+	controller := &LotAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the LotAPIController
+func (c *LotAPIController) Routes() Routes {
+	return Routes{
+		"ListTenderLots": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/lots",
+			c.ListTenderLots,
+		},
+		"CreateTenderLot": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/{tenderId}/lots",
+			c.CreateTenderLot,
+		},
+		"EditTenderLot": Route{
+			strings.ToUpper("Patch"),
+			"/api/tenders/{tenderId}/lots/{lotId}/edit",
+			c.EditTenderLot,
+		},
+		"CancelTenderLot": Route{
+			strings.ToUpper("Put"),
+			"/api/tenders/{tenderId}/lots/{lotId}/cancel",
+			c.CancelTenderLot,
+		},
+	}
+}
+
+// ListTenderLots - Лоты тендера
+func (c *LotAPIController) ListTenderLots(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, false)
+	if !ok {
+		return
+	}
+	result, err := c.service.ListTenderLots(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// CreateTenderLot - Добавление лота к тендеру
+func (c *LotAPIController) CreateTenderLot(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	createLotRequestParam := CreateLotRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&createLotRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertCreateLotRequestRequired(createLotRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertCreateLotRequestConstraints(createLotRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.CreateTenderLot(r.Context(), tenderIdParam, usernameParam, createLotRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// EditTenderLot - Редактирование лота
+func (c *LotAPIController) EditTenderLot(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	lotIdParam, ok := c.lotParam(w, r)
+	if !ok {
+		return
+	}
+	editLotRequestParam := EditLotRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&editLotRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertEditLotRequestRequired(editLotRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertEditLotRequestConstraints(editLotRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.EditTenderLot(r.Context(), tenderIdParam, lotIdParam, usernameParam, editLotRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// CancelTenderLot - Отмена лота
+func (c *LotAPIController) CancelTenderLot(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	lotIdParam, ok := c.lotParam(w, r)
+	if !ok {
+		return
+	}
+	result, err := c.service.CancelTenderLot(r.Context(), tenderIdParam, lotIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// tenderParams reads the tender id from the path and the username from the query.
+func (c *LotAPIController) tenderParams(w http.ResponseWriter, r *http.Request, usernameRequired bool) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	tenderId := mux.Vars(r)["tenderId"]
+	if tenderId == "" {
+		c.errorHandler(w, r, &RequiredError{"tenderId"}, nil)
+		return "", "", false
+	}
+	if usernameRequired && !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return tenderId, query.Get("username"), true
+}
+
+func (c *LotAPIController) lotParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	lotId := mux.Vars(r)["lotId"]
+	if lotId == "" {
+		c.errorHandler(w, r, &RequiredError{"lotId"}, nil)
+		return "", false
+	}
+	return lotId, true
+}
+
+func (c *LotAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
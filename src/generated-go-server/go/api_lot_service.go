@@ -0,0 +1,233 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// LotAPIService manages the lots of tenders. Lots are added while the tender is still being
+// prepared; afterwards they can only be edited while open or cancelled. Awarding a lot is
+// done by approving a bid on it, see SubmitBidDecision.
+type LotAPIService struct {
+	*DefaultAPIService
+}
+
+// NewLotAPIService creates a lot api service
+func NewLotAPIService(pg *Postgres, log *slog.Logger) *LotAPIService {
+	return &LotAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// ListTenderLots - Лоты тендера
+func (s *LotAPIService) ListTenderLots(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	lots, err := s.getTenderLots(ctx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, lots), nil
+}
+
+// CreateTenderLot - Добавление лота к тендеру до его публикации
+func (s *LotAPIService) CreateTenderLot(ctx context.Context, tenderId string, username string, req CreateLotRequest) (ImplResponse, error) {
+	const op = "LotAPIService.CreateTenderLot"
+	log := s.log.With(slog.String("op", op))
+
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if tender.Status != CREATED {
+		return errorDetailResult(ErrCodeInvalidStatus, MsgLotsFrozen)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	var auctioned bool
+	if err := s.pg.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auctions WHERE tender_id = $1)`, tenderIdUUID).Scan(&auctioned); err != nil {
+		log.Error("failed to check for an auction", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if auctioned {
+		return errorDetailResult(ErrCodeValidationFailed, MsgAuctionLots)
+	}
+
+	lots, err := s.getTenderLots(ctx, tenderIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if apiErr := checkLotBudget(req.Budget, tender, lots); apiErr != nil {
+		return apiErrorResult(apiErr)
+	}
+
+	budgetAmount, budgetCurrency := moneyValues(req.Budget)
+	sql, args, err := s.builder.
+		Insert("lots").
+		Columns("id", "tender_id", "name", "description", "budget_amount", "budget_currency", "status").
+		Values(uuid.New(), tenderIdUUID, req.Name, req.Description, budgetAmount, budgetCurrency, string(LOT_OPEN)).
+		Suffix("RETURNING " + strings.Join(lotColumns, ", ")).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	lot, err := scanLot(s.pg.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		log.Error("failed to save the lot", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusCreated, lot), nil
+}
+
+// EditTenderLot - Изменение открытого лота
+func (s *LotAPIService) EditTenderLot(ctx context.Context, tenderId string, lotId string, username string, req EditLotRequest) (ImplResponse, error) {
+	const op = "LotAPIService.EditTenderLot"
+	log := s.log.With(slog.String("op", op))
+
+	tender, lot, err := s.lotForWrite(ctx, tenderId, lotId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if lot.Status != LOT_OPEN {
+		return errorDetailResult(ErrCodeLotNotOpen, MsgLotNotOpen, lot.Id)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+	lotIdUUID, _ := s.ConvertIntoUUID(lot.Id)
+
+	if req.Budget != nil {
+		lots, err := s.getTenderLots(ctx, tenderIdUUID)
+		if err != nil {
+			return errorResult(ErrCodeInternal, err)
+		}
+		others := slices.DeleteFunc(lots, func(l Lot) bool { return l.Id == lot.Id })
+		if apiErr := checkLotBudget(req.Budget, tender, others); apiErr != nil {
+			return apiErrorResult(apiErr)
+		}
+	}
+
+	update := s.builder.
+		Update("lots").
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"id": lotIdUUID, "status": string(LOT_OPEN)})
+	if req.Name != "" {
+		update = update.Set("name", req.Name)
+	}
+	if req.Description != "" {
+		update = update.Set("description", req.Description)
+	}
+	if req.Budget != nil {
+		budgetAmount, budgetCurrency := moneyValues(req.Budget)
+		update = update.Set("budget_amount", budgetAmount).Set("budget_currency", budgetCurrency)
+	}
+
+	sql, args, err := update.Suffix("RETURNING " + strings.Join(lotColumns, ", ")).ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	edited, err := scanLot(s.pg.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The lot was awarded or cancelled after it was read.
+			return errorDetailResult(ErrCodeLotNotOpen, MsgLotNotOpen, lot.Id)
+		}
+		log.Error("failed to update the lot", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, edited), nil
+}
+
+// CancelTenderLot - Отмена открытого лота. Тендер закрывается, когда не остается открытых лотов
+func (s *LotAPIService) CancelTenderLot(ctx context.Context, tenderId string, lotId string, username string) (ImplResponse, error) {
+	const op = "LotAPIService.CancelTenderLot"
+	log := s.log.With(slog.String("op", op))
+
+	tender, lot, err := s.lotForWrite(ctx, tenderId, lotId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+	lotIdUUID, _ := s.ConvertIntoUUID(lot.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockTender(ctx, tx, tenderIdUUID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return errorResult(ErrCodeTenderNotFound, err)
+		}
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	sql, args, err := s.builder.
+		Update("lots").
+		Set("status", string(LOT_CANCELLED)).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"id": lotIdUUID, "status": string(LOT_OPEN)}).
+		Suffix("RETURNING " + strings.Join(lotColumns, ", ")).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	cancelled, err := scanLot(tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errorDetailResult(ErrCodeLotNotOpen, MsgLotNotOpen, lot.Id)
+		}
+		log.Error("failed to cancel the lot", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	closed, err := closeTenderIfSettled(ctx, tx, tenderIdUUID)
+	if err != nil {
+		log.Error("failed to close the tender", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if closed {
+		log.Info("tender closed", slog.String("tender_id", tender.Id))
+	}
+	return Response(http.StatusOK, cancelled), nil
+}
+
+// lotForWrite loads the tender and its lot if the user is responsible for the tender.
+func (s *LotAPIService) lotForWrite(ctx context.Context, tenderId string, lotId string, username string) (*Tender, *Lot, error) {
+	tender, err := s.tenderForWrite(ctx, tenderId, username)
+	if err != nil {
+		return nil, nil, err
+	}
+	lotIdUUID, err := s.ConvertIntoUUID(lotId)
+	if err != nil {
+		return nil, nil, NewAPIError(ErrCodeInvalidID, err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	lot, err := s.getLot(ctx, tenderIdUUID, lotIdUUID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil, NewAPIError(ErrCodeLotNotFound, err)
+		}
+		return nil, nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return tender, lot, nil
+}
@@ -0,0 +1,258 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// NotificationAPIController binds notification requests to the notification service and writes the service results to the http response
+type NotificationAPIController struct {
+	service      NotificationAPIServicer
+	errorHandler ErrorHandler
+}
+
+// NotificationAPIOption for how the controller is set up.
+type NotificationAPIOption func(*NotificationAPIController)
+
+// WithNotificationAPIErrorHandler inject ErrorHandler into controller
+func WithNotificationAPIErrorHandler(h ErrorHandler) NotificationAPIOption {
+	return func(c *NotificationAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewNotificationAPIController creates a notification api controller
+func NewNotificationAPIController(s NotificationAPIServicer, opts ...NotificationAPIOption) *NotificationAPIController {
+	controller := &NotificationAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the NotificationAPIController
+func (c *NotificationAPIController) Routes() Routes {
+	return Routes{
+		"GetNotifications": Route{
+			strings.ToUpper("Get"),
+			"/api/notifications",
+			c.GetNotifications,
+		},
+		"MarkAllNotificationsRead": Route{
+			strings.ToUpper("Put"),
+			"/api/notifications/read_all",
+			c.MarkAllNotificationsRead,
+		},
+		"GetNotificationPreferences": Route{
+			strings.ToUpper("Get"),
+			"/api/notifications/preferences",
+			c.GetNotificationPreferences,
+		},
+		"UpdateNotificationPreferences": Route{
+			strings.ToUpper("Put"),
+			"/api/notifications/preferences",
+			c.UpdateNotificationPreferences,
+		},
+		"GetEmailSettings": Route{
+			strings.ToUpper("Get"),
+			"/api/notifications/email",
+			c.GetEmailSettings,
+		},
+		"UpdateEmailSettings": Route{
+			strings.ToUpper("Put"),
+			"/api/notifications/email",
+			c.UpdateEmailSettings,
+		},
+		"MarkNotificationRead": Route{
+			strings.ToUpper("Put"),
+			"/api/notifications/{notificationId}/read",
+			c.MarkNotificationRead,
+		},
+	}
+}
+
+// GetNotifications - Входящие уведомления
+func (c *NotificationAPIController) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return
+	}
+	var limitParam int32 = 5
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](0),
+			WithMaximum[int32](50),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "limit", Err: err}, nil)
+			return
+		}
+		limitParam = param
+	}
+	var offsetParam int32
+	if query.Has("offset") {
+		param, err := parseNumericParameter[int32](
+			query.Get("offset"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](0),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "offset", Err: err}, nil)
+			return
+		}
+		offsetParam = param
+	}
+	var unreadOnlyParam bool
+	if query.Has("unreadOnly") {
+		param, err := parseBoolParameter(
+			query.Get("unreadOnly"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "unreadOnly", Err: err}, nil)
+			return
+		}
+		unreadOnlyParam = param
+	}
+	result, err := c.service.GetNotifications(r.Context(), query.Get("username"), limitParam, offsetParam, unreadOnlyParam)
+	c.writeResult(w, r, result, err)
+}
+
+// MarkNotificationRead - Отметка уведомления прочитанным
+func (c *NotificationAPIController) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	notificationIdParam := mux.Vars(r)["notificationId"]
+	if notificationIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"notificationId"}, nil)
+		return
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return
+	}
+	result, err := c.service.MarkNotificationRead(r.Context(), notificationIdParam, query.Get("username"))
+	c.writeResult(w, r, result, err)
+}
+
+// MarkAllNotificationsRead - Отметка всех уведомлений прочитанными
+func (c *NotificationAPIController) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	usernameParam, ok := c.usernameParam(w, r)
+	if !ok {
+		return
+	}
+	result, err := c.service.MarkAllNotificationsRead(r.Context(), usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// GetNotificationPreferences - Настройки уведомлений
+func (c *NotificationAPIController) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	usernameParam, ok := c.usernameParam(w, r)
+	if !ok {
+		return
+	}
+	result, err := c.service.GetNotificationPreferences(r.Context(), usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// UpdateNotificationPreferences - Изменение настроек уведомлений
+func (c *NotificationAPIController) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	usernameParam, ok := c.usernameParam(w, r)
+	if !ok {
+		return
+	}
+	updateNotificationPreferencesRequestParam := UpdateNotificationPreferencesRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&updateNotificationPreferencesRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertUpdateNotificationPreferencesRequestRequired(updateNotificationPreferencesRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertUpdateNotificationPreferencesRequestConstraints(updateNotificationPreferencesRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.UpdateNotificationPreferences(r.Context(), usernameParam, updateNotificationPreferencesRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// GetEmailSettings - Настройки писем с уведомлениями
+func (c *NotificationAPIController) GetEmailSettings(w http.ResponseWriter, r *http.Request) {
+	usernameParam, ok := c.usernameParam(w, r)
+	if !ok {
+		return
+	}
+	result, err := c.service.GetEmailSettings(r.Context(), usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// UpdateEmailSettings - Изменение настроек писем с уведомлениями
+func (c *NotificationAPIController) UpdateEmailSettings(w http.ResponseWriter, r *http.Request) {
+	usernameParam, ok := c.usernameParam(w, r)
+	if !ok {
+		return
+	}
+	updateEmailSettingsRequestParam := UpdateEmailSettingsRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&updateEmailSettingsRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertUpdateEmailSettingsRequestRequired(updateEmailSettingsRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertUpdateEmailSettingsRequestConstraints(updateEmailSettingsRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.UpdateEmailSettings(r.Context(), usernameParam, updateEmailSettingsRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// usernameParam reads the required username from the query.
+func (c *NotificationAPIController) usernameParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", false
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", false
+	}
+	return query.Get("username"), true
+}
+
+func (c *NotificationAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NotificationAPIService serves the inbox of an employee. The notifications themselves are
+// written by the NotificationDispatcher from the outbox; the service lists them, tracks
+// which are read and keeps the per-type preferences the dispatcher honours.
+type NotificationAPIService struct {
+	*DefaultAPIService
+}
+
+// NewNotificationAPIService creates a notification api service
+func NewNotificationAPIService(pg *Postgres, log *slog.Logger) *NotificationAPIService {
+	return &NotificationAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// GetNotifications - Входящие уведомления пользователя, новые первыми
+func (s *NotificationAPIService) GetNotifications(ctx context.Context, username string, limit int32, offset int32, unreadOnly bool) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	inbox := NotificationInbox{}
+	err = s.pg.Pool.QueryRow(ctx, `
+	SELECT COUNT(*), COUNT(*) FILTER (WHERE read_at IS NULL) FROM notifications WHERE employee_id = $1`,
+		user.Id).Scan(&inbox.Total, &inbox.Unread)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if unreadOnly {
+		inbox.Total = inbox.Unread
+	}
+
+	locale := LocaleFromContext(ctx)
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+notificationColumns+` FROM notifications n
+	WHERE n.employee_id = $1 AND (NOT $2 OR n.read_at IS NULL)
+	ORDER BY n.created_at DESC, n.id DESC
+	LIMIT $3 OFFSET $4`, user.Id, unreadOnly, limit, offset)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	inbox.Notifications, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (Notification, error) {
+		return scanNotification(row, locale)
+	})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if inbox.Notifications == nil {
+		inbox.Notifications = []Notification{}
+	}
+	return Response(http.StatusOK, inbox), nil
+}
+
+// MarkNotificationRead - Отметка уведомления прочитанным
+func (s *NotificationAPIService) MarkNotificationRead(ctx context.Context, notificationId string, username string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	notificationIdUUID, err := s.ConvertIntoUUID(notificationId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+
+	// Someone else's notification is reported as missing, not forbidden.
+	notification, err := scanNotification(s.pg.Pool.QueryRow(ctx, `
+	UPDATE notifications n SET read_at = COALESCE(n.read_at, CURRENT_TIMESTAMP)
+	WHERE n.id = $1 AND n.employee_id = $2
+	RETURNING `+notificationColumns, notificationIdUUID, user.Id), LocaleFromContext(ctx))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errorResult(ErrCodeNotificationNotFound, ErrNotFound)
+	}
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, notification), nil
+}
+
+// MarkAllNotificationsRead - Отметка всех уведомлений прочитанными
+func (s *NotificationAPIService) MarkAllNotificationsRead(ctx context.Context, username string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	tag, err := s.pg.Pool.Exec(ctx, `
+	UPDATE notifications SET read_at = CURRENT_TIMESTAMP WHERE employee_id = $1 AND read_at IS NULL`, user.Id)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, NotificationsMarked{Marked: int32(tag.RowsAffected())}), nil
+}
+
+// GetNotificationPreferences - Настройки уведомлений пользователя по всем типам событий
+func (s *NotificationAPIService) GetNotificationPreferences(ctx context.Context, username string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	preferences, err := s.notificationPreferences(ctx, user)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, preferences), nil
+}
+
+// UpdateNotificationPreferences - Включение и отключение уведомлений по типам событий
+func (s *NotificationAPIService) UpdateNotificationPreferences(ctx context.Context, username string, req UpdateNotificationPreferencesRequest) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, preference := range req.Preferences {
+		if _, err := tx.Exec(ctx, `
+		INSERT INTO notification_preferences (employee_id, event_type, enabled) VALUES ($1, $2, $3)
+		ON CONFLICT (employee_id, event_type) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = CURRENT_TIMESTAMP`,
+			user.Id, string(preference.EventType), preference.Enabled); err != nil {
+			s.log.Error("failed to save the notification preferences", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	preferences, err := s.notificationPreferences(ctx, user)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, preferences), nil
+}
+
+// notificationPreferences lists every event type with the choice of the user; types the user
+// never changed are enabled.
+func (s *NotificationAPIService) notificationPreferences(ctx context.Context, user *User) ([]NotificationPreference, error) {
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT event_type, enabled FROM notification_preferences WHERE employee_id = $1`, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (NotificationPreference, error) {
+		var preference NotificationPreference
+		err := row.Scan(&preference.EventType, &preference.Enabled)
+		return preference, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[NotificationEventType]bool, len(stored))
+	for _, preference := range stored {
+		enabled[preference.EventType] = preference.Enabled
+	}
+	preferences := make([]NotificationPreference, 0, len(AllowedNotificationEventTypeEnumValues))
+	for _, eventType := range AllowedNotificationEventTypeEnumValues {
+		on, ok := enabled[eventType]
+		preferences = append(preferences, NotificationPreference{EventType: eventType, Enabled: on || !ok})
+	}
+	return preferences, nil
+}
+
+// GetEmailSettings - Настройки писем с уведомлениями
+func (s *NotificationAPIService) GetEmailSettings(ctx context.Context, username string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	settings, err := s.emailSettings(ctx, user)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, settings), nil
+}
+
+// UpdateEmailSettings - Адрес, язык и отказ от писем. Письма, еще не отправленные на момент
+// отказа, отменяются.
+func (s *NotificationAPIService) UpdateEmailSettings(ctx context.Context, username string, req UpdateEmailSettingsRequest) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	enabled := req.Enabled == nil || *req.Enabled
+	locale := LocaleRU
+	if req.Locale != "" {
+		locale = Locale(req.Locale)
+	}
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+	INSERT INTO notification_email_settings (employee_id, email, locale, enabled) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (employee_id) DO UPDATE
+	SET email = EXCLUDED.email, locale = EXCLUDED.locale, enabled = EXCLUDED.enabled, updated_at = CURRENT_TIMESTAMP`,
+		user.Id, req.Email, string(locale), enabled); err != nil {
+		s.log.Error("failed to save the email settings", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	if !enabled {
+		if _, err := tx.Exec(ctx, `
+		UPDATE email_outbox SET failed_at = CURRENT_TIMESTAMP, last_error = $2
+		WHERE employee_id = $1 AND sent_at IS NULL AND failed_at IS NULL`, user.Id, emailOptedOut); err != nil {
+			return errorResult(ErrCodeInternal, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, EmailSettings{Email: req.Email, Enabled: enabled, Locale: string(locale)}), nil
+}
+
+// emailSettings returns the email settings of the user; without an address email is off.
+func (s *NotificationAPIService) emailSettings(ctx context.Context, user *User) (EmailSettings, error) {
+	settings := EmailSettings{Locale: string(LocaleRU)}
+	err := s.pg.Pool.QueryRow(ctx, `
+	SELECT email, enabled, locale FROM notification_email_settings WHERE employee_id = $1`, user.Id).
+		Scan(&settings.Email, &settings.Enabled, &settings.Locale)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return EmailSettings{}, err
+	}
+	return settings, nil
+}
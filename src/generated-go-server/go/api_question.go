@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// QuestionAPIController binds question requests to the question service and writes the service results to the http response
+type QuestionAPIController struct {
+	service      QuestionAPIServicer
+	errorHandler ErrorHandler
+}
+
+// QuestionAPIOption for how the controller is set up.
+type QuestionAPIOption func(*QuestionAPIController)
+
+// WithQuestionAPIErrorHandler inject ErrorHandler into controller
+func WithQuestionAPIErrorHandler(h ErrorHandler) QuestionAPIOption {
+	return func(c *QuestionAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewQuestionAPIController creates a question api controller
+func NewQuestionAPIController(s QuestionAPIServicer, opts ...QuestionAPIOption) *QuestionAPIController {
+	controller := &QuestionAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the QuestionAPIController
+func (c *QuestionAPIController) Routes() Routes {
+	return Routes{
+		"ListTenderQuestions": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/questions",
+			c.ListTenderQuestions,
+		},
+		"AskTenderQuestion": Route{
+			strings.ToUpper("Post"),
+			"/api/tenders/{tenderId}/questions",
+			c.AskTenderQuestion,
+		},
+		"AnswerTenderQuestion": Route{
+			strings.ToUpper("Put"),
+			"/api/tenders/{tenderId}/questions/{questionId}/answer",
+			c.AnswerTenderQuestion,
+		},
+	}
+}
+
+// ListTenderQuestions - Вопросы по тендеру
+func (c *QuestionAPIController) ListTenderQuestions(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, false)
+	if !ok {
+		return
+	}
+	result, err := c.service.ListTenderQuestions(r.Context(), tenderIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// AskTenderQuestion - Вопрос по тендеру
+func (c *QuestionAPIController) AskTenderQuestion(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	askQuestionRequestParam := AskQuestionRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&askQuestionRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertAskQuestionRequestRequired(askQuestionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertAskQuestionRequestConstraints(askQuestionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.AskTenderQuestion(r.Context(), tenderIdParam, usernameParam, askQuestionRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// AnswerTenderQuestion - Ответ на вопрос по тендеру
+func (c *QuestionAPIController) AnswerTenderQuestion(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.tenderParams(w, r, true)
+	if !ok {
+		return
+	}
+	questionIdParam := mux.Vars(r)["questionId"]
+	if questionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"questionId"}, nil)
+		return
+	}
+	answerQuestionRequestParam := AnswerQuestionRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&answerQuestionRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertAnswerQuestionRequestRequired(answerQuestionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertAnswerQuestionRequestConstraints(answerQuestionRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.AnswerTenderQuestion(r.Context(), tenderIdParam, questionIdParam, usernameParam, answerQuestionRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// tenderParams reads the id of the tender from the path and the username from the query.
+func (c *QuestionAPIController) tenderParams(w http.ResponseWriter, r *http.Request, usernameRequired bool) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	tenderId := mux.Vars(r)["tenderId"]
+	if tenderId == "" {
+		c.errorHandler(w, r, &RequiredError{"tenderId"}, nil)
+		return "", "", false
+	}
+	if usernameRequired && !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return tenderId, query.Get("username"), true
+}
+
+func (c *QuestionAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
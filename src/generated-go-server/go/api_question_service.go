@@ -0,0 +1,190 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// QuestionAPIService keeps the clarification questions on published tenders. Any employee may
+// ask; the responsibles of the tender answer, either to the author alone or to everyone. A
+// public answer that changes the terms can bump the tender version, like an edit does.
+type QuestionAPIService struct {
+	*DefaultAPIService
+}
+
+// NewQuestionAPIService creates a question api service
+func NewQuestionAPIService(pg *Postgres, log *slog.Logger) *QuestionAPIService {
+	return &QuestionAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// ListTenderQuestions - Вопросы по тендеру, видимые пользователю
+func (s *QuestionAPIService) ListTenderQuestions(ctx context.Context, tenderId string, username string) (ImplResponse, error) {
+	const op = "QuestionAPIService.ListTenderQuestions"
+	log := s.log.With(slog.String("op", op))
+
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	var userId *uuid.UUID
+	var responsible bool
+	if username != "" {
+		user, err := s.loadUser(ctx, username)
+		if err != nil {
+			return apiErrorResult(err)
+		}
+		userId = &user.Id
+		err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
+		if err1 != nil && !errors.Is(err1, ErrUserNoRightsTender) {
+			return errorResult(ErrCodeInternal, err2)
+		}
+		responsible = err1 == nil
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+questionColumns+` FROM tender_questions q
+	WHERE q.tender_id = $1
+	ORDER BY q.created_at, q.id`, tenderIdUUID)
+	if err != nil {
+		log.Error("failed to list the questions", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	stored, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (questionRow, error) {
+		return scanQuestion(row)
+	})
+	if err != nil {
+		log.Error("failed to read the questions", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	questions := []TenderQuestion{}
+	for _, q := range stored {
+		if q.visibleTo(userId, responsible) {
+			questions = append(questions, q.toQuestion(userId, responsible))
+		}
+	}
+	return Response(http.StatusOK, questions), nil
+}
+
+// AskTenderQuestion - Вопрос по опубликованному тендеру
+func (s *QuestionAPIService) AskTenderQuestion(ctx context.Context, tenderId string, username string, req AskQuestionRequest) (ImplResponse, error) {
+	const op = "QuestionAPIService.AskTenderQuestion"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tender, err := s.tenderForRead(ctx, tenderId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if tender.Status != PUBLISHED {
+		return errorDetailResult(ErrCodeInvalidStatus, MsgQuestionNotPublished)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	question, err := scanQuestion(s.pg.Pool.QueryRow(ctx, `
+	INSERT INTO tender_questions AS q (id, tender_id, author_id, question) VALUES ($1, $2, $3, $4)
+	RETURNING `+questionColumns, uuid.New(), tenderIdUUID, user.Id, req.Question))
+	if err != nil {
+		log.Error("failed to save the question", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusCreated, question.toQuestion(&user.Id, false)), nil
+}
+
+// AnswerTenderQuestion - Ответ ответственного на вопрос по тендеру
+func (s *QuestionAPIService) AnswerTenderQuestion(ctx context.Context, tenderId string, questionId string, username string, req AnswerQuestionRequest) (ImplResponse, error) {
+	const op = "QuestionAPIService.AnswerTenderQuestion"
+	log := s.log.With(slog.String("op", op))
+
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tender, err := s.loadTender(ctx, tenderId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if err := s.checkTenderRights(ctx, user, tender); err != nil {
+		return apiErrorResult(err)
+	}
+	questionIdUUID, err := s.ConvertIntoUUID(questionId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Only the first answer is recorded: a second one would silently replace what the
+	// bidders have already read.
+	question, err := scanQuestion(tx.QueryRow(ctx, `
+	UPDATE tender_questions q SET answer = $3, visibility = $4, answered_by = $5, answered_at = CURRENT_TIMESTAMP
+	WHERE q.id = $1 AND q.tender_id = $2 AND q.answer IS NULL
+	RETURNING `+questionColumns, questionIdUUID, tenderIdUUID, req.Answer, string(req.Visibility), user.Id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM tender_questions WHERE id = $1 AND tender_id = $2)`,
+			questionIdUUID, tenderIdUUID).Scan(&exists); err != nil {
+			return errorResult(ErrCodeInternal, err)
+		}
+		if !exists {
+			return errorResult(ErrCodeQuestionNotFound, ErrNotFound)
+		}
+		return errorDetailResult(ErrCodeAlreadyExists, MsgQuestionAnswered)
+	}
+	if err != nil {
+		log.Error("failed to save the answer", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	if req.BumpVersion {
+		// The current version is kept in tender_versions before the bump, as EditTender does.
+		// The snapshot is written outside the transaction, before the tender row is locked by
+		// the update: its foreign key would otherwise wait for that lock.
+		current, err := s.getTenderById(ctx, tenderIdUUID)
+		if err != nil {
+			log.Error("failed to get the tender", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+		if err := s.addVersionTableTender(ctx, username, current); err != nil {
+			log.Error("failed to add tender to the version table", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+		var version int32
+		if err := tx.QueryRow(ctx, `
+		UPDATE tenders SET version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1
+		RETURNING version`, tenderIdUUID).Scan(&version); err != nil {
+			log.Error("failed to bump the tender version", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+		if _, err := tx.Exec(ctx, `
+		UPDATE tender_questions SET tender_version = $2 WHERE id = $1`, questionIdUUID, version); err != nil {
+			log.Error("failed to save the tender version", slog.Any("error", err))
+			return errorResult(ErrCodeInternal, err)
+		}
+		question.TenderVersion = &version
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if question.TenderVersion != nil {
+		log.Info("tender version bumped by an answer", slog.String("tender_id", tender.Id), slog.Int("version", int(*question.TenderVersion)))
+	}
+	return Response(http.StatusOK, question.toQuestion(&user.Id, true)), nil
+}
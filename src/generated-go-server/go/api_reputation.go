@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// ReputationAPIController binds reputation requests to the reputation service and writes the service results to the http response
+type ReputationAPIController struct {
+	service      ReputationAPIServicer
+	errorHandler ErrorHandler
+}
+
+// ReputationAPIOption for how the controller is set up.
+type ReputationAPIOption func(*ReputationAPIController)
+
+// WithReputationAPIErrorHandler inject ErrorHandler into controller
+func WithReputationAPIErrorHandler(h ErrorHandler) ReputationAPIOption {
+	return func(c *ReputationAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewReputationAPIController creates a reputation api controller
+func NewReputationAPIController(s ReputationAPIServicer, opts ...ReputationAPIOption) *ReputationAPIController {
+	controller := &ReputationAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the ReputationAPIController
+func (c *ReputationAPIController) Routes() Routes {
+	return Routes{
+		"GetReputation": Route{
+			strings.ToUpper("Get"),
+			"/api/reputation/{authorType}/{authorId}",
+			c.GetReputation,
+		},
+	}
+}
+
+// GetReputation - Репутация автора предложений
+func (c *ReputationAPIController) GetReputation(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	params := mux.Vars(r)
+	authorTypeParam, err := NewBidAuthorTypeFromValue(params["authorType"])
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "authorType", Err: NewLocalizedError(MsgOneOf, "'Organization', 'User'")}, nil)
+		return
+	}
+	authorIdParam := params["authorId"]
+	if authorIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"authorId"}, nil)
+		return
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return
+	}
+	result, err := c.service.GetReputation(r.Context(), authorTypeParam, authorIdParam, query.Get("username"))
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// ReputationAPIService shows the reputation of bid authors: how many of their bids won, were
+// canceled, and how the tender responsibles rated them. The counters are kept up to date by
+// refreshReputation whenever a bid, its decisions or its feedback change.
+type ReputationAPIService struct {
+	*DefaultAPIService
+}
+
+// NewReputationAPIService creates a reputation api service
+func NewReputationAPIService(pg *Postgres, log *slog.Logger) *ReputationAPIService {
+	return &ReputationAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// GetReputation - Репутация автора предложений
+func (s *ReputationAPIService) GetReputation(ctx context.Context, authorType BidAuthorType, authorId string, username string) (ImplResponse, error) {
+	const op = "ReputationAPIService.GetReputation"
+	log := s.log.With(slog.String("op", op))
+
+	if _, err := s.loadUser(ctx, username); err != nil {
+		return apiErrorResult(err)
+	}
+	authorIdUUID, err := s.ConvertIntoUUID(authorId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+
+	if authorType == USER {
+		if _, err := s.getUserById(ctx, authorIdUUID); err != nil {
+			if errors.Is(err, ErrNoUser) {
+				return errorResult(ErrCodeUserNotFound, err)
+			}
+			return errorResult(ErrCodeInternal, err)
+		}
+	} else {
+		if _, err := s.getOrganizationById(ctx, authorIdUUID); err != nil {
+			if errors.Is(err, ErrNoOrganization) {
+				return errorResult(ErrCodeOrganizationNotFound, err)
+			}
+			return errorResult(ErrCodeInternal, err)
+		}
+	}
+
+	reputation, err := s.authorReputation(ctx, authorType, authorIdUUID)
+	if err != nil {
+		log.Error("failed to read the reputation", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, reputation), nil
+}
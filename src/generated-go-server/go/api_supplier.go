@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// SupplierAPIController binds supplier list requests to the supplier service and writes the service results to the http response
+type SupplierAPIController struct {
+	service      SupplierAPIServicer
+	errorHandler ErrorHandler
+}
+
+// SupplierAPIOption for how the controller is set up.
+type SupplierAPIOption func(*SupplierAPIController)
+
+// WithSupplierAPIErrorHandler inject ErrorHandler into controller
+func WithSupplierAPIErrorHandler(h ErrorHandler) SupplierAPIOption {
+	return func(c *SupplierAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewSupplierAPIController creates a supplier api controller
+func NewSupplierAPIController(s SupplierAPIServicer, opts ...SupplierAPIOption) *SupplierAPIController {
+	controller := &SupplierAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the SupplierAPIController
+func (c *SupplierAPIController) Routes() Routes {
+	return Routes{
+		"GetOrganizationSuppliers": Route{
+			strings.ToUpper("Get"),
+			"/api/organizations/{organizationId}/suppliers",
+			c.GetOrganizationSuppliers,
+		},
+		"SetOrganizationSupplier": Route{
+			strings.ToUpper("Put"),
+			"/api/organizations/{organizationId}/suppliers",
+			c.SetOrganizationSupplier,
+		},
+		"SetOrganizationSupplierPolicy": Route{
+			strings.ToUpper("Put"),
+			"/api/organizations/{organizationId}/suppliers/policy",
+			c.SetOrganizationSupplierPolicy,
+		},
+		"DeleteOrganizationSupplier": Route{
+			strings.ToUpper("Delete"),
+			"/api/organizations/{organizationId}/suppliers/{supplierId}",
+			c.DeleteOrganizationSupplier,
+		},
+		"CheckBidEligibility": Route{
+			strings.ToUpper("Get"),
+			"/api/tenders/{tenderId}/eligibility",
+			c.CheckBidEligibility,
+		},
+	}
+}
+
+// GetOrganizationSuppliers - Список поставщиков организации
+func (c *SupplierAPIController) GetOrganizationSuppliers(w http.ResponseWriter, r *http.Request) {
+	organizationIdParam, usernameParam, ok := c.entityParams(w, r, "organizationId")
+	if !ok {
+		return
+	}
+	result, err := c.service.GetOrganizationSuppliers(r.Context(), organizationIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// SetOrganizationSupplier - Квалификация поставщика или внесение в черный список
+func (c *SupplierAPIController) SetOrganizationSupplier(w http.ResponseWriter, r *http.Request) {
+	organizationIdParam, usernameParam, ok := c.entityParams(w, r, "organizationId")
+	if !ok {
+		return
+	}
+	setSupplierRequestParam := SetSupplierRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&setSupplierRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertSetSupplierRequestRequired(setSupplierRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertSetSupplierRequestConstraints(setSupplierRequestParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.SetOrganizationSupplier(r.Context(), organizationIdParam, usernameParam, setSupplierRequestParam)
+	c.writeResult(w, r, result, err)
+}
+
+// SetOrganizationSupplierPolicy - Правило допуска поставщиков организации
+func (c *SupplierAPIController) SetOrganizationSupplierPolicy(w http.ResponseWriter, r *http.Request) {
+	organizationIdParam, usernameParam, ok := c.entityParams(w, r, "organizationId")
+	if !ok {
+		return
+	}
+	supplierPolicyParam := SupplierPolicy{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&supplierPolicyParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertSupplierPolicyRequired(supplierPolicyParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertSupplierPolicyConstraints(supplierPolicyParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.SetOrganizationSupplierPolicy(r.Context(), organizationIdParam, usernameParam, supplierPolicyParam)
+	c.writeResult(w, r, result, err)
+}
+
+// DeleteOrganizationSupplier - Удаление поставщика из списка организации
+func (c *SupplierAPIController) DeleteOrganizationSupplier(w http.ResponseWriter, r *http.Request) {
+	organizationIdParam, usernameParam, ok := c.entityParams(w, r, "organizationId")
+	if !ok {
+		return
+	}
+	supplierIdParam := mux.Vars(r)["supplierId"]
+	if supplierIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"supplierId"}, nil)
+		return
+	}
+	result, err := c.service.DeleteOrganizationSupplier(r.Context(), organizationIdParam, supplierIdParam, usernameParam)
+	c.writeResult(w, r, result, err)
+}
+
+// CheckBidEligibility - Проверка допуска к подаче предложения по тендеру
+func (c *SupplierAPIController) CheckBidEligibility(w http.ResponseWriter, r *http.Request) {
+	tenderIdParam, usernameParam, ok := c.entityParams(w, r, "tenderId")
+	if !ok {
+		return
+	}
+	query, _ := parseQuery(r.URL.RawQuery)
+	result, err := c.service.CheckBidEligibility(r.Context(), tenderIdParam, usernameParam, query.Get("organizationId"))
+	c.writeResult(w, r, result, err)
+}
+
+// entityParams reads the id named idParam from the path and the required username from the query.
+func (c *SupplierAPIController) entityParams(w http.ResponseWriter, r *http.Request, idParam string) (string, string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", "", false
+	}
+	id := mux.Vars(r)[idParam]
+	if id == "" {
+		c.errorHandler(w, r, &RequiredError{idParam}, nil)
+		return "", "", false
+	}
+	if !query.Has("username") {
+		c.errorHandler(w, r, &RequiredError{Field: "username"}, nil)
+		return "", "", false
+	}
+	return id, query.Get("username"), true
+}
+
+func (c *SupplierAPIController) writeResult(w http.ResponseWriter, r *http.Request, result ImplResponse, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,215 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SupplierAPIService keeps the supplier lists of the organizations. The responsibles of an
+// organization qualify or blacklist other organizations and employees, optionally until a
+// set moment, and may require qualification for bids on their tenders. CreateBid enforces
+// the list through checkBidder.
+type SupplierAPIService struct {
+	*DefaultAPIService
+}
+
+// NewSupplierAPIService creates a supplier api service
+func NewSupplierAPIService(pg *Postgres, log *slog.Logger) *SupplierAPIService {
+	return &SupplierAPIService{DefaultAPIService: NewDefaultAPIService(pg, log)}
+}
+
+// GetOrganizationSuppliers - Список поставщиков организации
+func (s *SupplierAPIService) GetOrganizationSuppliers(ctx context.Context, organizationId string, username string) (ImplResponse, error) {
+	_, orgIdUUID, err := s.organizationForWrite(ctx, organizationId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	list := SupplierList{OrganizationId: orgIdUUID.String()}
+	err = s.pg.Pool.QueryRow(ctx, `
+	SELECT require_qualification FROM organization_supplier_policy WHERE organization_id = $1`,
+		orgIdUUID).Scan(&list.RequireQualification)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errorResult(ErrCodeInternal, err)
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+supplierColumns+` FROM organization_suppliers e
+	WHERE e.organization_id = $1
+	ORDER BY e.status, e.created_at, e.id`, orgIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	list.Suppliers, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (SupplierEntry, error) {
+		return scanSupplier(row)
+	})
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if list.Suppliers == nil {
+		list.Suppliers = []SupplierEntry{}
+	}
+	return Response(http.StatusOK, list), nil
+}
+
+// SetOrganizationSupplier - Квалификация поставщика или внесение в черный список
+func (s *SupplierAPIService) SetOrganizationSupplier(ctx context.Context, organizationId string, username string, req SetSupplierRequest) (ImplResponse, error) {
+	const op = "SupplierAPIService.SetOrganizationSupplier"
+	log := s.log.With(slog.String("op", op))
+
+	user, orgIdUUID, err := s.organizationForWrite(ctx, organizationId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != "" {
+		expiresAt = parseOptionalTime(req.ExpiresAt)
+		if !expiresAt.After(time.Now()) {
+			return errorDetailResult(ErrCodeValidationFailed, MsgSupplierExpiresPast)
+		}
+	}
+
+	// A supplier has one entry per organization: setting it again replaces the status.
+	var supplierOrganizationId, supplierUserId *uuid.UUID
+	conflict := "(organization_id, supplier_user_id)"
+	if req.SupplierOrganizationId != "" {
+		supplierIdUUID, _ := s.ConvertIntoUUID(req.SupplierOrganizationId)
+		if supplierIdUUID == orgIdUUID {
+			return errorDetailResult(ErrCodeValidationFailed, MsgSupplierOwnOrg)
+		}
+		if _, err := s.getOrganizationById(ctx, supplierIdUUID); err != nil {
+			if errors.Is(err, ErrNoOrganization) {
+				return errorResult(ErrCodeOrganizationNotFound, err)
+			}
+			return errorResult(ErrCodeInternal, err)
+		}
+		supplierOrganizationId = &supplierIdUUID
+		conflict = "(organization_id, supplier_organization_id)"
+	} else {
+		supplier, err := s.loadUser(ctx, req.SupplierUsername)
+		if err != nil {
+			return apiErrorResult(err)
+		}
+		supplierUserId = &supplier.Id
+	}
+
+	entry, err := scanSupplier(s.pg.Pool.QueryRow(ctx, `
+	INSERT INTO organization_suppliers AS e (id, organization_id, supplier_organization_id, supplier_user_id, status, reason, expires_at, updated_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT `+conflict+` DO UPDATE SET status = EXCLUDED.status, reason = EXCLUDED.reason,
+		expires_at = EXCLUDED.expires_at, updated_by = EXCLUDED.updated_by, updated_at = CURRENT_TIMESTAMP
+	RETURNING `+supplierColumns,
+		uuid.New(), orgIdUUID, supplierOrganizationId, supplierUserId, string(req.Status), req.Reason, expiresAt, user.Id))
+	if err != nil {
+		log.Error("failed to save the supplier", slog.Any("error", err))
+		return errorResult(ErrCodeInternal, err)
+	}
+	log.Info("supplier list changed", slog.String("organization_id", entry.OrganizationId),
+		slog.String("entry_id", entry.Id), slog.String("status", string(entry.Status)))
+	return Response(http.StatusOK, entry), nil
+}
+
+// DeleteOrganizationSupplier - Удаление поставщика из списка организации
+func (s *SupplierAPIService) DeleteOrganizationSupplier(ctx context.Context, organizationId string, supplierId string, username string) (ImplResponse, error) {
+	_, orgIdUUID, err := s.organizationForWrite(ctx, organizationId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	supplierIdUUID, err := s.ConvertIntoUUID(supplierId)
+	if err != nil {
+		return errorResult(ErrCodeInvalidID, err)
+	}
+
+	tag, err := s.pg.Pool.Exec(ctx, `
+	DELETE FROM organization_suppliers WHERE id = $1 AND organization_id = $2`, supplierIdUUID, orgIdUUID)
+	if err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errorResult(ErrCodeSupplierNotFound, ErrNotFound)
+	}
+	return Response(http.StatusNoContent, nil), nil
+}
+
+// SetOrganizationSupplierPolicy - Правило допуска поставщиков организации
+func (s *SupplierAPIService) SetOrganizationSupplierPolicy(ctx context.Context, organizationId string, username string, req SupplierPolicy) (ImplResponse, error) {
+	user, orgIdUUID, err := s.organizationForWrite(ctx, organizationId, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	var policy SupplierPolicy
+	if err := s.pg.Pool.QueryRow(ctx, `
+	INSERT INTO organization_supplier_policy (organization_id, require_qualification, updated_by) VALUES ($1, $2, $3)
+	ON CONFLICT (organization_id) DO UPDATE SET require_qualification = EXCLUDED.require_qualification,
+		updated_by = EXCLUDED.updated_by, updated_at = CURRENT_TIMESTAMP
+	RETURNING require_qualification`, orgIdUUID, req.RequireQualification, user.Id).Scan(&policy.RequireQualification); err != nil {
+		return errorResult(ErrCodeInternal, err)
+	}
+	return Response(http.StatusOK, policy), nil
+}
+
+// CheckBidEligibility - Проверка допуска к подаче предложения по тендеру
+func (s *SupplierAPIService) CheckBidEligibility(ctx context.Context, tenderId string, username string, organizationId string) (ImplResponse, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	tender, err := s.loadTender(ctx, tenderId)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	// The user checks for themselves or for an organization they are responsible for, so
+	// the reasons are only shown to the supplier concerned.
+	eligibility := BidEligibility{TenderId: tender.Id, AuthorType: USER, AuthorId: user.Id.String()}
+	authorId := user.Id
+	if organizationId != "" {
+		_, orgIdUUID, err := s.organizationForWrite(ctx, organizationId, username)
+		if err != nil {
+			return apiErrorResult(err)
+		}
+		eligibility.AuthorType, eligibility.AuthorId = ORGANIZATION, orgIdUUID.String()
+		authorId = orgIdUUID
+	}
+
+	apiErr := s.checkBidder(ctx, tender, eligibility.AuthorType, authorId)
+	if apiErr == nil && tender.ConflictPolicy == CONFLICT_POLICY_BLOCK {
+		apiErr = s.checkBlockedConflicts(ctx, tender, eligibility.AuthorType, authorId)
+	}
+	switch {
+	case apiErr == nil:
+		eligibility.Allowed = true
+	case apiErr.Code == ErrCodeInternal:
+		return apiErrorResult(apiErr)
+	default:
+		locale := LocaleFromContext(ctx)
+		eligibility.Code = apiErr.Code
+		if apiErr.Detail != nil {
+			eligibility.Detail = apiErr.Detail.Localize(locale)
+		} else {
+			eligibility.Detail = Translate(locale, errorMessageKey(apiErr.Code))
+		}
+	}
+	return Response(http.StatusOK, eligibility), nil
+}
+
+// checkBlockedConflicts runs the conflict checks CreateBid would run on a tender with the Block
+// policy, in a transaction that is never committed.
+func (s *SupplierAPIService) checkBlockedConflicts(ctx context.Context, tender *Tender, authorType BidAuthorType, authorId uuid.UUID) *APIError {
+	tx, err := s.pg.Pool.Begin(ctx)
+	if err != nil {
+		return NewAPIError(ErrCodeInternal, err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, apiErr := checkBidConflicts(ctx, tx, tender, authorType, authorId)
+	return apiErr
+}
@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Kinds of entities attachments belong to, as stored in attachments.entity_type.
+const (
+	attachmentEntityTender = "tender"
+	attachmentEntityBid    = "bid"
+)
+
+// attachmentTypes lists the accepted file extensions with the content type the file is served
+// with and the type http.DetectContentType must report for its contents. Office documents are
+// zip archives, CSV is plain text.
+var attachmentTypes = map[string]struct{ contentType, sniffed string }{
+	".pdf":  {"application/pdf", "application/pdf"},
+	".png":  {"image/png", "image/png"},
+	".jpg":  {"image/jpeg", "image/jpeg"},
+	".jpeg": {"image/jpeg", "image/jpeg"},
+	".txt":  {"text/plain; charset=utf-8", "text/plain; charset=utf-8"},
+	".csv":  {"text/csv; charset=utf-8", "text/plain; charset=utf-8"},
+	".xlsx": {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/zip"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip"},
+	".zip":  {"application/zip", "application/zip"},
+}
+
+// detectAttachmentType returns the content type of a file from its name and the first bytes
+// of its contents. Files whose contents do not match the extension are rejected.
+func detectAttachmentType(name string, head []byte) (string, bool) {
+	t, ok := attachmentTypes[strings.ToLower(filepath.Ext(name))]
+	if !ok || http.DetectContentType(head) != t.sniffed {
+		return "", false
+	}
+	return t.contentType, true
+}
+
+// allowedAttachmentExtensions lists the accepted extensions for error messages.
+func allowedAttachmentExtensions() string {
+	extensions := make([]string, 0, len(attachmentTypes))
+	for ext := range attachmentTypes {
+		extensions = append(extensions, ext)
+	}
+	sort.Strings(extensions)
+	return strings.Join(extensions, ", ")
+}
+
+// snapshotAttachments records the current attachments of an entity as the attachments of
+// version. It is called together with every write to the version tables, so a snapshot is
+// refreshed when the same version is saved again.
+func (s *DefaultAPIService) snapshotAttachments(ctx context.Context, entityType string, entityId string, version int32) error {
+	const op = "snapshotAttachments"
+	log := s.log.With(slog.String("op", op))
+
+	where := squirrel.Eq{"entity_type": entityType, "entity_id": entityId, "version": version}
+	sql, args, err := s.builder.Delete("attachment_snapshots").Where(where).ToSql()
+	if err != nil {
+		log.Error("failed to build query", slog.Any("error", err))
+		return err
+	}
+	if _, err := s.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		log.Error("failed to delete the previous snapshot", slog.Any("error", err))
+		return err
+	}
+
+	query := `
+	INSERT INTO attachment_snapshots (entity_type, entity_id, version, attachment_id)
+	SELECT entity_type, entity_id, $3, id
+	FROM attachments
+	WHERE entity_type = $1 AND entity_id = $2 AND removed_at IS NULL`
+
+	if _, err := s.pg.Pool.Exec(ctx, query, entityType, entityId, version); err != nil {
+		log.Error("failed to save the snapshot", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// restoreAttachments makes the attachments of version current again after a rollback.
+// Versions saved before attachments existed have no snapshot and leave the attachments as is.
+func (s *DefaultAPIService) restoreAttachments(ctx context.Context, entityType string, entityId string, version int32) error {
+	const op = "restoreAttachments"
+	log := s.log.With(slog.String("op", op))
+
+	query := `
+	UPDATE attachments
+	SET removed_at = CASE WHEN id IN (
+			SELECT attachment_id FROM attachment_snapshots WHERE entity_type = $1 AND entity_id = $2 AND version = $3
+		) THEN NULL ELSE COALESCE(removed_at, CURRENT_TIMESTAMP) END
+	WHERE entity_type = $1 AND entity_id = $2
+		AND EXISTS (SELECT 1 FROM attachment_snapshots WHERE entity_type = $1 AND entity_id = $2 AND version = $3)`
+
+	if _, err := s.pg.Pool.Exec(ctx, query, entityType, entityId, version); err != nil {
+		log.Error("failed to restore attachments", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
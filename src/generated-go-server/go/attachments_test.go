@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := store.Put(ctx, "4f0c1b6e-key", strings.NewReader("техническое задание"))
+	if err != nil || n != int64(len("техническое задание")) {
+		t.Fatalf("Put = %d, %v", n, err)
+	}
+
+	rc, err := store.Open(ctx, "4f0c1b6e-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "техническое задание" {
+		t.Errorf("Open = %q", data)
+	}
+
+	if err := store.Delete(ctx, "4f0c1b6e-key"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(ctx, "4f0c1b6e-key"); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("Open after Delete: %v, want ErrBlobNotFound", err)
+	}
+	if err := store.Delete(ctx, "4f0c1b6e-key"); err != nil {
+		t.Errorf("deleting a missing blob: %v", err)
+	}
+
+	for _, key := range []string{"", "ab", "../etc/passwd", "a/b/c", ".hidden"} {
+		if _, err := store.Put(ctx, key, strings.NewReader("x")); err == nil {
+			t.Errorf("Put(%q) accepted an unsafe key", key)
+		}
+	}
+}
+
+func TestDetectAttachmentType(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n")
+	zip := []byte("PK\x03\x04rest of the archive")
+
+	for _, tc := range []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{"spec.pdf", pdf, "application/pdf"},
+		{"Prices.XLSX", zip, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{"prices.csv", []byte("name,price\nтрубы,100\n"), "text/csv; charset=utf-8"},
+		{"spec.pdf", zip, ""},
+		{"run.exe", []byte("MZ\x90\x00"), ""},
+		{"notes", []byte("text"), ""},
+	} {
+		got, ok := detectAttachmentType(tc.name, tc.head)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Errorf("detectAttachmentType(%q) = %q, %v, want %q", tc.name, got, ok, tc.want)
+		}
+	}
+}
+
+// fakeAttachmentService records the uploaded file and serves one attachment.
+type fakeAttachmentService struct {
+	AttachmentAPIServicer
+	maxSize  int64
+	uploaded string
+}
+
+func (s *fakeAttachmentService) MaxSize() int64 { return s.maxSize }
+
+func (s *fakeAttachmentService) UploadTenderAttachment(ctx context.Context, tenderId, username string, file *os.File) (ImplResponse, error) {
+	defer os.Remove(file.Name())
+	data, err := os.ReadFile(file.Name())
+	s.uploaded = string(data)
+	return Response(http.StatusCreated, Attachment{Id: "1"}), err
+}
+
+func (s *fakeAttachmentService) DownloadTenderAttachment(ctx context.Context, tenderId, attachmentId, username string) (ImplResponse, error) {
+	return Response(http.StatusOK, &AttachmentContent{
+		Attachment: Attachment{Name: "ТЗ.pdf", ContentType: "application/pdf", Size: 4, Sha256: "abc"},
+		Content:    io.NopCloser(strings.NewReader("%PDF")),
+	}), nil
+}
+
+func multipartUpload(t *testing.T, field, name, content string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile(field, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte(content))
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/tenders/550e8400-e29b-41d4-a716-446655440000/attachments?username=user1", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestUploadAttachment(t *testing.T) {
+	service := &fakeAttachmentService{maxSize: 1 << 10}
+	router := NewRouter(NewAttachmentAPIController(service))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, multipartUpload(t, "file", "spec.pdf", "%PDF-1.7"))
+	if rec.Code != http.StatusCreated || service.uploaded != "%PDF-1.7" {
+		t.Errorf("got %d %s, uploaded %q", rec.Code, rec.Body.String(), service.uploaded)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, multipartUpload(t, "document", "spec.pdf", "%PDF-1.7"))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("without the file part: got %d %s, want 422", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, multipartUpload(t, "file", "spec.pdf", strings.Repeat("x", multipartOverhead+2<<10)))
+	if rec.Code != http.StatusRequestEntityTooLarge || !strings.Contains(rec.Body.String(), string(ErrCodePayloadTooLarge)) {
+		t.Errorf("too large: got %d %s, want 413", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	router := NewRouter(NewAttachmentAPIController(&fakeAttachmentService{}))
+	url := "/api/tenders/550e8400-e29b-41d4-a716-446655440000/attachments/550e8400-e29b-41d4-a716-446655440001"
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "%PDF" {
+		t.Fatalf("got %d %q", rec.Code, rec.Body.String())
+	}
+	for header, want := range map[string]string{
+		"Content-Type":           "application/pdf",
+		"Content-Length":         "4",
+		"Content-Disposition":    "attachment; filename*=utf-8''%D0%A2%D0%97.pdf",
+		"ETag":                   `"abc"`,
+		"X-Content-Type-Options": "nosniff",
+	} {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("If-None-Match", `"abc"`)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("If-None-Match: got %d %q, want 304", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,215 @@
+package openapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// defaultAuctionExtension is the anti-sniping window and extension, in seconds, of
+	// auctions created without them.
+	defaultAuctionExtension = 120
+	// eventAuctionFinished is the outbox event recorded when an auction ends.
+	eventAuctionFinished = "tender.auction_finished"
+	// auctionHeartbeat is how often an idle auction stream sends a comment, so that proxies
+	// do not drop the connection.
+	auctionHeartbeat = 15 * time.Second
+)
+
+// auctionColumns are the columns read by scanAuction.
+const auctionColumns = `tender_id, starts_at, ends_at, currency, start_price, min_decrement,
+	extension_window_seconds, extension_seconds, best_price, best_bid_id, offer_count,
+	winning_bid_id, finished_at`
+
+// auctionRow is an auction as stored. Its status depends on the time it is looked at, so it
+// is turned into an Auction only for a given moment.
+type auctionRow struct {
+	TenderId        uuid.UUID
+	StartsAt        time.Time
+	EndsAt          time.Time
+	Currency        string
+	StartPrice      decimal.NullDecimal
+	MinDecrement    decimal.Decimal
+	ExtensionWindow int32
+	Extension       int32
+	BestPrice       decimal.NullDecimal
+	BestBidId       *uuid.UUID
+	OfferCount      int32
+	WinningBidId    *uuid.UUID
+	FinishedAt      *time.Time
+}
+
+func scanAuction(row pgx.Row) (auctionRow, error) {
+	var a auctionRow
+	err := row.Scan(&a.TenderId, &a.StartsAt, &a.EndsAt, &a.Currency, &a.StartPrice, &a.MinDecrement,
+		&a.ExtensionWindow, &a.Extension, &a.BestPrice, &a.BestBidId, &a.OfferCount,
+		&a.WinningBidId, &a.FinishedAt)
+	return a, err
+}
+
+// status of the auction at now. An auction past its end stays Running until the scheduler
+// has recorded the result, but it no longer accepts offers.
+func (a auctionRow) status(now time.Time) AuctionStatus {
+	switch {
+	case a.FinishedAt != nil:
+		return AUCTION_FINISHED
+	case now.Before(a.StartsAt):
+		return AUCTION_SCHEDULED
+	default:
+		return AUCTION_RUNNING
+	}
+}
+
+func (a auctionRow) toAuction(now time.Time) Auction {
+	auction := Auction{
+		TenderId:               a.TenderId.String(),
+		Status:                 a.status(now),
+		StartsAt:               formatOptionalTime(&a.StartsAt),
+		EndsAt:                 formatOptionalTime(&a.EndsAt),
+		StartPrice:             a.money(a.StartPrice),
+		MinDecrement:           Money{Amount: a.MinDecrement, Currency: a.Currency},
+		ExtensionWindowSeconds: a.ExtensionWindow,
+		ExtensionSeconds:       a.Extension,
+		BestPrice:              a.money(a.BestPrice),
+		OfferCount:             a.OfferCount,
+		FinishedAt:             formatOptionalTime(a.FinishedAt),
+	}
+	if a.WinningBidId != nil {
+		auction.WinningBidId = a.WinningBidId.String()
+	}
+	return auction
+}
+
+func (a auctionRow) money(amount decimal.NullDecimal) *Money {
+	return nullMoney{Amount: amount, Currency: &a.Currency}.Money()
+}
+
+// checkOffer checks that price may be offered at now: the auction is running and the price
+// is at least one minimal decrement below the best price, or not above the start price for
+// the first offer.
+func (a auctionRow) checkOffer(price Money, now time.Time) *APIError {
+	if now.Before(a.StartsAt) {
+		return NewAPIError(ErrCodeAuctionNotRunning, nil).WithDetail(MsgAuctionNotStarted, formatOptionalTime(&a.StartsAt))
+	}
+	if a.FinishedAt != nil || !now.Before(a.EndsAt) {
+		return NewAPIError(ErrCodeAuctionNotRunning, nil).WithDetail(MsgAuctionEnded, formatOptionalTime(&a.EndsAt))
+	}
+	if price.Currency != a.Currency {
+		return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgPriceCurrencyMismatch, a.Currency)
+	}
+	if a.BestPrice.Valid {
+		limit := a.BestPrice.Decimal.Sub(a.MinDecrement)
+		if price.Amount.GreaterThan(limit) {
+			return NewAPIError(ErrCodeOfferTooHigh, nil).WithDetail(MsgAuctionDecrement, limit.String(), a.Currency)
+		}
+		return nil
+	}
+	if a.StartPrice.Valid && price.Amount.GreaterThan(a.StartPrice.Decimal) {
+		return NewAPIError(ErrCodeOfferTooHigh, nil).WithDetail(MsgAuctionStartPrice, a.StartPrice.Decimal.String(), a.Currency)
+	}
+	return nil
+}
+
+// extendedEnd is the end of the auction after an offer made at now. An offer made within the
+// anti-sniping window gives the others the extension to answer it.
+func (a auctionRow) extendedEnd(now time.Time) time.Time {
+	window := time.Duration(a.ExtensionWindow) * time.Second
+	if a.EndsAt.Sub(now) >= window {
+		return a.EndsAt
+	}
+	if end := now.Add(time.Duration(a.Extension) * time.Second); end.After(a.EndsAt) {
+		return end
+	}
+	return a.EndsAt
+}
+
+// tenderHasLots reports whether any lot was added to the tender. Auctions are run only on
+// tenders without lots: there is a single price to bid down.
+func tenderHasLots(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM lots WHERE tender_id = $1)`, tenderId).Scan(&exists)
+	return exists, err
+}
+
+// auctionJobKey is the Scheduler key of the job that ends the auction of a tender.
+func auctionJobKey(tenderId uuid.UUID) string {
+	return "auction:" + tenderId.String()
+}
+
+// AuctionFeed is the body of a FollowTenderAuction result: the auction as it is now and its
+// later updates. Stop must be called once the feed is no longer read.
+type AuctionFeed struct {
+	Current Auction
+	Updates <-chan Auction
+	Stop    func()
+}
+
+// AuctionHub passes auction updates to the participants following them. A slow follower
+// only misses intermediate updates: it always gets the latest one.
+type AuctionHub struct {
+	mu        sync.Mutex
+	followers map[string]map[chan Auction]struct{}
+	closed    bool
+}
+
+func NewAuctionHub() *AuctionHub {
+	return &AuctionHub{followers: make(map[string]map[chan Auction]struct{})}
+}
+
+// Follow returns the updates of the auction of the tender and a function that stops them.
+func (h *AuctionHub) Follow(tenderId string) (<-chan Auction, func()) {
+	ch := make(chan Auction, 1)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if h.followers[tenderId] == nil {
+		h.followers[tenderId] = make(map[chan Auction]struct{})
+	}
+	h.followers[tenderId][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.followers[tenderId], ch)
+		if len(h.followers[tenderId]) == 0 {
+			delete(h.followers, tenderId)
+		}
+	}
+}
+
+// Publish sends the auction to its followers without waiting for any of them.
+func (h *AuctionHub) Publish(auction Auction) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.followers[auction.TenderId] {
+		// Replace an update the follower has not read yet.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- auction
+	}
+}
+
+// Close ends every feed, so that open streams do not hold up the shutdown of the server.
+func (h *AuctionHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for tenderId, chans := range h.followers {
+		for ch := range chans {
+			close(ch)
+		}
+		delete(h.followers, tenderId)
+	}
+}
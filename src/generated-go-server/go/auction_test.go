@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+)
+
+func testAuction() auctionRow {
+	return auctionRow{
+		TenderId:        uuid.New(),
+		StartsAt:        schedulerEpoch,
+		EndsAt:          schedulerEpoch.Add(time.Hour),
+		Currency:        "RUB",
+		StartPrice:      decimal.NewNullDecimal(decimal.RequireFromString("1000")),
+		MinDecrement:    decimal.RequireFromString("10"),
+		ExtensionWindow: 120,
+		Extension:       300,
+	}
+}
+
+func TestAuctionCheckOffer(t *testing.T) {
+	running := schedulerEpoch.Add(time.Minute)
+	withBest := testAuction()
+	withBest.BestPrice = decimal.NewNullDecimal(decimal.RequireFromString("900"))
+	finished := withBest
+	finished.FinishedAt = &running
+
+	for _, tc := range []struct {
+		name    string
+		auction auctionRow
+		price   Money
+		at      time.Time
+		want    ErrorCode
+	}{
+		{"first offer at the start price", testAuction(), money("1000", "RUB"), running, ""},
+		{"first offer above the start price", testAuction(), money("1000.01", "RUB"), running, ErrCodeOfferTooHigh},
+		{"one decrement below the best", withBest, money("890", "RUB"), running, ""},
+		{"less than a decrement below the best", withBest, money("890.5", "RUB"), running, ErrCodeOfferTooHigh},
+		{"another currency", withBest, money("100", "USD"), running, ErrCodeValidationFailed},
+		{"before the start", testAuction(), money("500", "RUB"), schedulerEpoch.Add(-time.Second), ErrCodeAuctionNotRunning},
+		{"at the end", withBest, money("500", "RUB"), schedulerEpoch.Add(time.Hour), ErrCodeAuctionNotRunning},
+		{"finished", finished, money("500", "RUB"), running, ErrCodeAuctionNotRunning},
+	} {
+		err := tc.auction.checkOffer(tc.price, tc.at)
+		var code ErrorCode
+		if err != nil {
+			code = err.Code
+		}
+		if code != tc.want {
+			t.Errorf("%s: code %q, want %q", tc.name, code, tc.want)
+		}
+	}
+}
+
+func TestAuctionExtendedEnd(t *testing.T) {
+	a := testAuction()
+	for _, tc := range []struct {
+		before time.Duration
+		want   time.Time
+	}{
+		{time.Hour, a.EndsAt},
+		{2 * time.Minute, a.EndsAt},
+		{119 * time.Second, a.EndsAt.Add(-119*time.Second + 5*time.Minute)},
+		{time.Second, a.EndsAt.Add(-time.Second + 5*time.Minute)},
+	} {
+		if got := a.extendedEnd(a.EndsAt.Add(-tc.before)); !got.Equal(tc.want) {
+			t.Errorf("offer %v before the end: ends at %v, want %v", tc.before, got, tc.want)
+		}
+	}
+
+	// An extension shorter than the time left never brings the end forward.
+	a.Extension = 10
+	if got := a.extendedEnd(a.EndsAt.Add(-time.Minute)); !got.Equal(a.EndsAt) {
+		t.Errorf("short extension moved the end to %v", got)
+	}
+}
+
+func TestAuctionStatus(t *testing.T) {
+	a := testAuction()
+	if got := a.toAuction(schedulerEpoch.Add(-time.Second)).Status; got != AUCTION_SCHEDULED {
+		t.Errorf("before the start: %s", got)
+	}
+	if got := a.toAuction(a.EndsAt.Add(time.Second)); got.Status != AUCTION_RUNNING || got.BestPrice != nil {
+		t.Errorf("after the end, before the result: %+v", got)
+	}
+	winner := uuid.New()
+	a.FinishedAt, a.WinningBidId = &a.EndsAt, &winner
+	a.BestPrice = decimal.NewNullDecimal(decimal.RequireFromString("500"))
+	got := a.toAuction(a.EndsAt)
+	if got.Status != AUCTION_FINISHED || got.WinningBidId != winner.String() || got.BestPrice.Amount.String() != "500" {
+		t.Errorf("finished: %+v", got)
+	}
+}
+
+func TestAuctionHub(t *testing.T) {
+	hub := NewAuctionHub()
+	first, stopFirst := hub.Follow("t1")
+	other, stopOther := hub.Follow("t2")
+	defer stopOther()
+
+	hub.Publish(Auction{TenderId: "t1", OfferCount: 1})
+	hub.Publish(Auction{TenderId: "t1", OfferCount: 2})
+	if got := <-first; got.OfferCount != 2 {
+		t.Errorf("a slow follower got offer %d, want the latest", got.OfferCount)
+	}
+	select {
+	case got := <-other:
+		t.Errorf("update of another tender delivered: %+v", got)
+	default:
+	}
+
+	stopFirst()
+	hub.Publish(Auction{TenderId: "t1", OfferCount: 3})
+	select {
+	case got := <-first:
+		t.Errorf("update delivered after stop: %+v", got)
+	default:
+	}
+
+	hub.Close()
+	if _, ok := <-other; ok {
+		t.Error("Close left a feed open")
+	}
+	late, _ := hub.Follow("t2")
+	if _, ok := <-late; ok {
+		t.Error("Follow after Close returned an open feed")
+	}
+}
+
+func TestCreateAuctionRequestValidation(t *testing.T) {
+	router := NewRouter(NewAuctionAPIController(nil))
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{"startsAt":"2030-01-01T10:00:00Z","minDecrement":{"amount":"10","currency":"RUB"}}`, http.StatusUnprocessableEntity},
+		{`{"startsAt":"2030-01-01T10:00:00Z","endsAt":"2030-01-01T09:00:00Z","minDecrement":{"amount":"10","currency":"RUB"}}`, http.StatusBadRequest},
+		{`{"startsAt":"2030-01-01","endsAt":"2030-01-01T11:00:00Z","minDecrement":{"amount":"10","currency":"RUB"}}`, http.StatusBadRequest},
+		{`{"startsAt":"2030-01-01T10:00:00Z","endsAt":"2030-01-01T11:00:00Z","minDecrement":{"amount":"10","currency":"RUB"},"startPrice":{"amount":"100","currency":"USD"}}`, http.StatusBadRequest},
+		{`{"startsAt":"2030-01-01T10:00:00Z","endsAt":"2030-01-01T11:00:00Z","minDecrement":{"amount":"10","currency":"RUB"},"extensionSeconds":3601}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/tenders/"+importOrgID+"/auction?username=user1", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+
+	var parsing *ParsingError
+	err := AssertCreateAuctionRequestConstraints(CreateAuctionRequest{
+		StartsAt:     "2030-01-01T10:00:00+03:00",
+		EndsAt:       "2030-01-01T10:00:00+03:00",
+		MinDecrement: money("10", "RUB"),
+	})
+	if !errors.As(err, &parsing) || parsing.Param != "endsAt" {
+		t.Errorf("an auction ending when it starts: err = %v", err)
+	}
+}
+
+// scriptedTx is a transaction that answers QueryRow from a script and records what it
+// executes. A query is answered by the first script entry whose key it contains; a query
+// without one finds no rows. Other methods of pgx.Tx are not implemented.
+type scriptedTx struct {
+	pgx.Tx
+	script []scriptedRow
+	execs  []scriptedExec
+}
+
+type scriptedRow struct {
+	key    string
+	values []any
+}
+
+type scriptedExec struct {
+	sql  string
+	args []any
+}
+
+func (tx *scriptedTx) QueryRow(_ context.Context, sql string, _ ...any) pgx.Row {
+	for _, r := range tx.script {
+		if strings.Contains(sql, r.key) {
+			return scriptedValues(r.values)
+		}
+	}
+	return scriptedValues(nil)
+}
+
+func (tx *scriptedTx) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx.execs = append(tx.execs, scriptedExec{sql: sql, args: args})
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+// executed returns the statements that contain key.
+func (tx *scriptedTx) executed(key string) []scriptedExec {
+	var execs []scriptedExec
+	for _, e := range tx.execs {
+		if strings.Contains(e.sql, key) {
+			execs = append(execs, e)
+		}
+	}
+	return execs
+}
+
+type scriptedValues []any
+
+func (v scriptedValues) Scan(dest ...any) error {
+	if v == nil {
+		return pgx.ErrNoRows
+	}
+	if len(dest) != len(v) {
+		return fmt.Errorf("%d values scanned into %d destinations", len(v), len(dest))
+	}
+	for i, value := range v {
+		target := reflect.ValueOf(dest[i]).Elem()
+		target.Set(reflect.ValueOf(value).Convert(target.Type()))
+	}
+	return nil
+}
+
+func TestApproveAuctionWinner(t *testing.T) {
+	tenderId, bidId, authorId := uuid.New(), uuid.New(), uuid.New()
+	tx := &scriptedTx{script: []scriptedRow{
+		// The bid as it is after the decision, and as it was counted when it was created.
+		{"FROM bids b WHERE b.bid_id", []any{USER, authorId, true, true, false, int32(0), int32(0)}},
+		{"FROM reputation_bids", []any{USER, authorId, false, false, false, int32(0), int32(0)}},
+		{"SELECT author_type, author_id FROM bids", []any{USER, authorId}},
+	}}
+
+	if err := approveAuctionWinner(context.Background(), tx, tenderId, bidId); err != nil {
+		t.Fatal(err)
+	}
+
+	decisions := tx.executed("INSERT INTO bid_decisions")
+	if len(decisions) != 1 || decisions[0].args[0] != bidId || decisions[0].args[1] != string(APPROVED) {
+		t.Errorf("decisions = %+v, want the bid approved", decisions)
+	}
+	counters := tx.executed("INSERT INTO reputation ")
+	if len(counters) != 1 {
+		t.Fatalf("reputation updated %d times, want once", len(counters))
+	}
+	// author_type, author_id, bids, decided, wins, ...
+	if args := counters[0].args; args[1] != authorId || args[2] != int32(0) || args[3] != int32(1) || args[4] != int32(1) {
+		t.Errorf("reputation change = %v, want one more decided bid and one more win", args)
+	}
+	events := tx.executed("INSERT INTO outbox_events")
+	if len(events) == 0 || events[0].args[0] != eventBidApproved || events[0].args[1] != bidId {
+		t.Fatalf("events = %+v, want %s first", events, eventBidApproved)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(events[0].args[2].([]byte), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload["tenderId"] != tenderId.String() || payload["authorType"] != string(USER) || payload["authorId"] != authorId.String() {
+		t.Errorf("payload = %v, want the tender and the author of the bid", payload)
+	}
+	if len(tx.executed("UPDATE tenders SET status")) != 1 {
+		t.Error("the tender was not closed")
+	}
+}
@@ -0,0 +1,128 @@
+package openapi
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and runs functions after a delay. Services that act at a set moment,
+// such as closing an auction, take a Clock so that tests can drive them with a FakeClock.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine once d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call made by Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the call and reports whether it was still pending.
+	Stop() bool
+}
+
+// SystemClock is the Clock of the running server.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+// FakeClock is a Clock that only moves when told to. Functions scheduled with AfterFunc run
+// synchronously inside Advance, so a test sees their effects as soon as Advance returns.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    int
+	timers []*fakeTimer
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	t := &fakeTimer{clock: c, at: c.now.Add(d), seq: c.seq, f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and runs every function that falls due on the way,
+// in the order of their due time. While a function runs the clock reads its due time, and
+// functions it schedules before the new time run too.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	until := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		next := c.nextDue(until)
+		if next == nil {
+			c.now = until
+			c.mu.Unlock()
+			return
+		}
+		c.remove(next)
+		if next.at.After(c.now) {
+			c.now = next.at
+		}
+		c.mu.Unlock()
+
+		next.f()
+	}
+}
+
+// Pending returns the number of functions waiting to run.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// nextDue returns the earliest timer due by until, or nil. Timers due at the same moment run
+// in the order they were created.
+func (c *FakeClock) nextDue(until time.Time) *fakeTimer {
+	var next *fakeTimer
+	for _, t := range c.timers {
+		if t.at.After(until) {
+			continue
+		}
+		if next == nil || t.at.Before(next.at) || (t.at.Equal(next.at) && t.seq < next.seq) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	i := slices.Index(c.timers, t)
+	if i < 0 {
+		return false
+	}
+	c.timers = slices.Delete(c.timers, i, i+1)
+	return true
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	seq   int
+	f     func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
@@ -1,37 +1,528 @@
 package openapi
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"net"
+	"net/mail"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
+const redactedValue = "*****"
+
+// Config is the effective configuration of the service. It is assembled by Load from,
+// in increasing order of precedence: built-in defaults, an optional YAML/TOML file,
+// the .env file and the process environment.
 type Config struct {
-	ServerAddress    string
-	PostgresConn     string
-	PostgresJdbcUrl  string
-	PostgresUsername string
-	PostgresPassword string
-	PostgresHost     string
-	PostgresPort     string
-	PostgresDatabase string
+	Server        ServerConfig        `yaml:"server" toml:"server"`
+	Postgres      PostgresConfig      `yaml:"postgres" toml:"postgres"`
+	Log           LogConfig           `yaml:"log" toml:"log"`
+	I18n          I18nConfig          `yaml:"i18n" toml:"i18n"`
+	Validation    ValidationConfig    `yaml:"validation" toml:"validation"`
+	Attachments   AttachmentsConfig   `yaml:"attachments" toml:"attachments"`
+	SealedBids    SealedBidsConfig    `yaml:"sealed_bids" toml:"sealed_bids"`
+	Notifications NotificationsConfig `yaml:"notifications" toml:"notifications"`
+	Mail          MailConfig          `yaml:"mail" toml:"mail"`
+	Idempotency   IdempotencyConfig   `yaml:"idempotency" toml:"idempotency"`
+	Features      FeatureFlags        `yaml:"features" toml:"features"`
+}
+
+type ServerConfig struct {
+	Address         string        `yaml:"address" toml:"address" env:"SERVER_ADDRESS"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	// DrainDelay is how long the server keeps serving while readiness reports it is shutting
+	// down, so that load balancers stop routing to it before connections are refused.
+	DrainDelay time.Duration `yaml:"drain_delay" toml:"drain_delay" env:"SERVER_DRAIN_DELAY"`
+}
+
+type PostgresConfig struct {
+	Conn         string        `yaml:"conn" toml:"conn" env:"POSTGRES_CONN"`
+	JdbcUrl      string        `yaml:"jdbc_url" toml:"jdbc_url" env:"POSTGRES_JDBC_URL"`
+	Username     string        `yaml:"username" toml:"username" env:"POSTGRES_USERNAME"`
+	Password     string        `yaml:"password" toml:"password" env:"POSTGRES_PASSWORD"`
+	Host         string        `yaml:"host" toml:"host" env:"POSTGRES_HOST"`
+	Port         string        `yaml:"port" toml:"port" env:"POSTGRES_PORT"`
+	Database     string        `yaml:"database" toml:"database" env:"POSTGRES_DATABASE"`
+	MaxPoolSize  int           `yaml:"max_pool_size" toml:"max_pool_size" env:"POSTGRES_MAX_POOL_SIZE"`
+	ConnAttempts int           `yaml:"conn_attempts" toml:"conn_attempts" env:"POSTGRES_CONN_ATTEMPTS"`
+	ConnTimeout  time.Duration `yaml:"conn_timeout" toml:"conn_timeout" env:"POSTGRES_CONN_TIMEOUT"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn, error.
+	Level string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	// Format is either text or json.
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+}
+
+type I18nConfig struct {
+	// DefaultLocale is used when the request has no Accept-Language header or accepts
+	// none of the supported languages.
+	DefaultLocale string `yaml:"default_locale" toml:"default_locale" env:"DEFAULT_LOCALE"`
+}
+
+type ValidationConfig struct {
+	// Requests rejects requests that do not match the OpenAPI spec.
+	Requests bool `yaml:"requests" toml:"requests" env:"VALIDATE_REQUESTS"`
+	// Responses logs successful responses that do not match the spec. Meant for debugging:
+	// every response is buffered before it is sent.
+	Responses bool `yaml:"responses" toml:"responses" env:"VALIDATE_RESPONSES"`
+}
+
+type AttachmentsConfig struct {
+	// Dir is the directory of the local blob store.
+	Dir string `yaml:"dir" toml:"dir" env:"ATTACHMENTS_DIR"`
+	// MaxSize is the largest accepted file in bytes.
+	MaxSize int64 `yaml:"max_size" toml:"max_size" env:"ATTACHMENTS_MAX_SIZE"`
+}
+
+type SealedBidsConfig struct {
+	// Key is the base64 encoded 32 byte AES key that encrypts the bids of sealed tenders.
+	// Sealed tenders cannot be created while it is empty.
+	Key string `yaml:"key" toml:"key" env:"SEALED_BIDS_KEY"`
+	// RevealInterval is how often the bids of sealed tenders past their deadline are revealed.
+	RevealInterval time.Duration `yaml:"reveal_interval" toml:"reveal_interval" env:"SEALED_BIDS_REVEAL_INTERVAL"`
+}
+
+type NotificationsConfig struct {
+	// DispatchInterval is how often pending events are delivered to the inboxes.
+	DispatchInterval time.Duration `yaml:"dispatch_interval" toml:"dispatch_interval" env:"NOTIFICATIONS_DISPATCH_INTERVAL"`
+}
+
+type MailConfig struct {
+	// Driver is smtp, file or stdout; no email is sent while it is empty. file and stdout
+	// write the messages instead of sending them, for local testing.
+	Driver string `yaml:"driver" toml:"driver" env:"MAIL_DRIVER"`
+	// From is the sender address of every message.
+	From         string `yaml:"from" toml:"from" env:"MAIL_FROM"`
+	SMTPHost     string `yaml:"smtp_host" toml:"smtp_host" env:"MAIL_SMTP_HOST"`
+	SMTPPort     string `yaml:"smtp_port" toml:"smtp_port" env:"MAIL_SMTP_PORT"`
+	SMTPUsername string `yaml:"smtp_username" toml:"smtp_username" env:"MAIL_SMTP_USERNAME"`
+	SMTPPassword string `yaml:"smtp_password" toml:"smtp_password" env:"MAIL_SMTP_PASSWORD"`
+	// File is where the file driver appends the messages.
+	File string `yaml:"file" toml:"file" env:"MAIL_FILE"`
+	// SendInterval is how often the queued messages are sent.
+	SendInterval time.Duration `yaml:"send_interval" toml:"send_interval" env:"MAIL_SEND_INTERVAL"`
+	// Timeout limits the delivery of one message.
+	Timeout time.Duration `yaml:"timeout" toml:"timeout" env:"MAIL_TIMEOUT"`
+	// MaxAttempts is how many times a message is tried before it is given up.
+	MaxAttempts int `yaml:"max_attempts" toml:"max_attempts" env:"MAIL_MAX_ATTEMPTS"`
+}
+
+type IdempotencyConfig struct {
+	// TTL is how long a stored response is replayed for its Idempotency-Key.
+	TTL time.Duration `yaml:"ttl" toml:"ttl" env:"IDEMPOTENCY_TTL"`
+	// CleanupInterval is how often expired keys are deleted.
+	CleanupInterval time.Duration `yaml:"cleanup_interval" toml:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL"`
+}
+
+type FeatureFlags struct {
+	// AutoMigrate applies pending schema migrations on startup.
+	AutoMigrate bool `yaml:"auto_migrate" toml:"auto_migrate" env:"FEATURE_AUTO_MIGRATE"`
+	// InitDatabase creates the tender schema and seed data on startup.
+	InitDatabase bool `yaml:"init_database" toml:"init_database" env:"FEATURE_INIT_DATABASE"`
+}
+
+// DefaultConfig returns the configuration used when nothing else is provided.
+func DefaultConfig() Config {
+	return Config{
+		Server: ServerConfig{
+			Address:         "0.0.0.0:8080",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+			DrainDelay:      5 * time.Second,
+		},
+		Postgres: PostgresConfig{
+			Port:         "5432",
+			MaxPoolSize:  1,
+			ConnAttempts: 10,
+			ConnTimeout:  time.Second,
+		},
+		Log: LogConfig{
+			Level:  "debug",
+			Format: "text",
+		},
+		I18n: I18nConfig{
+			DefaultLocale: string(LocaleRU),
+		},
+		Validation: ValidationConfig{
+			Requests: true,
+		},
+		Attachments: AttachmentsConfig{
+			Dir:     "data/attachments",
+			MaxSize: 20 << 20,
+		},
+		SealedBids: SealedBidsConfig{
+			RevealInterval: 30 * time.Second,
+		},
+		Notifications: NotificationsConfig{
+			DispatchInterval: 5 * time.Second,
+		},
+		Mail: MailConfig{
+			From:         "tenders@localhost",
+			SMTPPort:     "587",
+			File:         "data/mail.log",
+			SendInterval: 5 * time.Second,
+			Timeout:      10 * time.Second,
+			MaxAttempts:  8,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             24 * time.Hour,
+			CleanupInterval: 10 * time.Minute,
+		},
+		Features: FeatureFlags{
+			AutoMigrate: true,
+		},
+	}
 }
 
-func MustLoad() *Config {
-	err := godotenv.Load(".env")
+// Load assembles the configuration and validates it. path may be empty, in which case
+// only defaults, .env and the environment are used. A missing .env file is not an error.
+func Load(path string) (*Config, error) {
+	cfg := DefaultConfig()
+
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := godotenv.Load(".env"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+
+	if err := applyEnv(reflect.ValueOf(&cfg).Elem()); err != nil {
+		return nil, err
+	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// MustLoad is Load that terminates the process on error.
+func MustLoad(path string) *Config {
+	cfg, err := Load(path)
+	if err != nil {
+		log.Fatalf("Error loading config: %s", err)
+	}
+	return cfg
+}
+
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		log.Fatalf("Error loading .env file: %s", err)
-	}
-	return &Config{
-		ServerAddress:    os.Getenv("SERVER_ADDRESS"),
-		PostgresConn:     os.Getenv("POSTGRES_CONN"),
-		PostgresJdbcUrl:  os.Getenv("POSTGRES_JDBC_URL"),
-		PostgresUsername: os.Getenv("POSTGRES_USERNAME"),
-		PostgresPassword: os.Getenv("POSTGRES_PASSWORD"),
-		PostgresHost:     os.Getenv("POSTGRES_HOST"),
-		PostgresPort:     os.Getenv("POSTGRES_PORT"),
-		PostgresDatabase: os.Getenv("POSTGRES_DATABASE=tender-service\n"),
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	case ".toml":
+		err = toml.Unmarshal(data, cfg)
+	default:
+		return fmt.Errorf("config file %s: unsupported format, expected .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// applyEnv walks the config struct and overrides every field tagged with `env`
+// whose variable is set in the environment.
+func applyEnv(v reflect.Value) error {
+	var errs []error
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := v.Type().Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		name := fieldType.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := setField(field, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported config field type %s", field.Type())
+	}
+
+	return nil
+}
+
+// Validate checks the whole configuration and reports every problem at once.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if _, _, err := net.SplitHostPort(c.Server.Address); err != nil {
+		add("server.address: %v", err)
+	}
+	if c.Server.ReadTimeout <= 0 {
+		add("server.read_timeout: must be positive")
+	}
+	if c.Server.WriteTimeout <= 0 {
+		add("server.write_timeout: must be positive")
+	}
+	if c.Server.IdleTimeout <= 0 {
+		add("server.idle_timeout: must be positive")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		add("server.shutdown_timeout: must be positive")
+	}
+	if c.Server.DrainDelay < 0 {
+		add("server.drain_delay: must not be negative")
+	}
+
+	if c.Postgres.Conn == "" {
+		if c.Postgres.Host == "" || c.Postgres.Database == "" || c.Postgres.Username == "" {
+			add("postgres: either conn or host, database and username must be set")
+		}
+	} else if _, err := url.Parse(c.Postgres.Conn); err != nil {
+		add("postgres.conn: %v", err)
+	}
+	if c.Postgres.Port != "" {
+		if port, err := strconv.Atoi(c.Postgres.Port); err != nil || port < 1 || port > 65535 {
+			add("postgres.port: %q is not a valid port", c.Postgres.Port)
+		}
+	}
+	if c.Postgres.MaxPoolSize < 1 {
+		add("postgres.max_pool_size: must be at least 1, got %d", c.Postgres.MaxPoolSize)
+	}
+	if c.Postgres.ConnAttempts < 1 {
+		add("postgres.conn_attempts: must be at least 1, got %d", c.Postgres.ConnAttempts)
+	}
+	if c.Postgres.ConnTimeout <= 0 {
+		add("postgres.conn_timeout: must be positive")
+	}
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		add("log.level: %q must be one of debug, info, warn, error", c.Log.Level)
+	}
+	switch c.Log.Format {
+	case "text", "json":
+	default:
+		add("log.format: %q must be one of text, json", c.Log.Format)
+	}
+
+	if _, err := ParseLocale(c.I18n.DefaultLocale); err != nil {
+		add("i18n.default_locale: %v", err)
+	}
+
+	if c.Attachments.Dir == "" {
+		add("attachments.dir: must be set")
+	}
+	if c.Attachments.MaxSize < 1 {
+		add("attachments.max_size: must be positive, got %d", c.Attachments.MaxSize)
+	}
+
+	if c.SealedBids.Key != "" {
+		if _, err := NewSealer(c.SealedBids.Key); err != nil {
+			add("sealed_bids.key: %v", err)
+		}
+	}
+	if c.SealedBids.RevealInterval <= 0 {
+		add("sealed_bids.reveal_interval: must be positive")
+	}
+	if c.Notifications.DispatchInterval <= 0 {
+		add("notifications.dispatch_interval: must be positive")
+	}
+
+	switch c.Mail.Driver {
+	case "", mailDriverStdout:
+	case mailDriverSMTP:
+		if c.Mail.SMTPHost == "" {
+			add("mail.smtp_host: must be set for the smtp driver")
+		}
+	case mailDriverFile:
+		if c.Mail.File == "" {
+			add("mail.file: must be set for the file driver")
+		}
+	default:
+		add("mail.driver: %q must be one of smtp, file, stdout or empty", c.Mail.Driver)
+	}
+	if c.Mail.Driver != "" {
+		if _, err := mail.ParseAddress(c.Mail.From); err != nil {
+			add("mail.from: %v", err)
+		}
+	}
+	if c.Mail.SendInterval <= 0 {
+		add("mail.send_interval: must be positive")
+	}
+	if c.Mail.Timeout <= 0 {
+		add("mail.timeout: must be positive")
+	}
+	if c.Mail.MaxAttempts < 1 {
+		add("mail.max_attempts: must be at least 1, got %d", c.Mail.MaxAttempts)
+	}
+	if c.Idempotency.TTL <= 0 {
+		add("idempotency.ttl: must be positive")
+	}
+	if c.Idempotency.CleanupInterval <= 0 {
+		add("idempotency.cleanup_interval: must be positive")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// ConnString returns POSTGRES_CONN or, if it is not set, a URL built from the separate fields.
+func (p PostgresConfig) ConnString() string {
+	if p.Conn != "" {
+		return p.Conn
+	}
+
+	host := p.Host
+	if p.Port != "" {
+		host = net.JoinHostPort(p.Host, p.Port)
+	}
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(p.Username, p.Password),
+		Host:   host,
+		Path:   "/" + p.Database,
+	}
+	return u.String()
+}
+
+// Redacted returns a copy of the config that is safe to print or log.
+func (c Config) Redacted() Config {
+	if c.Postgres.Password != "" {
+		c.Postgres.Password = redactedValue
+	}
+	c.Postgres.Conn = redactURL(c.Postgres.Conn)
+	c.Postgres.JdbcUrl = redactJdbcURL(c.Postgres.JdbcUrl)
+	if c.SealedBids.Key != "" {
+		c.SealedBids.Key = redactedValue
+	}
+	if c.Mail.SMTPPassword != "" {
+		c.Mail.SMTPPassword = redactedValue
+	}
+	return c
+}
+
+// redactURL hides the password of a connection URL, whether in the user info or in the
+// password parameter. A value that does not parse is hidden altogether.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redactedValue
+	}
+	if q := u.Query(); q.Has("password") {
+		// The same mask as url.Redacted, which survives query encoding.
+		q.Set("password", "xxxxx")
+		u.RawQuery = q.Encode()
+	}
+	return u.Redacted()
+}
+
+// redactJdbcURL is redactURL for a JDBC URL such as jdbc:postgresql://host/db?password=secret.
+func redactJdbcURL(raw string) string {
+	if rest, ok := strings.CutPrefix(raw, "jdbc:"); ok {
+		return "jdbc:" + redactURL(rest)
+	}
+	return redactURL(raw)
+}
+
+// MarshalYAML writes the config the way it is read: durations as strings such as "5s", so that
+// the output of -print-config can be used as a config file.
+func (c Config) MarshalYAML() (any, error) {
+	return configNode(reflect.ValueOf(c))
+}
+
+func configNode(v reflect.Value) (*yaml.Node, error) {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: time.Duration(v.Int()).String()}, nil
+	}
+	if v.Kind() != reflect.Struct {
+		var node yaml.Node
+		err := node.Encode(v.Interface())
+		return &node, err
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		value, err := configNode(v.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
 	}
+	return node, nil
 }
@@ -0,0 +1,218 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configEnv lists the variables the tests set, so that none leaks in from the environment
+// and none set by a .env file outlives its test.
+var configEnv = []string{"SERVER_ADDRESS", "LOG_LEVEL", "LOG_FORMAT", "POSTGRES_CONN", "POSTGRES_PASSWORD",
+	"POSTGRES_MAX_POOL_SIZE", "SERVER_READ_TIMEOUT", "NOTIFICATIONS_DISPATCH_INTERVAL", "MAIL_SMTP_PASSWORD", "SEALED_BIDS_KEY"}
+
+// loadConfigIn runs Load in a fresh directory holding the given files, with configEnv cleared
+// and env set.
+func loadConfigIn(t *testing.T, files map[string]string, configFile string, env map[string]string) (*Config, error) {
+	t.Helper()
+	for _, name := range configEnv {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	path := ""
+	if configFile != "" {
+		path = filepath.Join(dir, configFile)
+	}
+	return Load(path)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": `
+server:
+  address: ":9000"
+log:
+  level: debug
+  format: json
+postgres:
+  conn: postgres://file/tenders
+notifications:
+  dispatch_interval: 7s
+`,
+		".env": "LOG_LEVEL=warn\nPOSTGRES_CONN=postgres://dotenv/tenders\n",
+	}
+	cfg, err := loadConfigIn(t, files, "config.yaml", map[string]string{"POSTGRES_CONN": "postgres://env/tenders"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := DefaultConfig()
+	cases := []struct {
+		name      string
+		got, want any
+	}{
+		{"default", cfg.Server.ReadTimeout, defaults.Server.ReadTimeout},
+		{"file over default", cfg.Server.Address, ":9000"},
+		{"file duration", cfg.Notifications.DispatchInterval, 7 * time.Second},
+		{"file not in .env", cfg.Log.Format, "json"},
+		{".env over file", cfg.Log.Level, "warn"},
+		{"environment over .env", cfg.Postgres.Conn, "postgres://env/tenders"},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestLoadFileFormats(t *testing.T) {
+	cases := []struct {
+		name, file, content string
+	}{
+		{"yaml", "config.yaml", "server:\n  address: \":9001\"\n  read_timeout: 3s\npostgres:\n  conn: postgres://file/tenders\n  max_pool_size: 4\n"},
+		{"yml", "config.yml", "server:\n  address: \":9001\"\n  read_timeout: 3s\npostgres:\n  conn: postgres://file/tenders\n  max_pool_size: 4\n"},
+		{"toml", "config.toml", "[server]\naddress = \":9001\"\nread_timeout = \"3s\"\n[postgres]\nconn = \"postgres://file/tenders\"\nmax_pool_size = 4\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := loadConfigIn(t, map[string]string{tc.file: tc.content}, tc.file, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Server.Address != ":9001" || cfg.Server.ReadTimeout != 3*time.Second || cfg.Postgres.MaxPoolSize != 4 {
+				t.Errorf("got %+v %+v", cfg.Server, cfg.Postgres)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		files      map[string]string
+		configFile string
+		env        map[string]string
+		want       []string
+	}{
+		{
+			name:       "unsupported file format",
+			files:      map[string]string{"config.json": "{}"},
+			configFile: "config.json",
+			want:       []string{"unsupported format"},
+		},
+		{
+			name:       "malformed yaml",
+			files:      map[string]string{"config.yaml": "server: [\n"},
+			configFile: "config.yaml",
+			want:       []string{"parse config file"},
+		},
+		{
+			name:       "missing file",
+			configFile: "config.yaml",
+			want:       []string{"read config file"},
+		},
+		{
+			name: "unparsable variables",
+			env: map[string]string{"POSTGRES_CONN": "postgres://env/tenders",
+				"SERVER_READ_TIMEOUT": "soon", "POSTGRES_MAX_POOL_SIZE": "many"},
+			want: []string{"SERVER_READ_TIMEOUT", "POSTGRES_MAX_POOL_SIZE"},
+		},
+		{
+			name: "every invalid value is reported",
+			env: map[string]string{"SERVER_ADDRESS": "nowhere", "LOG_LEVEL": "loud",
+				"NOTIFICATIONS_DISPATCH_INTERVAL": "-1s"},
+			want: []string{"invalid configuration", "server.address", "log.level", "notifications.dispatch_interval", "postgres"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadConfigIn(t, tc.files, tc.configFile, tc.env)
+			if err == nil {
+				t.Fatal("Load succeeded")
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigRedacted(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Postgres.Conn = "postgres://tenders:conn-secret@db:5432/tenders"
+	cfg.Postgres.JdbcUrl = "jdbc:postgresql://db:5432/tenders?user=tenders&password=jdbc-secret"
+	cfg.Postgres.Password = "password-secret"
+	cfg.SealedBids.Key = "key-secret"
+	cfg.Mail.SMTPPassword = "smtp-secret"
+
+	redacted := cfg.Redacted()
+	printed := strings.Join([]string{redacted.Postgres.Conn, redacted.Postgres.JdbcUrl, redacted.Postgres.Password,
+		redacted.SealedBids.Key, redacted.Mail.SMTPPassword}, " ")
+	for _, secret := range []string{"conn-secret", "jdbc-secret", "password-secret", "key-secret", "smtp-secret"} {
+		if strings.Contains(printed, secret) {
+			t.Errorf("%s is not redacted: %s", secret, printed)
+		}
+	}
+	if redacted.Postgres.Conn != "postgres://tenders:xxxxx@db:5432/tenders" {
+		t.Errorf("conn = %q, want everything but the password", redacted.Postgres.Conn)
+	}
+	if redacted.Postgres.JdbcUrl != "jdbc:postgresql://db:5432/tenders?password=xxxxx&user=tenders" {
+		t.Errorf("jdbc url = %q, want everything but the password", redacted.Postgres.JdbcUrl)
+	}
+	if cfg.Postgres.Password != "password-secret" {
+		t.Error("Redacted changed the original config")
+	}
+
+	empty := DefaultConfig().Redacted()
+	if empty.Postgres.Password != "" || empty.SealedBids.Key != "" {
+		t.Error("unset secrets must stay empty")
+	}
+}
+
+func TestPrintedConfigLoads(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Postgres.Conn = "postgres://tenders@db:5432/tenders"
+	cfg.Server.DrainDelay = 1500 * time.Millisecond
+	cfg.Idempotency.TTL = 36 * time.Hour
+
+	out, err := yaml.Marshal(cfg.Redacted())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "drain_delay: 1.5s") {
+		t.Errorf("durations are not printed as strings:\n%s", out)
+	}
+
+	loaded, err := loadConfigIn(t, map[string]string{"config.yaml": string(out)}, "config.yaml", nil)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	if *loaded != cfg {
+		t.Errorf("got %+v, want %+v", *loaded, cfg)
+	}
+}
@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// conflictFinding is a conflict of interest found on a bid or a decision.
+type conflictFinding struct {
+	Kind         ConflictKind
+	UserId       *uuid.UUID
+	Username     string
+	RelatedBidId *uuid.UUID
+}
+
+// detail describes the finding with a message from the catalog.
+func (f conflictFinding) detail() *LocalizedError {
+	switch f.Kind {
+	case CONFLICT_AUTHOR_RESPONSIBLE:
+		return NewLocalizedError(MsgConflictAuthorResponsible, f.Username)
+	case CONFLICT_SAME_ORGANIZATION:
+		related := ""
+		if f.RelatedBidId != nil {
+			related = f.RelatedBidId.String()
+		}
+		return NewLocalizedError(MsgConflictSameOrganization, related)
+	default:
+		return NewLocalizedError(MsgConflictSelfEvaluation, f.Username)
+	}
+}
+
+// conflictError is what a Block policy answers with for the first finding.
+func conflictError(findings []conflictFinding) *APIError {
+	apiErr := NewAPIError(ErrCodeConflictOfInterest, nil)
+	apiErr.Detail = findings[0].detail()
+	return apiErr
+}
+
+// bidConflictFacts is what the conflict checks of a new bid learn from the database.
+type bidConflictFacts struct {
+	// Responsible is a responsible of the tender's organization on the bidding side, if any.
+	Responsible *User
+	// Creator is the username of the tender's creator.
+	Creator string
+	// RelatedBidId is an earlier bid from the author or an organization the author belongs to.
+	RelatedBidId *uuid.UUID
+}
+
+// findings tells the conflicts in the facts apart from the ordinary bidders of the tender. On a
+// public tender checkBidder admits only the tender's organization and its responsibles, so a
+// responsible bidding is a conflict only if they created the tender, and only when bidding in
+// person. A private tender is bid on by invited outsiders, so any responsible on the bidding
+// side is a conflict.
+func (f bidConflictFacts) findings(tender *Tender, authorType BidAuthorType) []conflictFinding {
+	var findings []conflictFinding
+	if r := f.Responsible; r != nil && (tender.Private || (authorType == USER && r.Username == f.Creator)) {
+		findings = append(findings, conflictFinding{Kind: CONFLICT_AUTHOR_RESPONSIBLE, UserId: &r.Id, Username: r.Username})
+	}
+	if f.RelatedBidId != nil {
+		findings = append(findings, conflictFinding{Kind: CONFLICT_SAME_ORGANIZATION, RelatedBidId: f.RelatedBidId})
+	}
+	return findings
+}
+
+// loadBidConflictFacts collects the facts for the conflict checks of a new bid by the author. An
+// employee belongs to the organizations they are responsible for; on a public tender the
+// tender's own organization, which every bidder belongs to, does not make two bids related. The
+// caller holds the tender lock, so that two bids of one organization cannot both miss each other.
+func loadBidConflictFacts(ctx context.Context, tx pgx.Tx, tender *Tender, authorType BidAuthorType, authorId uuid.UUID) (*bidConflictFacts, error) {
+	tenderId, _ := uuid.Parse(tender.Id)
+	organizationId, _ := uuid.Parse(tender.OrganizationId)
+	var facts bidConflictFacts
+
+	var responsible User
+	err := tx.QueryRow(ctx, `
+	SELECT e.id, e.username FROM organization_responsible t JOIN employee e ON e.id = t.user_id
+	WHERE t.organization_id = $1 AND CASE WHEN $3::text = 'User' THEN t.user_id = $2
+		ELSE t.user_id IN (SELECT user_id FROM organization_responsible WHERE organization_id = $2) END
+	ORDER BY e.username LIMIT 1`, organizationId, authorId, string(authorType)).Scan(&responsible.Id, &responsible.Username)
+	switch {
+	case err == nil:
+		facts.Responsible = &responsible
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, err
+	}
+
+	if err := tx.QueryRow(ctx, `SELECT creator_username FROM tenders WHERE id = $1`, tenderId).Scan(&facts.Creator); err != nil {
+		return nil, err
+	}
+
+	var unrelatedOrganization *uuid.UUID
+	if !tender.Private {
+		unrelatedOrganization = &organizationId
+	}
+	var relatedBidId uuid.UUID
+	err = tx.QueryRow(ctx, `
+	WITH author_organizations AS (
+		SELECT organization_id FROM (
+			SELECT $2::uuid AS organization_id WHERE $3::text = 'Organization'
+			UNION SELECT organization_id FROM organization_responsible WHERE user_id = $2 AND $3::text = 'User'
+		) o WHERE organization_id IS DISTINCT FROM $5
+	)
+	SELECT b.bid_id FROM bids b
+	WHERE b.tender_id = $1 AND b.status <> $4 AND (
+		(b.author_type = $3 AND b.author_id = $2)
+		OR (b.author_type = 'Organization' AND b.author_id IN (SELECT organization_id FROM author_organizations))
+		OR (b.author_type = 'User' AND b.author_id IN (SELECT user_id FROM organization_responsible
+			WHERE organization_id IN (SELECT organization_id FROM author_organizations))))
+	ORDER BY b.created_at LIMIT 1`, tenderId, authorId, string(authorType), string(CANCELED_BID), unrelatedOrganization).Scan(&relatedBidId)
+	switch {
+	case err == nil:
+		facts.RelatedBidId = &relatedBidId
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, err
+	}
+	return &facts, nil
+}
+
+// checkBidConflicts runs the conflict checks of a new bid under the policy of the tender. It
+// returns the findings to flag, or the error of a Block policy.
+func checkBidConflicts(ctx context.Context, tx pgx.Tx, tender *Tender, authorType BidAuthorType, authorId uuid.UUID) ([]conflictFinding, *APIError) {
+	if tender.ConflictPolicy == "" || tender.ConflictPolicy == CONFLICT_POLICY_OFF {
+		return nil, nil
+	}
+	facts, err := loadBidConflictFacts(ctx, tx, tender, authorType, authorId)
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return screenConflicts(tender.ConflictPolicy, facts.findings(tender, authorType))
+}
+
+// decisionConflicts finds a decision on a bid made by its author or by a responsible of the
+// organization that wrote it. On a public tender a bid of the tender's own organization is
+// decided by its responsibles as a matter of course, so that is not a conflict.
+func (s *DefaultAPIService) decisionConflicts(ctx context.Context, tender *Tender, bid *Bid, decider *User) ([]conflictFinding, error) {
+	if !tender.Private && bid.AuthorType == ORGANIZATION && bid.AuthorId == tender.OrganizationId {
+		return nil, nil
+	}
+	if err := s.checkBidAuthor(ctx, decider, bid); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code == ErrCodeForbiddenNotAuthor {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []conflictFinding{{Kind: CONFLICT_SELF_EVALUATION, UserId: &decider.Id, Username: decider.Username}}, nil
+}
+
+// screenConflicts applies the policy of the tender to the findings: Block turns the first one
+// into an error, Flag keeps them for recordConflicts, Off drops them.
+func screenConflicts(policy ConflictPolicy, findings []conflictFinding) ([]conflictFinding, *APIError) {
+	if len(findings) == 0 {
+		return nil, nil
+	}
+	switch policy {
+	case CONFLICT_POLICY_BLOCK:
+		return nil, conflictError(findings)
+	case CONFLICT_POLICY_FLAG:
+		return findings, nil
+	default:
+		return nil, nil
+	}
+}
+
+// recordConflicts saves the flagged findings of a bid in the transaction that saves the bid
+// or the decision.
+func recordConflicts(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID, bidId uuid.UUID, findings []conflictFinding) error {
+	for _, f := range findings {
+		if _, err := tx.Exec(ctx, `
+		INSERT INTO tender_conflicts (id, tender_id, bid_id, kind, user_id, related_bid_id) VALUES ($1, $2, $3, $4, $5, $6)`,
+			uuid.New(), tenderId, bidId, string(f.Kind), f.UserId, f.RelatedBidId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conflictColumns are the columns read by scanConflict from tender_conflicts aliased c.
+const conflictColumns = `c.id, c.tender_id, c.bid_id, c.kind, c.user_id,
+	COALESCE((SELECT username FROM employee WHERE id = c.user_id), ''), c.related_bid_id, c.created_at`
+
+func scanConflict(row pgx.Row, locale Locale) (TenderConflict, error) {
+	var conflict TenderConflict
+	var id, tenderId, bidId uuid.UUID
+	var f conflictFinding
+	var createdAt time.Time
+
+	err := row.Scan(&id, &tenderId, &bidId, &f.Kind, &f.UserId, &f.Username, &f.RelatedBidId, &createdAt)
+	if err != nil {
+		return TenderConflict{}, err
+	}
+
+	conflict.Id = id.String()
+	conflict.TenderId = tenderId.String()
+	conflict.BidId = bidId.String()
+	conflict.Kind = f.Kind
+	conflict.Username = f.Username
+	if f.RelatedBidId != nil {
+		conflict.RelatedBidId = f.RelatedBidId.String()
+	}
+	conflict.Detail = f.detail().Localize(locale)
+	conflict.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	return conflict, nil
+}
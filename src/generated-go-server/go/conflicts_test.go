@@ -0,0 +1,140 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestScreenConflicts(t *testing.T) {
+	related := uuid.MustParse(importOrgID)
+	findings := []conflictFinding{
+		{Kind: CONFLICT_SAME_ORGANIZATION, RelatedBidId: &related},
+		{Kind: CONFLICT_AUTHOR_RESPONSIBLE, Username: "user1"},
+	}
+
+	for _, policy := range []ConflictPolicy{"", CONFLICT_POLICY_OFF} {
+		if flagged, err := screenConflicts(policy, findings); flagged != nil || err != nil {
+			t.Errorf("%q: got %v, %v, want nothing", policy, flagged, err)
+		}
+	}
+	if flagged, err := screenConflicts(CONFLICT_POLICY_FLAG, findings); len(flagged) != 2 || err != nil {
+		t.Errorf("Flag: got %v, %v, want both findings", flagged, err)
+	}
+	_, err := screenConflicts(CONFLICT_POLICY_BLOCK, findings)
+	if err == nil || err.Code != ErrCodeConflictOfInterest || err.Status() != http.StatusConflict {
+		t.Fatalf("Block: got %v", err)
+	}
+	if got := err.Detail.Localize(LocaleEN); got != "the same organization has already submitted bid "+importOrgID {
+		t.Errorf("Block detail = %q", got)
+	}
+	if flagged, err := screenConflicts(CONFLICT_POLICY_BLOCK, nil); flagged != nil || err != nil {
+		t.Errorf("Block without findings: got %v, %v", flagged, err)
+	}
+
+	self := conflictFinding{Kind: CONFLICT_SELF_EVALUATION, Username: "user2"}
+	if got := self.detail().Localize(LocaleRU); !strings.HasPrefix(got, "user2 ") {
+		t.Errorf("self evaluation detail = %q", got)
+	}
+}
+
+func TestBidConflictFindings(t *testing.T) {
+	related := uuid.MustParse(importOrgID)
+	responsible := &User{Id: uuid.New(), Username: "user1"}
+	public := &Tender{OrganizationId: importOrgID}
+	private := &Tender{OrganizationId: importOrgID, Private: true}
+
+	cases := []struct {
+		name       string
+		tender     *Tender
+		authorType BidAuthorType
+		facts      bidConflictFacts
+		want       []ConflictKind
+	}{
+		{"public tender, responsible", public, USER, bidConflictFacts{Responsible: responsible, Creator: "user2"}, nil},
+		{"public tender, its organization", public, ORGANIZATION, bidConflictFacts{Responsible: responsible, Creator: "user1"}, nil},
+		{"public tender, its creator", public, USER, bidConflictFacts{Responsible: responsible, Creator: "user1"},
+			[]ConflictKind{CONFLICT_AUTHOR_RESPONSIBLE}},
+		{"private tender, responsible", private, ORGANIZATION, bidConflictFacts{Responsible: responsible, Creator: "user2"},
+			[]ConflictKind{CONFLICT_AUTHOR_RESPONSIBLE}},
+		{"private tender, outsider", private, USER, bidConflictFacts{Creator: "user2"}, nil},
+		{"earlier bid", public, USER, bidConflictFacts{Responsible: responsible, Creator: "user2", RelatedBidId: &related},
+			[]ConflictKind{CONFLICT_SAME_ORGANIZATION}},
+	}
+	for _, tc := range cases {
+		findings := tc.facts.findings(tc.tender, tc.authorType)
+		var got []ConflictKind
+		for _, f := range findings {
+			got = append(got, f.Kind)
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+			}
+		}
+		if flagged, err := screenConflicts(CONFLICT_POLICY_BLOCK, findings); len(tc.want) == 0 && (flagged != nil || err != nil) {
+			t.Errorf("%s: Block rejected an ordinary bid: %v", tc.name, err)
+		}
+	}
+}
+
+func TestTenderConflictPolicyValidation(t *testing.T) {
+	router := NewRouter(NewConflictAPIController(nil))
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{}`, http.StatusUnprocessableEntity},
+		{`{"policy":"Warn"}`, http.StatusBadRequest},
+		{`{"policy":"Block","notify":true}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/tenders/"+importOrgID+"/conflicts/policy?username=user1", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
+
+// columnsRow is a row of a query selecting columns; scanning it into any other number of
+// destinations fails like pgx does.
+type columnsRow struct{ columns []string }
+
+func (r columnsRow) Scan(dest ...any) error {
+	// A column expression may select several columns, e.g. tenderSealingColumns.
+	n := 0
+	for _, c := range r.columns {
+		depth := 0
+		n++
+		for _, ch := range c {
+			switch {
+			case ch == '(':
+				depth++
+			case ch == ')':
+				depth--
+			case ch == ',' && depth == 0:
+				n++
+			}
+		}
+	}
+	if len(dest) != n {
+		return fmt.Errorf("number of field descriptions must equal number of destinations, got %d and %d", n, len(dest))
+	}
+	return nil
+}
+
+func TestScanUserTender(t *testing.T) {
+	if _, err := scanUserTender(columnsRow{userTenderColumns}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,239 @@
+package openapi
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// emailBatchSize is how many queued messages one run of the sender handles.
+	emailBatchSize = 50
+	// emailFirstRetry is the delay after the first failure; it doubles up to emailMaxRetry.
+	emailFirstRetry = 30 * time.Second
+	emailMaxRetry   = time.Hour
+	// emailOptedOut is the error recorded on queued messages cancelled by an opt-out.
+	emailOptedOut = "recipient opted out"
+)
+
+//go:embed mail_templates/*.tmpl
+var mailTemplateFiles embed.FS
+
+// mailTemplates hold, per locale, a subject and a body template named after each event type,
+// for example "bid.created.subject" and "bid.created.body".
+var mailTemplates = map[Locale]*template.Template{
+	LocaleRU: template.Must(template.ParseFS(mailTemplateFiles, "mail_templates/ru.tmpl")),
+	LocaleEN: template.Must(template.ParseFS(mailTemplateFiles, "mail_templates/en.tmpl")),
+}
+
+// emailData is what the mail templates are rendered with.
+type emailData struct {
+	Name       string
+	TenderName string
+	TenderId   string
+	BidId      string
+}
+
+// renderEmail renders the subject and body of an event in locale. ok is false for events
+// that have no email.
+func renderEmail(locale Locale, eventType NotificationEventType, data emailData) (subject string, body string, ok bool, err error) {
+	templates, found := mailTemplates[locale]
+	if !found {
+		templates = mailTemplates[LocaleRU]
+	}
+	if templates.Lookup(string(eventType)+".subject") == nil {
+		return "", "", false, nil
+	}
+
+	var b strings.Builder
+	if err := templates.ExecuteTemplate(&b, string(eventType)+".subject", data); err != nil {
+		return "", "", false, err
+	}
+	subject = strings.TrimSpace(b.String())
+	b.Reset()
+	if err := templates.ExecuteTemplate(&b, string(eventType)+".body", data); err != nil {
+		return "", "", false, err
+	}
+	return subject, strings.TrimLeft(b.String(), "\n"), true, nil
+}
+
+// notifiedEmployee is a notification just written by the dispatcher.
+type notifiedEmployee struct {
+	NotificationId uuid.UUID
+	EmployeeId     uuid.UUID
+}
+
+// enqueueEmails queues a message for every notified employee with email turned on, in the
+// transaction that writes the notifications. The sender delivers them later.
+func enqueueEmails(ctx context.Context, tx pgx.Tx, event outboxEvent, notified []notifiedEmployee) (int, error) {
+	if mailTemplates[LocaleRU].Lookup(string(event.Type)+".subject") == nil || len(notified) == 0 {
+		return 0, nil
+	}
+	notifications := make(map[uuid.UUID]uuid.UUID, len(notified))
+	employeeIds := make([]uuid.UUID, 0, len(notified))
+	for _, n := range notified {
+		notifications[n.EmployeeId] = n.NotificationId
+		employeeIds = append(employeeIds, n.EmployeeId)
+	}
+
+	data := emailData{}
+	if tenderId := event.uuid("tenderId"); tenderId != nil {
+		data.TenderId = tenderId.String()
+		err := tx.QueryRow(ctx, `SELECT name FROM tenders WHERE id = $1`, *tenderId).Scan(&data.TenderName)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return 0, err
+		}
+	}
+	if bidId := event.uuid("bidId"); bidId != nil {
+		data.BidId = bidId.String()
+	}
+
+	rows, err := tx.Query(ctx, `
+	SELECT s.employee_id, s.email, s.locale, COALESCE(NULLIF(e.first_name, ''), e.username)
+	FROM notification_email_settings s JOIN employee e ON e.id = s.employee_id
+	WHERE s.employee_id = ANY($1) AND s.enabled`, employeeIds)
+	if err != nil {
+		return 0, err
+	}
+	type recipient struct {
+		EmployeeId uuid.UUID
+		Email      string
+		Locale     Locale
+		Name       string
+	}
+	recipients, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (recipient, error) {
+		var r recipient
+		err := row.Scan(&r.EmployeeId, &r.Email, &r.Locale, &r.Name)
+		return r, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range recipients {
+		data.Name = r.Name
+		subject, body, _, err := renderEmail(r.Locale, event.Type, data)
+		if err != nil {
+			return 0, fmt.Errorf("render %s: %w", event.Type, err)
+		}
+		if _, err := tx.Exec(ctx, `
+		INSERT INTO email_outbox (notification_id, employee_id, recipient, subject, body) VALUES ($1, $2, $3, $4, $5)`,
+			notifications[r.EmployeeId], r.EmployeeId, r.Email, subject, body); err != nil {
+			return 0, err
+		}
+	}
+	return len(recipients), nil
+}
+
+// emailRetryDelay is how long to wait before another try after the given number of attempts.
+func emailRetryDelay(attempts int) time.Duration {
+	delay := emailFirstRetry
+	for i := 1; i < attempts && delay < emailMaxRetry; i++ {
+		delay *= 2
+	}
+	return min(delay, emailMaxRetry)
+}
+
+// queuedEmail is a message claimed from email_outbox by the sender.
+type queuedEmail struct {
+	Id       int64
+	Attempts int
+	Message  EmailMessage
+}
+
+// EmailSender delivers the queued messages through a Mailer. A message is claimed by pushing
+// its next attempt past the delivery timeout, so several instances never send it at once and
+// a message claimed by an instance that died is tried again. Failures are retried with
+// exponential backoff until MaxAttempts; an SMTP 5xx reply gives the message up at once.
+type EmailSender struct {
+	pg          *Postgres
+	mailer      Mailer
+	timeout     time.Duration
+	maxAttempts int
+	log         *slog.Logger
+}
+
+func NewEmailSender(pg *Postgres, mailer Mailer, cfg MailConfig, log *slog.Logger) *EmailSender {
+	return &EmailSender{
+		pg:          pg,
+		mailer:      mailer,
+		timeout:     cfg.Timeout,
+		maxAttempts: cfg.MaxAttempts,
+		log:         log.With(slog.String("op", "EmailSender")),
+	}
+}
+
+// Run is a WorkerFunc that sends the messages that are due.
+func (s *EmailSender) Run(ctx context.Context) error {
+	var errs []error
+	for range emailBatchSize {
+		email, err := s.claim(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := s.deliver(ctx, email); err != nil {
+			errs = append(errs, fmt.Errorf("email %d: %w", email.Id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// claim takes the oldest due message and counts the attempt.
+func (s *EmailSender) claim(ctx context.Context) (queuedEmail, error) {
+	var email queuedEmail
+	err := s.pg.Pool.QueryRow(ctx, `
+	UPDATE email_outbox SET attempts = attempts + 1, next_attempt_at = clock_timestamp() + $1 * INTERVAL '1 millisecond'
+	WHERE id = (
+		SELECT id FROM email_outbox
+		WHERE sent_at IS NULL AND failed_at IS NULL AND next_attempt_at <= clock_timestamp()
+		ORDER BY next_attempt_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED)
+	RETURNING id, attempts, recipient, subject, body`, (2*s.timeout).Milliseconds()).
+		Scan(&email.Id, &email.Attempts, &email.Message.To, &email.Message.Subject, &email.Message.Body)
+	return email, err
+}
+
+// deliver sends a claimed message and records the outcome.
+func (s *EmailSender) deliver(ctx context.Context, email queuedEmail) error {
+	sendCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	sendErr := s.mailer.Send(sendCtx, email.Message)
+	cancel()
+
+	if sendErr == nil {
+		_, err := s.pg.Pool.Exec(ctx, `
+		UPDATE email_outbox SET sent_at = clock_timestamp(), last_error = NULL WHERE id = $1`, email.Id)
+		return err
+	}
+	if ctx.Err() != nil {
+		// Shutting down: the claim expires and the message is tried again.
+		return nil
+	}
+
+	if email.Attempts >= s.maxAttempts || permanentMailError(sendErr) {
+		s.log.Error("email given up", slog.Int64("email_id", email.Id), slog.Int("attempts", email.Attempts), slog.Any("error", sendErr))
+		_, err := s.pg.Pool.Exec(ctx, `
+		UPDATE email_outbox SET failed_at = clock_timestamp(), last_error = $2 WHERE id = $1`, email.Id, sendErr.Error())
+		return err
+	}
+
+	delay := emailRetryDelay(email.Attempts)
+	s.log.Warn("email delivery failed, will retry", slog.Int64("email_id", email.Id), slog.Int("attempts", email.Attempts),
+		slog.Duration("retry_in", delay), slog.Any("error", sendErr))
+	_, err := s.pg.Pool.Exec(ctx, `
+	UPDATE email_outbox SET next_attempt_at = clock_timestamp() + $2 * INTERVAL '1 millisecond', last_error = $3 WHERE id = $1`,
+		email.Id, delay.Milliseconds(), sendErr.Error())
+	return err
+}
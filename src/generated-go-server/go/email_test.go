@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderEmail(t *testing.T) {
+	data := emailData{Name: "Иван", TenderName: "Поставка труб", TenderId: importOrgID, BidId: importOrgID}
+	for _, locale := range supportedLocales {
+		for _, eventType := range AllowedNotificationEventTypeEnumValues {
+			subject, body, ok, err := renderEmail(locale, eventType, data)
+			if err != nil || !ok {
+				t.Errorf("%s %s: ok = %v, err = %v", locale, eventType, ok, err)
+				continue
+			}
+			if subject == "" || strings.Contains(subject, "\n") {
+				t.Errorf("%s %s: subject = %q", locale, eventType, subject)
+			}
+			if !strings.Contains(body, data.TenderName) || !strings.Contains(body, data.TenderId) {
+				t.Errorf("%s %s: body misses the tender:\n%s", locale, eventType, body)
+			}
+			if strings.Contains(body, "<no value>") {
+				t.Errorf("%s %s: body has a missing field:\n%s", locale, eventType, body)
+			}
+		}
+	}
+
+	if _, _, ok, err := renderEmail(LocaleEN, "bid.updated", data); ok || err != nil {
+		t.Errorf("unknown event: ok = %v, err = %v", ok, err)
+	}
+}
+
+func TestFormatEmail(t *testing.T) {
+	msg := EmailMessage{To: "bob@example.com", Subject: "Новое предложение\r\nBcc: eve@example.com", Body: "Строка 1\nСтрока 2"}
+	raw := formatEmail("tenders@localhost", msg, time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+
+	r := textproto.NewReader(bufio.NewReader(bytes.NewReader(raw)))
+	header, err := r.ReadMIMEHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Get("Bcc") != "" {
+		t.Error("the subject injected a header")
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("subject = %q, %v", subject, err)
+	}
+	if header.Get("To") != msg.To || header.Get("Date") != "Fri, 02 Jan 2026 15:04:05 +0000" {
+		t.Errorf("header = %v", header)
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(r.R))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The body travels with CRLF line endings.
+	if got := strings.TrimRight(string(body), "\r\n"); got != "Строка 1\r\nСтрока 2" {
+		t.Errorf("body = %q", got)
+	}
+}
+
+func TestStreamMailer(t *testing.T) {
+	var buf bytes.Buffer
+	mailer := NewStreamMailer(&buf, "tenders@localhost")
+	for i := range 2 {
+		msg := EmailMessage{To: fmt.Sprintf("user%d@example.com", i), Subject: "Subject", Body: "Body"}
+		if err := mailer.Send(context.Background(), msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := strings.Count(buf.String(), "From: tenders@localhost\r\n"); got != 2 {
+		t.Errorf("wrote %d messages, want 2:\n%s", got, buf.String())
+	}
+}
+
+func TestEmailRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		8:  time.Hour,
+		50: time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := emailRetryDelay(attempts); got != want {
+			t.Errorf("emailRetryDelay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestPermanentMailError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&textproto.Error{Code: 550, Msg: "mailbox unavailable"}, true},
+		{fmt.Errorf("rcpt: %w", &textproto.Error{Code: 554, Msg: "rejected"}), true},
+		{&textproto.Error{Code: 451, Msg: "try again later"}, false},
+		{errors.New("connection refused"), false},
+	}
+	for _, tc := range cases {
+		if got := permanentMailError(tc.err); got != tc.want {
+			t.Errorf("permanentMailError(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestMailConfigValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		patch func(*MailConfig)
+		valid bool
+	}{
+		{"off", func(c *MailConfig) {}, true},
+		{"stdout", func(c *MailConfig) { c.Driver = mailDriverStdout }, true},
+		{"smtp without host", func(c *MailConfig) { c.Driver = mailDriverSMTP }, false},
+		{"smtp", func(c *MailConfig) { c.Driver, c.SMTPHost = mailDriverSMTP, "smtp.example.com" }, true},
+		{"unknown driver", func(c *MailConfig) { c.Driver = "sendmail" }, false},
+		{"bad sender", func(c *MailConfig) { c.Driver, c.From = mailDriverStdout, "tenders" }, false},
+		{"no attempts", func(c *MailConfig) { c.MaxAttempts = 0 }, false},
+	}
+	for _, tc := range cases {
+		config := DefaultConfig()
+		config.Postgres.Conn = "postgres://localhost/tenders"
+		tc.patch(&config.Mail)
+		err := config.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: got %v", tc.name, err)
+		}
+	}
+}
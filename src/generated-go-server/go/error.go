@@ -60,46 +60,14 @@ func (e *ValidationError) Error() string {
 // you would like errors to be handled differently from the DefaultErrorHandler
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error, result *ImplResponse)
 
-// DefaultErrorHandler defines the default logic on how to handle errors from the controller. Any errors from parsing
-// request params will return a StatusBadRequest. Otherwise, the error code originating from the servicer will be used.
-func DefaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error, result *ImplResponse) {
-	var parsingErr *ParsingError
-	if ok := errors.As(err, &parsingErr); ok {
-		// Handle parsing errors
-		_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusBadRequest), w)
-		return
-	}
-
-	var requiredErr *RequiredError
-	if ok := errors.As(err, &requiredErr); ok {
-		// Handle missing required errors
-		_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusUnprocessableEntity), w)
-		return
-	}
-
-	var validErr *ValidationError
-	if ok := errors.As(err, &validErr); ok {
-
-		// Handle missing required errors
-		_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusBadRequest), w)
-		return
-	}
-
-	if result.Code == http.StatusBadRequest {
-		_ = EncodeJSONResponse(result.Body, func(i int) *int { return &i }(http.StatusBadRequest), w)
-		return
-	}
-
-	if result.Code == http.StatusNotFound{
-		_ = EncodeJSONResponse(result.Body, func(i int) *int { return &i }(http.StatusNotFound), w)
-		return
-	}
-	_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusInternalServerError), w)
-	return
-
+// DefaultErrorHandler defines the default logic on how to handle errors from the controller. Every error is
+// written as an application/problem+json response with a stable error code, see problem.go.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error, result *ImplResponse) {
+	p := problemFromError(r, err, result)
+	logProblem(r, p, err)
+	_ = WriteProblem(w, p)
 }
 
-
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
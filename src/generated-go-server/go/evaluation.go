@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// outlierMinScores is how many evaluators must score a bid on a criterion before their
+	// scores are compared with each other.
+	outlierMinScores = 3
+	// outlierDistance is how many points a score may differ from the median score of the bid
+	// on the criterion before it is flagged as an outlier.
+	outlierDistance = 3.0
+)
+
+// criterionColumns are the columns read by scanCriterion.
+var criterionColumns = []string{"id", "tender_id", "name", "description", "weight", "created_at"}
+
+func scanCriterion(row pgx.Row) (EvaluationCriterion, error) {
+	var c EvaluationCriterion
+	var id, tenderId uuid.UUID
+	var createdAt time.Time
+
+	if err := row.Scan(&id, &tenderId, &c.Name, &c.Description, &c.Weight, &createdAt); err != nil {
+		return EvaluationCriterion{}, err
+	}
+	c.Id = id.String()
+	c.TenderId = tenderId.String()
+	c.CreatedAt = createdAt.Format(time.RFC3339)
+	return c, nil
+}
+
+// getTenderCriteria returns the evaluation criteria of a tender in the order they were added.
+func (s *DefaultAPIService) getTenderCriteria(ctx context.Context, tenderId uuid.UUID) ([]EvaluationCriterion, error) {
+	const op = "getTenderCriteria"
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := s.builder.
+		Select(criterionColumns...).
+		From("evaluation_criteria").
+		Where(squirrel.Eq{"tender_id": tenderId}).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to fetch criteria", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	defer rows.Close()
+
+	criteria := []EvaluationCriterion{}
+	for rows.Next() {
+		c, err := scanCriterion(rows)
+		if err != nil {
+			log.Error("failed to scan criterion", slog.Any("error", err))
+			return nil, ErrSQLQuery
+		}
+		criteria = append(criteria, c)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating over rows", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	return criteria, nil
+}
+
+// bidScore is a score together with the bid it was given to.
+type bidScore struct {
+	BidId string
+	BidScore
+}
+
+// getScores returns the scores matching where, ordered by criterion and evaluator. where may
+// refer to the tables as s (bid_scores), c (evaluation_criteria) and e (employee).
+func (s *DefaultAPIService) getScores(ctx context.Context, where squirrel.Sqlizer) ([]bidScore, error) {
+	const op = "getScores"
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := s.builder.
+		Select("s.bid_id", "s.criterion_id", "e.username", "s.score", "s.comment", "s.updated_at").
+		From("bid_scores s").
+		Join("evaluation_criteria c ON c.id = s.criterion_id").
+		Join("employee e ON e.id = s.evaluator_id").
+		Where(where).
+		OrderBy("c.created_at", "c.id", "e.username").
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to fetch scores", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	defer rows.Close()
+
+	var scores []bidScore
+	for rows.Next() {
+		var sc bidScore
+		var bidId, criterionId uuid.UUID
+		var updatedAt time.Time
+		if err := rows.Scan(&bidId, &criterionId, &sc.Evaluator, &sc.Score, &sc.Comment, &updatedAt); err != nil {
+			log.Error("failed to scan score", slog.Any("error", err))
+			return nil, ErrSQLQuery
+		}
+		sc.BidId = bidId.String()
+		sc.CriterionId = criterionId.String()
+		sc.UpdatedAt = updatedAt.Format(time.RFC3339)
+		scores = append(scores, sc)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating over rows", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	return scores, nil
+}
+
+// evaluationStarted reports whether any bid of the tender has been scored. From then on the
+// criteria are fixed so that all bids are compared by the same weights.
+func evaluationStarted(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID) (bool, error) {
+	var started bool
+	err := tx.QueryRow(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM bid_scores s JOIN evaluation_criteria c ON c.id = s.criterion_id
+		WHERE c.tender_id = $1
+	)`, tenderId).Scan(&started)
+	return started, err
+}
+
+// rankBids computes the weighted score of every bid and orders the bids by it. A bid scores
+// the weighted mean of its criterion averages over the criteria it was scored on; bids
+// without scores come last without a rank. Equal scores share a rank and keep the order of
+// bids, which is the order they were submitted in.
+func rankBids(criteria []EvaluationCriterion, bids []Bid, scores []bidScore) []BidRanking {
+	type key struct{ bid, criterion string }
+	byKey := make(map[key][]BidScore)
+	for _, sc := range scores {
+		k := key{sc.BidId, sc.CriterionId}
+		byKey[k] = append(byKey[k], sc.BidScore)
+	}
+
+	rankings := make([]BidRanking, 0, len(bids))
+	for _, bid := range bids {
+		r := BidRanking{
+			BidId:      bid.Id,
+			BidName:    bid.Name,
+			Complete:   len(criteria) > 0,
+			Criteria:   []CriterionResult{},
+			Evaluators: []EvaluatorResult{},
+		}
+
+		var total weightedMean
+		evaluators := make(map[string]*weightedMean)
+		for _, c := range criteria {
+			cs := byKey[key{bid.Id, c.Id}]
+			if len(cs) == 0 {
+				r.Complete = false
+				continue
+			}
+			markOutliers(cs)
+
+			var sum float64
+			for _, sc := range cs {
+				sum += float64(sc.Score)
+				e, ok := evaluators[sc.Evaluator]
+				if !ok {
+					e = &weightedMean{}
+					evaluators[sc.Evaluator] = e
+				}
+				e.add(float64(sc.Score), c.Weight)
+			}
+			avg := sum / float64(len(cs))
+			total.add(avg, c.Weight)
+			r.Criteria = append(r.Criteria, CriterionResult{CriterionId: c.Id, Average: round2(avg), Scores: cs})
+		}
+
+		if total.n > 0 {
+			score := round2(total.value())
+			r.Score = &score
+		}
+		for name, e := range evaluators {
+			r.Evaluators = append(r.Evaluators, EvaluatorResult{
+				Evaluator: name,
+				Score:     round2(e.value()),
+				Complete:  e.n == len(criteria),
+			})
+		}
+		sort.Slice(r.Evaluators, func(i, j int) bool { return r.Evaluators[i].Evaluator < r.Evaluators[j].Evaluator })
+		rankings = append(rankings, r)
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		a, b := rankings[i].Score, rankings[j].Score
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a > *b
+	})
+	for i := range rankings {
+		if rankings[i].Score == nil {
+			break
+		}
+		if i > 0 && *rankings[i].Score == *rankings[i-1].Score {
+			rankings[i].Rank = rankings[i-1].Rank
+		} else {
+			rankings[i].Rank = int32(i + 1)
+		}
+	}
+	return rankings
+}
+
+// weightedMean accumulates scores with the weights of their criteria.
+type weightedMean struct {
+	sum, weight float64
+	n           int
+}
+
+func (m *weightedMean) add(score float64, weight int32) {
+	m.sum += score * float64(weight)
+	m.weight += float64(weight)
+	m.n++
+}
+
+func (m *weightedMean) value() float64 {
+	return m.sum / m.weight
+}
+
+// markOutliers flags the scores of one bid on one criterion that are far from the median
+// of all evaluators' scores.
+func markOutliers(scores []BidScore) {
+	if len(scores) < outlierMinScores {
+		return
+	}
+	values := make([]float64, len(scores))
+	for i, sc := range scores {
+		values[i] = float64(sc.Score)
+	}
+	slices.Sort(values)
+
+	median := values[len(values)/2]
+	if len(values)%2 == 0 {
+		median = (values[len(values)/2-1] + median) / 2
+	}
+	for i := range scores {
+		scores[i].Outlier = math.Abs(float64(scores[i].Score)-median) > outlierDistance
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
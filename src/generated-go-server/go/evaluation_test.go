@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRankBids(t *testing.T) {
+	criteria := []EvaluationCriterion{
+		{Id: "price", Weight: 60},
+		{Id: "delivery", Weight: 40},
+	}
+	bids := []Bid{{Id: "a", Name: "A"}, {Id: "b", Name: "B"}, {Id: "c", Name: "C"}, {Id: "d", Name: "D"}}
+	score := func(bid, criterion, evaluator string, value int32) bidScore {
+		return bidScore{BidId: bid, BidScore: BidScore{CriterionId: criterion, Evaluator: evaluator, Score: value}}
+	}
+	scores := []bidScore{
+		score("a", "price", "anna", 6), score("a", "price", "boris", 8),
+		score("a", "delivery", "anna", 10),
+		score("b", "price", "anna", 9), score("b", "delivery", "anna", 9),
+		score("c", "price", "anna", 9), score("c", "delivery", "boris", 9),
+	}
+
+	rankings := rankBids(criteria, bids, scores)
+
+	var order []string
+	for _, r := range rankings {
+		order = append(order, r.BidId)
+	}
+	if got := strings.Join(order, ","); got != "b,c,a,d" {
+		t.Fatalf("order = %s, want b,c,a,d", got)
+	}
+
+	b, c, a, d := rankings[0], rankings[1], rankings[2], rankings[3]
+	if *b.Score != 9 || b.Rank != 1 || c.Rank != 1 {
+		t.Errorf("tied bids: b = %v rank %d, c rank %d", *b.Score, b.Rank, c.Rank)
+	}
+	// price averages 7, delivery 10: (7*60 + 10*40) / 100.
+	if *a.Score != 8.2 || a.Rank != 3 || !a.Complete {
+		t.Errorf("a = %v rank %d complete %v", *a.Score, a.Rank, a.Complete)
+	}
+	if d.Score != nil || d.Rank != 0 || d.Complete {
+		t.Errorf("unscored bid = %+v", d)
+	}
+
+	if len(a.Evaluators) != 2 || a.Evaluators[0].Evaluator != "anna" || a.Evaluators[0].Score != 7.6 || !a.Evaluators[0].Complete {
+		t.Errorf("anna on a = %+v", a.Evaluators)
+	}
+	if boris := a.Evaluators[1]; boris.Score != 8 || boris.Complete {
+		t.Errorf("boris on a = %+v", boris)
+	}
+}
+
+func TestMarkOutliers(t *testing.T) {
+	scores := []BidScore{{Score: 7}, {Score: 8}, {Score: 2}, {Score: 10}}
+	markOutliers(scores)
+	for i, want := range []bool{false, false, true, false} {
+		if scores[i].Outlier != want {
+			t.Errorf("score %d: outlier = %v, want %v", scores[i].Score, scores[i].Outlier, want)
+		}
+	}
+
+	pair := []BidScore{{Score: 0}, {Score: 10}}
+	markOutliers(pair)
+	if pair[0].Outlier || pair[1].Outlier {
+		t.Error("two scores must not be compared")
+	}
+}
+
+func TestSubmitBidScoresValidation(t *testing.T) {
+	router := NewRouter(NewEvaluationAPIController(nil))
+	const criterion = "6f1c2a8e-0d1b-4c5e-9f3a-1b2c3d4e5f60"
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{"scores":[]}`, http.StatusUnprocessableEntity},
+		{`{"scores":[{"criterionId":"` + criterion + `","score":11}]}`, http.StatusBadRequest},
+		{`{"scores":[{"criterionId":"` + criterion + `","score":5},{"criterionId":"` + criterion + `","score":6}]}`, http.StatusBadRequest},
+		{`{"scores":[{"criterionId":"price","score":5}]}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/bids/"+importOrgID+"/scores?username=user1", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
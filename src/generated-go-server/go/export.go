@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// exportFlushRows is how many rows are written between flushes to the client.
+const exportFlushRows = 100
+
+// Export is the body of a successful export response. Rows are produced while the response
+// is written, straight from the database cursor, so the table never has to fit in memory.
+type Export struct {
+	Format ExportFormat
+	// Name is the file name without the date and the extension, e.g. "tenders".
+	Name    string
+	Columns []string
+	// Rows calls emit for every row in order. Cells are strings or integers.
+	Rows func(ctx context.Context, emit func(cells []any) error) error
+}
+
+// rowWriter encodes the rows of an export in one file format.
+type rowWriter interface {
+	WriteRow(cells []any) error
+	// Flush passes buffered rows to the underlying writer.
+	Flush() error
+	Close() error
+}
+
+func newRowWriter(format ExportFormat, w io.Writer, sheet string) (rowWriter, string) {
+	if format == EXPORT_XLSX {
+		return newXLSXWriter(w, sheet), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return newCSVWriter(w), "text/csv; charset=utf-8"
+}
+
+// WriteExport streams e to w as a file attachment. The response starts with the first row,
+// so an error before it, such as an unavailable database, is returned for the caller to
+// report. If producing a row fails later the connection is aborted, so that a truncated
+// file is never mistaken for a complete one.
+func WriteExport(w http.ResponseWriter, r *http.Request, e *Export) error {
+	rw, contentType := newRowWriter(e.Format, w, e.Name)
+	filename := fmt.Sprintf("%s-%s.%s", e.Name, time.Now().Format("2006-01-02"), e.Format)
+
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		w.WriteHeader(http.StatusOK)
+
+		header := make([]any, len(e.Columns))
+		for i, c := range e.Columns {
+			header[i] = c
+		}
+		return rw.WriteRow(header)
+	}
+
+	rc := http.NewResponseController(w)
+	// The write timeout of the server is meant for ordinary responses, not for an export
+	// that streams as long as the table has rows.
+	_ = rc.SetWriteDeadline(time.Time{})
+	rows := 0
+	err := e.Rows(r.Context(), func(cells []any) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := rw.WriteRow(cells); err != nil {
+			return err
+		}
+		if rows++; rows%exportFlushRows == 0 {
+			if err := rw.Flush(); err != nil {
+				return err
+			}
+			_ = rc.Flush()
+		}
+		return nil
+	})
+	if err != nil && !started {
+		return err
+	}
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = rw.Close()
+	}
+
+	if err != nil {
+		log.Printf("%s %s request_id=%s export aborted after %d rows: %v",
+			r.Method, r.RequestURI, RequestIDFromContext(r.Context()), rows, err)
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
+// csvWriter writes RFC 4180 CSV prefixed with a UTF-8 byte order mark, without which
+// spreadsheet applications misread Cyrillic text.
+type csvWriter struct {
+	w       io.Writer
+	csv     *csv.Writer
+	started bool
+	record  []string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: w, csv: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) WriteRow(cells []any) error {
+	if !c.started {
+		c.started = true
+		if _, err := io.WriteString(c.w, "\ufeff"); err != nil {
+			return err
+		}
+	}
+
+	c.record = c.record[:0]
+	for _, cell := range cells {
+		c.record = append(c.record, formatCell(cell))
+	}
+	return c.csv.Write(c.record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+func formatCell(cell any) string {
+	switch v := cell.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
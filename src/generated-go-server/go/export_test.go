@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testExport(format ExportFormat, rows [][]any, failAfter int) *Export {
+	return &Export{
+		Format:  format,
+		Name:    "tenders",
+		Columns: []string{"id", "name", "version"},
+		Rows: func(ctx context.Context, emit func([]any) error) error {
+			for i, row := range rows {
+				if i == failAfter {
+					return errors.New("connection reset")
+				}
+				if err := emit(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+var exportRows = [][]any{
+	{"1", "Доставка, сборка", int32(2)},
+	{"2", `Кавычки "и" <теги>`, int32(1)},
+}
+
+func TestWriteExportCSV(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := WriteExport(rec, httptest.NewRequest(http.MethodGet, "/api/tenders/export", nil), testExport(EXPORT_CSV, exportRows, -1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, `attachment; filename="tenders-`) || !strings.HasSuffix(cd, `.csv"`) {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+
+	want := "\ufeffid,name,version\n" +
+		"1,\"Доставка, сборка\",2\n" +
+		"2,\"Кавычки \"\"и\"\" <теги>\",1\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestWriteExportXLSX(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := WriteExport(rec, httptest.NewRequest(http.MethodGet, "/api/tenders/export", nil), testExport(EXPORT_XLSX, exportRows, -1)); err != nil {
+		t.Fatal(err)
+	}
+
+	body := rec.Body.Bytes()
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(data)
+
+		// Every part must be well-formed XML.
+		dec := xml.NewDecoder(bytes.NewReader(data))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: %v", f.Name, err)
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Cells []struct {
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal([]byte(parts["xl/worksheets/sheet1.xml"]), &sheet); err != nil {
+		t.Fatal(err)
+	}
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("got %d rows, want header and 2 rows", len(sheet.Rows))
+	}
+	if c := sheet.Rows[2].Cells[1]; c.Type != "inlineStr" || c.Inline != `Кавычки "и" <теги>` {
+		t.Errorf("string cell = %+v", c)
+	}
+	if c := sheet.Rows[1].Cells[2]; c.Type != "" || c.Value != "2" {
+		t.Errorf("number cell = %+v", c)
+	}
+}
+
+func TestWriteExportErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := WriteExport(rec, httptest.NewRequest(http.MethodGet, "/api/tenders/export", nil), testExport(EXPORT_CSV, exportRows, 0))
+	if err == nil || rec.Body.Len() != 0 {
+		t.Errorf("err = %v, body %q: a failure before the first row must be returned", err, rec.Body.String())
+	}
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", r)
+		}
+	}()
+
+	rec = httptest.NewRecorder()
+	_ = WriteExport(rec, httptest.NewRequest(http.MethodGet, "/api/tenders/export", nil), testExport(EXPORT_CSV, exportRows, 1))
+	t.Error("a failure after the first row must abort the response")
+}
+
+func TestExportFormatParameter(t *testing.T) {
+	router := NewRouter(NewExportAPIController(nil))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/tenders/export?format=pdf", nil))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "format") {
+		t.Errorf("got %d %s, want 400 for the format parameter", rec.Code, rec.Body.String())
+	}
+}
+
+func TestExportBidsSortParameter(t *testing.T) {
+	router := NewRouter(NewExportAPIController(nil))
+
+	rec := httptest.NewRecorder()
+	target := "/api/tenders/550e8400-e29b-41d4-a716-446655440000/bids/export?username=user1&sort=author"
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "sort") {
+		t.Errorf("got %d %s, want 400 for the sort parameter", rec.Code, rec.Body.String())
+	}
+}
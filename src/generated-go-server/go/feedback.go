@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// feedbackColumns are the columns read by scanFeedback from bid_feedback aliased f.
+const feedbackColumns = `f.feedback_id, f.bid_id, COALESCE(f.username, ''), f.feedback, COALESCE(f.rating, 0), f.tags,
+	f.version, f.retracted_at IS NOT NULL, COALESCE(f.created_at, CURRENT_TIMESTAMP), COALESCE(f.updated_at, f.created_at, CURRENT_TIMESTAMP)`
+
+func scanFeedback(row pgx.Row) (BidFeedback, error) {
+	var feedback BidFeedback
+	var id, bidId uuid.UUID
+	var tags []string
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&id, &bidId, &feedback.Username, &feedback.Feedback, &feedback.Rating, &tags,
+		&feedback.Version, &feedback.Retracted, &createdAt, &updatedAt)
+	if err != nil {
+		return BidFeedback{}, err
+	}
+
+	feedback.Id = id.String()
+	feedback.BidId = bidId.String()
+	feedback.Tags = feedbackTags(tags)
+	feedback.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	feedback.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	return feedback, nil
+}
+
+// feedbackVersionColumns are the columns read by scanFeedbackVersion from bid_feedback_versions aliased v.
+const feedbackVersionColumns = `v.feedback_id, v.version, v.feedback, COALESCE(v.rating, 0), v.tags, v.retracted, v.changed_at`
+
+func scanFeedbackVersion(row pgx.Row) (BidFeedbackVersion, error) {
+	var version BidFeedbackVersion
+	var feedbackId uuid.UUID
+	var tags []string
+	var changedAt time.Time
+
+	err := row.Scan(&feedbackId, &version.Version, &version.Feedback, &version.Rating, &tags, &version.Retracted, &changedAt)
+	if err != nil {
+		return BidFeedbackVersion{}, err
+	}
+
+	version.FeedbackId = feedbackId.String()
+	version.Tags = feedbackTags(tags)
+	version.ChangedAt = changedAt.UTC().Format(time.RFC3339)
+	return version, nil
+}
+
+func feedbackTags(values []string) []FeedbackTag {
+	tags := make([]FeedbackTag, 0, len(values))
+	for _, v := range values {
+		tags = append(tags, FeedbackTag(v))
+	}
+	return tags
+}
+
+func feedbackTagStrings(tags []FeedbackTag) []string {
+	values := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, string(tag))
+	}
+	return values
+}
+
+// nullableRating stores a missing rating as NULL, so that it does not count in the averages.
+func nullableRating(rating int32) *int32 {
+	if rating == 0 {
+		return nil
+	}
+	return &rating
+}
+
+// recordFeedbackVersion appends the current state of the feedback to its history, in the
+// transaction that changed it.
+func recordFeedbackVersion(ctx context.Context, tx pgx.Tx, feedback BidFeedback) error {
+	_, err := tx.Exec(ctx, `
+	INSERT INTO bid_feedback_versions (feedback_id, version, feedback, rating, tags, retracted)
+	VALUES ($1, $2, $3, $4, $5, $6)`,
+		feedback.Id, feedback.Version, feedback.Feedback, nullableRating(feedback.Rating),
+		feedbackTagStrings(feedback.Tags), feedback.Retracted)
+	return err
+}
+
+// currentFeedback locks the current feedback of the user on the bid.
+func currentFeedback(ctx context.Context, tx pgx.Tx, bidId uuid.UUID, username string) (BidFeedback, error) {
+	feedback, err := scanFeedback(tx.QueryRow(ctx, `
+	SELECT `+feedbackColumns+` FROM bid_feedback f
+	WHERE f.bid_id = $1 AND f.username = $2 AND f.retracted_at IS NULL
+	FOR UPDATE`, bidId, username))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return BidFeedback{}, NewAPIError(ErrCodeFeedbackNotFound, err).WithDetail(MsgFeedbackNotFound)
+		}
+		return BidFeedback{}, NewAPIError(ErrCodeInternal, err)
+	}
+	return feedback, nil
+}
+
+// addReview counts a review in the summary; a review without a rating only counts in Reviews.
+func (summary *BidReviewSummary) addReview(rating int32, tags []FeedbackTag) {
+	summary.Reviews++
+	if rating >= 1 && rating <= 5 {
+		summary.Ratings++
+		summary.RatingCounts[rating-1]++
+	}
+	for _, tag := range tags {
+		summary.Tags[tag]++
+	}
+}
@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEditBidFeedbackRequestConstraints(t *testing.T) {
+	cases := []struct {
+		name  string
+		req   EditBidFeedbackRequest
+		param string
+	}{
+		{"valid", EditBidFeedbackRequest{Rating: 4, Tags: []FeedbackTag{FEEDBACK_TAG_PRICE}}, ""},
+		{"clear tags", EditBidFeedbackRequest{Tags: []FeedbackTag{}}, ""},
+		{"no changes", EditBidFeedbackRequest{}, "feedback"},
+		{"too long", EditBidFeedbackRequest{Feedback: strings.Repeat("ж", maxFeedbackLength+1)}, "feedback"},
+		{"rating", EditBidFeedbackRequest{Rating: 6}, "rating"},
+		{"unknown tag", EditBidFeedbackRequest{Tags: []FeedbackTag{"Speed"}}, "tags"},
+		{"duplicate tag", EditBidFeedbackRequest{Tags: []FeedbackTag{FEEDBACK_TAG_QUALITY, FEEDBACK_TAG_QUALITY}}, "tags"},
+	}
+	for _, tc := range cases {
+		err := AssertEditBidFeedbackRequestConstraints(tc.req)
+		if tc.param == "" {
+			if err != nil {
+				t.Errorf("%s: got %v", tc.name, err)
+			}
+			continue
+		}
+		var parsingErr *ParsingError
+		if !errors.As(err, &parsingErr) || parsingErr.Param != tc.param {
+			t.Errorf("%s: got %v, want an error on %s", tc.name, err, tc.param)
+		}
+	}
+
+	err := checkFeedbackTags([]FeedbackTag{FEEDBACK_TAG_PRICE, FEEDBACK_TAG_PRICE})
+	if got := err.Localize(LocaleEN); got != "tag Price is given twice" {
+		t.Errorf("duplicate tag detail = %q", got)
+	}
+}
+
+func TestBidReviewSummary(t *testing.T) {
+	summary := BidReviewSummary{Tags: map[FeedbackTag]int32{}}
+	summary.addReview(5, []FeedbackTag{FEEDBACK_TAG_QUALITY, FEEDBACK_TAG_PRICE})
+	summary.addReview(3, []FeedbackTag{FEEDBACK_TAG_PRICE})
+	summary.addReview(0, nil)
+
+	if summary.Reviews != 3 || summary.Ratings != 2 {
+		t.Errorf("reviews %d, ratings %d, want 3 and 2", summary.Reviews, summary.Ratings)
+	}
+	if summary.RatingCounts != [5]int32{0, 0, 1, 0, 1} {
+		t.Errorf("rating counts = %v", summary.RatingCounts)
+	}
+	if summary.Tags[FEEDBACK_TAG_PRICE] != 2 || summary.Tags[FEEDBACK_TAG_QUALITY] != 1 {
+		t.Errorf("tags = %v", summary.Tags)
+	}
+}
+
+func TestBidFeedbackRequestValidation(t *testing.T) {
+	router := NewRouter(NewDefaultAPIController(nil), NewFeedbackAPIController(nil))
+	bid := "/api/bids/" + importOrgID
+	cases := []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodPut, bid + "/feedback?username=user1&bidFeedback=ok&rating=6", "", http.StatusBadRequest},
+		{http.MethodPut, bid + "/feedback?username=user1&bidFeedback=ok&rating=5&tags=Price,Speed", "", http.StatusBadRequest},
+		{http.MethodPut, bid + "/feedback?username=user1&bidFeedback=ok&tags=Price&tags=Price", "", http.StatusBadRequest},
+		{http.MethodPatch, bid + "/feedback?username=user1", `{}`, http.StatusBadRequest},
+		{http.MethodPatch, bid + "/feedback?username=user1", `{"rating": 0, "stars": 5}`, http.StatusBadRequest},
+		{http.MethodDelete, bid + "/feedback", "", http.StatusUnprocessableEntity},
+		{http.MethodGet, bid + "/reviews/summary?authorUsername=user2", "", http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s %s %s: got %d %s, want %d", tc.method, tc.path, tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
@@ -24,7 +24,7 @@ func (s *DefaultAPIService) getTenderById(ctx context.Context, tenderId uuid.UUI
 	log := s.log.With(slog.String("op", op))
 
 	sql, args, err := s.builder.
-		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "created_at").
+		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "created_at", "budget_amount", "budget_currency", tenderSealingColumns, "private", "conflict_policy").
 		From("tenders").
 		Where(squirrel.Eq{"id": tenderId}).
 		ToSql()
@@ -37,6 +37,8 @@ func (s *DefaultAPIService) getTenderById(ctx context.Context, tenderId uuid.UUI
 	var tender Tender
 	var tenderIdUUID uuid.UUID
 	var tenderTime time.Time
+	var budget nullMoney
+	var sealing tenderSealing
 
 	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(
 		&tenderIdUUID,
@@ -47,6 +49,13 @@ func (s *DefaultAPIService) getTenderById(ctx context.Context, tenderId uuid.UUI
 		&tender.OrganizationId,
 		&tender.Version,
 		&tenderTime,
+		&budget.Amount,
+		&budget.Currency,
+		&sealing.Sealed,
+		&sealing.Deadline,
+		&sealing.RevealedAt,
+		&tender.Private,
+		&tender.ConflictPolicy,
 	)
 
 	if err != nil {
@@ -60,6 +69,8 @@ func (s *DefaultAPIService) getTenderById(ctx context.Context, tenderId uuid.UUI
 
 	tender.Id = s.ConvertFromUUID(tenderIdUUID)
 	tender.CreatedAt = tenderTime.Format(time.RFC3339)
+	tender.Budget = budget.Money()
+	sealing.apply(&tender)
 	return &tender, nil
 
 }
@@ -270,7 +281,7 @@ func (s *DefaultAPIService) getBidById(ctx context.Context, bidId uuid.UUID) (*B
 		slog.String("bid_id", bidId.String()))
 
 	sql, args, err := s.builder.
-		Select("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+		Select("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at", "price_amount", "price_currency", bidLotIdsColumn).
 		From("bids").
 		Where(squirrel.Eq{"bid_id": bidId}).
 		ToSql()
@@ -282,6 +293,7 @@ func (s *DefaultAPIService) getBidById(ctx context.Context, bidId uuid.UUID) (*B
 
 	bid := &Bid{}
 	var timeBid time.Time
+	var price nullMoney
 
 	err = s.pg.Pool.QueryRow(ctx, sql, args...).Scan(
 		&bid.Id,
@@ -293,6 +305,9 @@ func (s *DefaultAPIService) getBidById(ctx context.Context, bidId uuid.UUID) (*B
 		&bid.AuthorId,
 		&bid.Version,
 		&timeBid,
+		&price.Amount,
+		&price.Currency,
+		&bid.LotIds,
 	)
 
 	if err != nil {
@@ -305,6 +320,7 @@ func (s *DefaultAPIService) getBidById(ctx context.Context, bidId uuid.UUID) (*B
 	}
 
 	bid.CreatedAt = timeBid.Format(time.RFC3339)
+	bid.Price = price.Money()
 
 	return bid, nil
 }
@@ -397,6 +413,12 @@ func (s *DefaultAPIService) addVersionTableTender(ctx context.Context, userName
 		values = append(values, newId)
 	}
 
+	if tender.Budget != nil {
+		amount, currency := moneyValues(tender.Budget)
+		columns = append(columns, "budget_amount", "budget_currency")
+		values = append(values, amount, currency)
+	}
+
 	sql, args, err := s.builder.
 		Insert("tender_versions").
 		Columns(columns...).
@@ -414,7 +436,7 @@ func (s *DefaultAPIService) addVersionTableTender(ctx context.Context, userName
 		return err
 	}
 
-	return nil
+	return s.snapshotAttachments(ctx, attachmentEntityTender, tender.Id, tender.Version)
 }
 
 func (s *DefaultAPIService) addVersionTableBid(ctx context.Context, bid Bid) error {
@@ -423,8 +445,9 @@ func (s *DefaultAPIService) addVersionTableBid(ctx context.Context, bid Bid) err
 
 	newTime := time.Now().Format(time.RFC3339)
 
-	columns := []string{"bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at"}
-	values := []interface{}{bid.Id, bid.Name, bid.Description, bid.Status, bid.TenderId, bid.AuthorType, bid.AuthorId, bid.Version, newTime}
+	priceAmount, priceCurrency := moneyValues(bid.Price)
+	columns := []string{"bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at", "price_amount", "price_currency"}
+	values := []interface{}{bid.Id, bid.Name, bid.Description, bid.Status, bid.TenderId, bid.AuthorType, bid.AuthorId, bid.Version, newTime, priceAmount, priceCurrency}
 
 	sql, args, err := s.builder.
 		Insert("bids_versions").
@@ -443,5 +466,5 @@ func (s *DefaultAPIService) addVersionTableBid(ctx context.Context, bid Bid) err
 		return err
 	}
 
-	return nil
+	return s.snapshotAttachments(ctx, attachmentEntityBid, bid.Id, bid.Version)
 }
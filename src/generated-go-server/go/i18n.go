@@ -0,0 +1,615 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// Locale is a language the service can answer in.
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+)
+
+// fallbackLocale is used when no locale was negotiated for the request, e.g. in background jobs.
+const fallbackLocale = LocaleRU
+
+// supportedLocales is the list of catalog languages. The order matters for Accept-Language
+// negotiation: it is the preference order when the client accepts several languages equally.
+var supportedLocales = []Locale{LocaleRU, LocaleEN}
+
+// ParseLocale checks that s names a supported locale.
+func ParseLocale(s string) (Locale, error) {
+	for _, l := range supportedLocales {
+		if string(l) == s {
+			return l, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported locale %q, expected one of %v", s, supportedLocales)
+}
+
+// MessageKey identifies a user-facing message in the catalog.
+type MessageKey string
+
+const (
+	MsgTenderVersionAbsent MessageKey = "result.tender_version_absent"
+
+	MsgUnknownServiceType MessageKey = "validation.unknown_service_type"
+	MsgUsernameRequired   MessageKey = "validation.username_required"
+	MsgVersionMin         MessageKey = "validation.version_min"
+	MsgRequired           MessageKey = "validation.required"
+	MsgRequiredField      MessageKey = "validation.required_field"
+	MsgInvalidField       MessageKey = "validation.invalid_field"
+	MsgMaxLength          MessageKey = "validation.max_length"
+	MsgMustBeUUID         MessageKey = "validation.must_be_uuid"
+	MsgRequiredUUID       MessageKey = "validation.required_uuid"
+	MsgOneOf              MessageKey = "validation.one_of"
+	MsgRFC3339            MessageKey = "validation.rfc3339"
+	MsgMinLength          MessageKey = "validation.min_length"
+	MsgMinimum            MessageKey = "validation.minimum"
+	MsgMaximum            MessageKey = "validation.maximum"
+	MsgMinItems           MessageKey = "validation.min_items"
+	MsgMaxItems           MessageKey = "validation.max_items"
+	MsgUniqueItems        MessageKey = "validation.unique_items"
+	MsgType               MessageKey = "validation.type"
+	MsgFormat             MessageKey = "validation.format"
+	MsgPattern            MessageKey = "validation.pattern"
+	MsgInvalidValue       MessageKey = "validation.invalid_value"
+
+	MsgImportNoRows         MessageKey = "import.no_rows"
+	MsgImportTooManyRows    MessageKey = "import.too_many_rows"
+	MsgImportMalformedRow   MessageKey = "import.malformed_row"
+	MsgImportUnknownColumn  MessageKey = "import.unknown_column"
+	MsgImportUserNotFound   MessageKey = "import.user_not_found"
+	MsgImportNotResponsible MessageKey = "import.not_responsible"
+
+	MsgAttachmentTooLarge     MessageKey = "attachment.too_large"
+	MsgAttachmentTypeAllowed  MessageKey = "attachment.type_allowed"
+	MsgAttachmentNameRequired MessageKey = "attachment.name_required"
+
+	MsgMoneyCurrency         MessageKey = "money.currency"
+	MsgMoneyPositive         MessageKey = "money.positive"
+	MsgMoneyTooLarge         MessageKey = "money.too_large"
+	MsgMoneyScale            MessageKey = "money.scale"
+	MsgPriceCurrencyMismatch MessageKey = "money.price_currency_mismatch"
+	MsgPriceExceedsBudget    MessageKey = "money.price_exceeds_budget"
+
+	MsgLotDuplicate      MessageKey = "lot.duplicate"
+	MsgPriceExceedsLots  MessageKey = "lot.price_exceeds_lots"
+	MsgLotUnknown        MessageKey = "lot.unknown"
+	MsgLotNotOpen        MessageKey = "lot.not_open"
+	MsgLotRequired       MessageKey = "lot.required"
+	MsgLotNotInBid       MessageKey = "lot.not_in_bid"
+	MsgLotsRequired      MessageKey = "lot.lots_required"
+	MsgLotsAbsent        MessageKey = "lot.lots_absent"
+	MsgLotsFrozen        MessageKey = "lot.lots_frozen"
+	MsgLotBudgetCurrency MessageKey = "lot.budget_currency"
+	MsgTenderClosed      MessageKey = "lot.tender_closed"
+
+	MsgWeightRange        MessageKey = "evaluation.weight_range"
+	MsgScoreRange         MessageKey = "evaluation.score_range"
+	MsgCriterionDuplicate MessageKey = "evaluation.criterion_duplicate"
+	MsgCriterionUnknown   MessageKey = "evaluation.criterion_unknown"
+	MsgCriteriaAbsent     MessageKey = "evaluation.criteria_absent"
+	MsgEvaluationStarted  MessageKey = "evaluation.started"
+	MsgBidNotPublished    MessageKey = "evaluation.bid_not_published"
+
+	MsgSealedDeadline   MessageKey = "sealing.deadline_required"
+	MsgDeadlinePast     MessageKey = "sealing.deadline_past"
+	MsgSealingDisabled  MessageKey = "sealing.disabled"
+	MsgBidsSealed       MessageKey = "sealing.bids_sealed"
+	MsgSubmissionClosed MessageKey = "sealing.submission_closed"
+
+	MsgAuctionWindow          MessageKey = "auction.window"
+	MsgAuctionCurrency        MessageKey = "auction.currency"
+	MsgAuctionExtensionRange  MessageKey = "auction.extension_range"
+	MsgAuctionDelivery        MessageKey = "auction.delivery_only"
+	MsgAuctionSealed          MessageKey = "auction.sealed"
+	MsgAuctionLots            MessageKey = "auction.lots"
+	MsgAuctionStartPast       MessageKey = "auction.start_past"
+	MsgAuctionExists          MessageKey = "auction.exists"
+	MsgAuctionNotStarted      MessageKey = "auction.not_started"
+	MsgAuctionEnded           MessageKey = "auction.ended"
+	MsgAuctionDecrement       MessageKey = "auction.decrement"
+	MsgAuctionStartPrice      MessageKey = "auction.start_price"
+	MsgAuctionBidTender       MessageKey = "auction.bid_tender"
+	MsgAuctionBidNotPublished MessageKey = "auction.bid_not_published"
+	MsgAuctionNotParticipant  MessageKey = "auction.not_participant"
+	MsgAuctionPriceEdit       MessageKey = "auction.price_edit"
+
+	MsgQuestionNotPublished MessageKey = "question.tender_not_published"
+	MsgQuestionAnswered     MessageKey = "question.answered"
+	MsgQuestionBumpPrivate  MessageKey = "question.bump_private"
+
+	MsgInvitationInvitee      MessageKey = "invitation.invitee"
+	MsgInvitationPublicTender MessageKey = "invitation.public_tender"
+	MsgInvitationOwnOrg       MessageKey = "invitation.own_organization"
+	MsgInvitationExists       MessageKey = "invitation.exists"
+	MsgInvitationNotPending   MessageKey = "invitation.not_pending"
+	MsgInvitationRevoked      MessageKey = "invitation.revoked"
+	MsgInvitationNotAccepted  MessageKey = "invitation.not_accepted"
+	MsgInvitationNotAddressee MessageKey = "invitation.not_addressee"
+
+	MsgNotOrganizationResponsible   MessageKey = "supplier.not_responsible"
+	MsgSupplierSubject              MessageKey = "supplier.subject"
+	MsgSupplierReasonRequired       MessageKey = "supplier.reason_required"
+	MsgSupplierOwnOrg               MessageKey = "supplier.own_organization"
+	MsgSupplierExpiresPast          MessageKey = "supplier.expires_past"
+	MsgSupplierBlacklisted          MessageKey = "supplier.blacklisted"
+	MsgSupplierBlacklistedUntil     MessageKey = "supplier.blacklisted_until"
+	MsgSupplierNotQualified         MessageKey = "supplier.not_qualified"
+	MsgSupplierQualificationExpired MessageKey = "supplier.qualification_expired"
+
+	MsgConflictAuthorResponsible MessageKey = "conflict.author_responsible"
+	MsgConflictSameOrganization  MessageKey = "conflict.same_organization"
+	MsgConflictSelfEvaluation    MessageKey = "conflict.self_evaluation"
+
+	MsgFeedbackRating       MessageKey = "feedback.rating"
+	MsgFeedbackTagDuplicate MessageKey = "feedback.tag_duplicate"
+	MsgFeedbackNoChanges    MessageKey = "feedback.no_changes"
+	MsgFeedbackExists       MessageKey = "feedback.exists"
+	MsgFeedbackNotFound     MessageKey = "feedback.not_found"
+
+	MsgNotificationTypeDuplicate   MessageKey = "notification.type_duplicate"
+	MsgNotificationBidCreated      MessageKey = "notification.bid_created"
+	MsgNotificationBidApproved     MessageKey = "notification.bid_approved"
+	MsgNotificationBidRejected     MessageKey = "notification.bid_rejected"
+	MsgNotificationTenderClosed    MessageKey = "notification.tender_closed"
+	MsgNotificationAuctionFinished MessageKey = "notification.auction_finished"
+	MsgNotificationBidsRevealed    MessageKey = "notification.bids_revealed"
+	MsgNotificationEmailInvalid    MessageKey = "notification.email_invalid"
+)
+
+// errorMessageKey is the catalog key of the title of an error code.
+func errorMessageKey(code ErrorCode) MessageKey {
+	return MessageKey("error." + string(code))
+}
+
+// catalog holds every user-facing message in every supported locale. Messages may contain
+// fmt verbs, which are filled from the arguments passed to Translate.
+var catalog = map[Locale]map[MessageKey]string{
+	LocaleRU: {
+		errorMessageKey(ErrCodeInvalidParameter):        "Неверный формат запроса или его параметры",
+		errorMessageKey(ErrCodeMissingParameter):        "Не передан обязательный параметр",
+		errorMessageKey(ErrCodeValidationFailed):        "Данные запроса не прошли проверку",
+		errorMessageKey(ErrCodeInvalidID):               "Неверный идентификатор, ожидается UUID",
+		errorMessageKey(ErrCodeInvalidStatus):           "Недопустимый статус",
+		errorMessageKey(ErrCodeUserNotFound):            "Пользователь не существует или некорректен",
+		errorMessageKey(ErrCodeOrganizationNotFound):    "Организация не существует или некорректна",
+		errorMessageKey(ErrCodeForbiddenNotResponsible): "Недостаточно прав для выполнения действия: пользователь не является ответственным за организацию тендера",
+		errorMessageKey(ErrCodeForbiddenNotAuthor):      "Недостаточно прав для выполнения действия: пользователь не является автором предложения",
+		errorMessageKey(ErrCodeForbiddenOrganization):   "Недостаточно прав для выполнения действия: организация не имеет прав на этот тендер",
+		errorMessageKey(ErrCodeNotFound):                "Ресурс не найден",
+		errorMessageKey(ErrCodeTenderNotFound):          "Тендер не найден",
+		errorMessageKey(ErrCodeBidNotFound):             "Предложение не найдено",
+		errorMessageKey(ErrCodeVersionNotFound):         "Версия не найдена",
+		errorMessageKey(ErrCodeReviewsNotFound):         "Отзывы не найдены",
+		errorMessageKey(ErrCodeAttachmentNotFound):      "Вложение не найдено",
+		errorMessageKey(ErrCodeLotNotFound):             "Лот не найден",
+		errorMessageKey(ErrCodeLotNotOpen):              "Лот уже присужден или отменен",
+		errorMessageKey(ErrCodeTenderClosed):            "Тендер закрыт",
+		errorMessageKey(ErrCodeCriterionNotFound):       "Критерий оценки не найден",
+		errorMessageKey(ErrCodeEvaluationStarted):       "Оценка предложений уже началась",
+		errorMessageKey(ErrCodeBidsSealed):              "Предложения закрытого тендера еще не раскрыты",
+		errorMessageKey(ErrCodeSubmissionClosed):        "Прием предложений закончен",
+		errorMessageKey(ErrCodeAuctionNotFound):         "Аукцион по тендеру не назначен",
+		errorMessageKey(ErrCodeAuctionNotRunning):       "Торги не идут",
+		errorMessageKey(ErrCodeOfferTooHigh):            "Цена недостаточно снижена",
+		errorMessageKey(ErrCodeQuestionNotFound):        "Вопрос не найден",
+		errorMessageKey(ErrCodeInvitationNotFound):      "Приглашение не найдено",
+		errorMessageKey(ErrCodeForbiddenNotInvited):     "Тендер доступен только по приглашению",
+		errorMessageKey(ErrCodeSupplierNotFound):        "Поставщик не найден в списке организации",
+		errorMessageKey(ErrCodeSupplierBlacklisted):     "Поставщик в черном списке организации",
+		errorMessageKey(ErrCodeSupplierNotQualified):    "Поставщик не квалифицирован организацией",
+		errorMessageKey(ErrCodeConflictOfInterest):      "Конфликт интересов",
+		errorMessageKey(ErrCodeFeedbackNotFound):        "Отзыв не найден",
+		errorMessageKey(ErrCodeFeedbackExists):          "Отзыв уже оставлен",
+		errorMessageKey(ErrCodeNotificationNotFound):    "Уведомление не найдено",
+		errorMessageKey(ErrCodeIdempotencyKeyReused):    "Ключ идемпотентности уже использован для другого запроса",
+		errorMessageKey(ErrCodeIdempotencyKeyInUse):     "Запрос с этим ключом идемпотентности еще выполняется",
+		errorMessageKey(ErrCodePayloadTooLarge):         "Слишком большой запрос",
+		errorMessageKey(ErrCodeUnsupportedMediaType):    "Неподдерживаемый тип файла",
+		errorMessageKey(ErrCodeMethodNotAllowed):        "Метод не поддерживается",
+		errorMessageKey(ErrCodeAlreadyExists):           "Ресурс уже существует",
+		errorMessageKey(ErrCodeInternal):                "Внутренняя ошибка сервера",
+		errorMessageKey(ErrCodeServiceUnavailable):      "Сервис временно недоступен",
+
+		MsgTenderVersionAbsent: "Версия тендера не найдена, данные не изменены.",
+
+		MsgUnknownServiceType: "неизвестный тип услуги",
+		MsgUsernameRequired:   "необходимо указать username",
+		MsgVersionMin:         "версия должна быть не меньше 1",
+		MsgRequired:           "обязательное поле",
+		MsgRequiredField:      "не заполнено обязательное поле '%s'",
+		MsgInvalidField:       "недопустимое значение поля '%s'",
+		MsgMaxLength:          "длина превышает %d символов",
+		MsgMustBeUUID:         "должно быть корректным UUID",
+		MsgRequiredUUID:       "обязательное поле, должно быть корректным UUID",
+		MsgOneOf:              "допустимые значения: %s",
+		MsgRFC3339:            "ожидается дата и время в формате RFC3339",
+		MsgMinLength:          "длина должна быть не меньше %d символов",
+		MsgMinimum:            "должно быть не меньше %v",
+		MsgMaximum:            "должно быть не больше %v",
+		MsgMinItems:           "должно содержать не меньше %d элементов",
+		MsgMaxItems:           "должно содержать не больше %d элементов",
+		MsgUniqueItems:        "элементы не должны повторяться",
+		MsgType:               "ожидается значение типа %s",
+		MsgFormat:             "ожидается значение в формате %s",
+		MsgPattern:            "не соответствует шаблону %s",
+		MsgInvalidValue:       "недопустимое значение",
+
+		MsgImportNoRows:         "файл не содержит строк",
+		MsgImportTooManyRows:    "файл содержит больше %d строк",
+		MsgImportMalformedRow:   "строка не разобрана: %s",
+		MsgImportUnknownColumn:  "неизвестная колонка %q",
+		MsgImportUserNotFound:   "пользователь %s не найден",
+		MsgImportNotResponsible: "пользователь %s не является ответственным за организацию",
+
+		MsgAttachmentTooLarge:     "размер файла превышает %d байт",
+		MsgAttachmentTypeAllowed:  "допустимые файлы: %s",
+		MsgAttachmentNameRequired: "у файла должно быть имя",
+
+		MsgMoneyCurrency:         "неизвестный код валюты %q, ожидается код ISO 4217 заглавными буквами",
+		MsgMoneyPositive:         "сумма должна быть больше нуля",
+		MsgMoneyTooLarge:         "сумма должна быть меньше %s",
+		MsgMoneyScale:            "для валюты %s допускается не больше %d знаков после запятой",
+		MsgPriceCurrencyMismatch: "цена предложения должна быть в валюте бюджета тендера (%s)",
+		MsgPriceExceedsBudget:    "цена предложения превышает бюджет тендера %s %s",
+
+		MsgLotDuplicate:      "лот %s указан несколько раз",
+		MsgPriceExceedsLots:  "цена предложения превышает суммарный бюджет выбранных лотов %s %s",
+		MsgLotUnknown:        "лот %s не относится к тендеру",
+		MsgLotNotOpen:        "лот %s уже присужден или отменен",
+		MsgLotRequired:       "предложение подано на несколько лотов, укажите lotId",
+		MsgLotNotInBid:       "предложение не подано на лот %s",
+		MsgLotsRequired:      "у тендера есть лоты, укажите в lotIds хотя бы один",
+		MsgLotsAbsent:        "у тендера нет лотов, lotIds указывать не нужно",
+		MsgLotsFrozen:        "лоты можно добавлять только до публикации тендера",
+		MsgLotBudgetCurrency: "бюджет лота должен быть в валюте бюджета тендера (%s)",
+		MsgTenderClosed:      "тендер уже закрыт",
+
+		MsgWeightRange:        "вес критерия должен быть от %d до %d",
+		MsgScoreRange:         "оценка должна быть от %d до %d",
+		MsgCriterionDuplicate: "критерий %s указан несколько раз",
+		MsgCriterionUnknown:   "критерий %s не относится к тендеру",
+		MsgCriteriaAbsent:     "у тендера нет критериев оценки",
+		MsgEvaluationStarted:  "предложения тендера уже оцениваются, критерии менять нельзя",
+		MsgBidNotPublished:    "оценивать можно только опубликованные предложения",
+
+		MsgSealedDeadline:   "для закрытого тендера нужен срок подачи предложений submissionDeadline",
+		MsgDeadlinePast:     "срок подачи предложений уже прошел",
+		MsgSealingDisabled:  "закрытые тендеры не настроены на сервере",
+		MsgBidsSealed:       "предложения будут раскрыты после окончания приема %s",
+		MsgSubmissionClosed: "прием предложений закончился %s",
+
+		MsgAuctionWindow:          "окончание торгов должно быть позже начала",
+		MsgAuctionCurrency:        "начальная цена должна быть в валюте шага %s",
+		MsgAuctionExtensionRange:  "значение должно быть от %d до %d секунд",
+		MsgAuctionDelivery:        "аукцион проводится только по тендерам на поставку (Delivery)",
+		MsgAuctionSealed:          "по закрытому тендеру аукцион не проводится",
+		MsgAuctionLots:            "аукцион проводится только по тендерам без лотов",
+		MsgAuctionStartPast:       "начало торгов уже прошло",
+		MsgAuctionExists:          "аукцион по тендеру уже назначен",
+		MsgAuctionNotStarted:      "торги начнутся %s",
+		MsgAuctionEnded:           "торги закончились %s",
+		MsgAuctionDecrement:       "цена должна быть не выше %s %s: лучшая цена минус минимальный шаг",
+		MsgAuctionStartPrice:      "первая цена должна быть не выше начальной %s %s",
+		MsgAuctionBidTender:       "предложение относится к другому тендеру",
+		MsgAuctionBidNotPublished: "торговаться можно только опубликованным предложением",
+		MsgAuctionNotParticipant:  "за торгами следят только участники и ответственные за тендер",
+		MsgAuctionPriceEdit:       "цена предложения по тендеру с аукционом меняется только ставками",
+
+		MsgQuestionNotPublished: "вопросы задаются только по опубликованным тендерам",
+		MsgQuestionAnswered:     "на вопрос уже дан ответ",
+		MsgQuestionBumpPrivate:  "версия тендера меняется только публичным ответом",
+
+		MsgInvitationInvitee:      "укажите либо организацию, либо пользователя",
+		MsgInvitationPublicTender: "приглашения рассылаются только по тендерам по приглашениям",
+		MsgInvitationOwnOrg:       "организация тендера не приглашается к нему",
+		MsgInvitationExists:       "приглашение уже отправлено",
+		MsgInvitationNotPending:   "приглашение уже принято или отклонено",
+		MsgInvitationRevoked:      "приглашение отозвано",
+		MsgInvitationNotAccepted:  "предложение подается после принятия приглашения",
+		MsgInvitationNotAddressee: "приглашение адресовано другому участнику",
+
+		MsgNotOrganizationResponsible:   "пользователь не является ответственным за организацию",
+		MsgSupplierSubject:              "укажите ровно одно из полей supplierOrganizationId и supplierUsername",
+		MsgSupplierReasonRequired:       "для черного списка укажите причину",
+		MsgSupplierOwnOrg:               "организация не может быть своим поставщиком",
+		MsgSupplierExpiresPast:          "срок действия должен быть в будущем",
+		MsgSupplierBlacklisted:          "поставщик в черном списке организации: %s",
+		MsgSupplierBlacklistedUntil:     "поставщик в черном списке организации до %[2]s: %[1]s",
+		MsgSupplierNotQualified:         "организация принимает предложения только от квалифицированных поставщиков",
+		MsgSupplierQualificationExpired: "квалификация поставщика истекла %s",
+
+		MsgConflictAuthorResponsible: "%s отвечает за организацию тендера и участвует в нем на стороне участника",
+		MsgConflictSameOrganization:  "от той же организации уже подано предложение %s",
+		MsgConflictSelfEvaluation:    "%s принимает решение по предложению своей стороны",
+
+		MsgFeedbackRating:       "оценка должна быть от 1 до 5",
+		MsgFeedbackTagDuplicate: "тег %s указан дважды",
+		MsgFeedbackNoChanges:    "укажите хотя бы одно из полей feedback, rating и tags",
+		MsgFeedbackExists:       "вы уже оставили отзыв на это предложение, его можно изменить или отозвать",
+		MsgFeedbackNotFound:     "у вас нет действующего отзыва на это предложение",
+
+		MsgNotificationTypeDuplicate:   "тип события %s указан дважды",
+		MsgNotificationBidCreated:      "Новое предложение по тендеру «%s»",
+		MsgNotificationBidApproved:     "Ваше предложение по тендеру «%s» одобрено",
+		MsgNotificationBidRejected:     "Ваше предложение по тендеру «%s» отклонено",
+		MsgNotificationTenderClosed:    "Тендер «%s», в котором вы участвуете, закрыт",
+		MsgNotificationAuctionFinished: "Аукцион по тендеру «%s» завершен",
+		MsgNotificationBidsRevealed:    "Предложения по закрытому тендеру «%s» раскрыты",
+		MsgNotificationEmailInvalid:    "некорректный адрес почты %s",
+	},
+	LocaleEN: {
+		errorMessageKey(ErrCodeInvalidParameter):        "Invalid request format or parameters",
+		errorMessageKey(ErrCodeMissingParameter):        "A required parameter is missing",
+		errorMessageKey(ErrCodeValidationFailed):        "Request data failed validation",
+		errorMessageKey(ErrCodeInvalidID):               "Invalid identifier, a UUID is expected",
+		errorMessageKey(ErrCodeInvalidStatus):           "Invalid status",
+		errorMessageKey(ErrCodeUserNotFound):            "User does not exist or is invalid",
+		errorMessageKey(ErrCodeOrganizationNotFound):    "Organization does not exist or is invalid",
+		errorMessageKey(ErrCodeForbiddenNotResponsible): "Insufficient permissions: the user is not responsible for the tender's organization",
+		errorMessageKey(ErrCodeForbiddenNotAuthor):      "Insufficient permissions: the user is not the author of the bid",
+		errorMessageKey(ErrCodeForbiddenOrganization):   "Insufficient permissions: the organization has no rights to this tender",
+		errorMessageKey(ErrCodeNotFound):                "Resource not found",
+		errorMessageKey(ErrCodeTenderNotFound):          "Tender not found",
+		errorMessageKey(ErrCodeBidNotFound):             "Bid not found",
+		errorMessageKey(ErrCodeVersionNotFound):         "Version not found",
+		errorMessageKey(ErrCodeReviewsNotFound):         "Reviews not found",
+		errorMessageKey(ErrCodeAttachmentNotFound):      "Attachment not found",
+		errorMessageKey(ErrCodeLotNotFound):             "Lot not found",
+		errorMessageKey(ErrCodeLotNotOpen):              "The lot is already awarded or cancelled",
+		errorMessageKey(ErrCodeTenderClosed):            "The tender is closed",
+		errorMessageKey(ErrCodeCriterionNotFound):       "Evaluation criterion not found",
+		errorMessageKey(ErrCodeEvaluationStarted):       "Bid evaluation has already started",
+		errorMessageKey(ErrCodeBidsSealed):              "Bids of the sealed tender are not revealed yet",
+		errorMessageKey(ErrCodeSubmissionClosed):        "Bid submission is closed",
+		errorMessageKey(ErrCodeAuctionNotFound):         "The tender has no auction",
+		errorMessageKey(ErrCodeAuctionNotRunning):       "The auction is not running",
+		errorMessageKey(ErrCodeOfferTooHigh):            "The price is not low enough",
+		errorMessageKey(ErrCodeQuestionNotFound):        "Question not found",
+		errorMessageKey(ErrCodeInvitationNotFound):      "Invitation not found",
+		errorMessageKey(ErrCodeForbiddenNotInvited):     "The tender is open by invitation only",
+		errorMessageKey(ErrCodeSupplierNotFound):        "Supplier not found in the organization's list",
+		errorMessageKey(ErrCodeSupplierBlacklisted):     "The supplier is blacklisted by the organization",
+		errorMessageKey(ErrCodeSupplierNotQualified):    "The supplier is not qualified by the organization",
+		errorMessageKey(ErrCodeConflictOfInterest):      "Conflict of interest",
+		errorMessageKey(ErrCodeFeedbackNotFound):        "Feedback not found",
+		errorMessageKey(ErrCodeFeedbackExists):          "Feedback already left",
+		errorMessageKey(ErrCodeNotificationNotFound):    "Notification not found",
+		errorMessageKey(ErrCodeIdempotencyKeyReused):    "The idempotency key was already used for a different request",
+		errorMessageKey(ErrCodeIdempotencyKeyInUse):     "A request with this idempotency key is still in progress",
+		errorMessageKey(ErrCodePayloadTooLarge):         "Request is too large",
+		errorMessageKey(ErrCodeUnsupportedMediaType):    "Unsupported file type",
+		errorMessageKey(ErrCodeMethodNotAllowed):        "Method not allowed",
+		errorMessageKey(ErrCodeAlreadyExists):           "Resource already exists",
+		errorMessageKey(ErrCodeInternal):                "Internal server error",
+		errorMessageKey(ErrCodeServiceUnavailable):      "Service temporarily unavailable",
+
+		MsgTenderVersionAbsent: "Tender version not found, nothing was changed.",
+
+		MsgUnknownServiceType: "unknown service type",
+		MsgUsernameRequired:   "username is required",
+		MsgVersionMin:         "version must be at least 1",
+		MsgRequired:           "is required",
+		MsgRequiredField:      "required field '%s' is missing",
+		MsgInvalidField:       "invalid value of field '%s'",
+		MsgMaxLength:          "exceeds the maximum length of %d characters",
+		MsgMustBeUUID:         "must be a valid UUID",
+		MsgRequiredUUID:       "is required and must be a valid UUID",
+		MsgOneOf:              "must be one of %s",
+		MsgRFC3339:            "must be a date and time in RFC3339 format",
+		MsgMinLength:          "must be at least %d characters long",
+		MsgMinimum:            "must be at least %v",
+		MsgMaximum:            "must be at most %v",
+		MsgMinItems:           "must contain at least %d items",
+		MsgMaxItems:           "must contain at most %d items",
+		MsgUniqueItems:        "must not contain duplicate items",
+		MsgType:               "must be of type %s",
+		MsgFormat:             "must be in %s format",
+		MsgPattern:            "does not match the pattern %s",
+		MsgInvalidValue:       "invalid value",
+
+		MsgImportNoRows:         "the file contains no rows",
+		MsgImportTooManyRows:    "the file contains more than %d rows",
+		MsgImportMalformedRow:   "the row cannot be parsed: %s",
+		MsgImportUnknownColumn:  "unknown column %q",
+		MsgImportUserNotFound:   "user %s not found",
+		MsgImportNotResponsible: "user %s is not responsible for the organization",
+
+		MsgAttachmentTooLarge:     "the file is larger than %d bytes",
+		MsgAttachmentTypeAllowed:  "allowed files: %s",
+		MsgAttachmentNameRequired: "the file must have a name",
+
+		MsgMoneyCurrency:         "unknown currency code %q, an upper-case ISO 4217 code is expected",
+		MsgMoneyPositive:         "the amount must be greater than zero",
+		MsgMoneyTooLarge:         "the amount must be less than %s",
+		MsgMoneyScale:            "%s amounts allow at most %d decimal places",
+		MsgPriceCurrencyMismatch: "the bid price must be in the currency of the tender budget (%s)",
+		MsgPriceExceedsBudget:    "the bid price exceeds the tender budget of %s %s",
+
+		MsgLotDuplicate:      "lot %s is listed more than once",
+		MsgPriceExceedsLots:  "the bid price exceeds the total budget of the chosen lots of %s %s",
+		MsgLotUnknown:        "lot %s does not belong to the tender",
+		MsgLotNotOpen:        "lot %s is already awarded or cancelled",
+		MsgLotRequired:       "the bid covers several lots, lotId is required",
+		MsgLotNotInBid:       "the bid does not cover lot %s",
+		MsgLotsRequired:      "the tender has lots, lotIds must list at least one",
+		MsgLotsAbsent:        "the tender has no lots, lotIds must be empty",
+		MsgLotsFrozen:        "lots can only be added before the tender is published",
+		MsgLotBudgetCurrency: "the lot budget must be in the currency of the tender budget (%s)",
+		MsgTenderClosed:      "the tender is already closed",
+
+		MsgWeightRange:        "the criterion weight must be between %d and %d",
+		MsgScoreRange:         "the score must be between %d and %d",
+		MsgCriterionDuplicate: "criterion %s is listed more than once",
+		MsgCriterionUnknown:   "criterion %s does not belong to the tender",
+		MsgCriteriaAbsent:     "the tender has no evaluation criteria",
+		MsgEvaluationStarted:  "bids of the tender are already being scored, criteria can no longer change",
+		MsgBidNotPublished:    "only published bids can be scored",
+
+		MsgSealedDeadline:   "a sealed tender needs a submissionDeadline",
+		MsgDeadlinePast:     "the submission deadline has already passed",
+		MsgSealingDisabled:  "sealed tenders are not configured on this server",
+		MsgBidsSealed:       "bids are revealed once submissions close at %s",
+		MsgSubmissionClosed: "submissions closed at %s",
+
+		MsgAuctionWindow:          "the auction must end after it starts",
+		MsgAuctionCurrency:        "the start price must be in %s, the currency of the decrement",
+		MsgAuctionExtensionRange:  "the value must be between %d and %d seconds",
+		MsgAuctionDelivery:        "auctions are run only on Delivery tenders",
+		MsgAuctionSealed:          "a sealed tender cannot be auctioned",
+		MsgAuctionLots:            "auctions are run only on tenders without lots",
+		MsgAuctionStartPast:       "the auction start has already passed",
+		MsgAuctionExists:          "the tender already has an auction",
+		MsgAuctionNotStarted:      "the auction starts at %s",
+		MsgAuctionEnded:           "the auction ended at %s",
+		MsgAuctionDecrement:       "the price must be at most %s %s: the best price less the minimal decrement",
+		MsgAuctionStartPrice:      "the first price must be at most the start price %s %s",
+		MsgAuctionBidTender:       "the bid belongs to another tender",
+		MsgAuctionBidNotPublished: "only a published bid can take part in the auction",
+		MsgAuctionNotParticipant:  "only bidders and responsibles of the tender can follow the auction",
+		MsgAuctionPriceEdit:       "the price of a bid on an auctioned tender changes only through offers",
+
+		MsgQuestionNotPublished: "questions can be asked only about published tenders",
+		MsgQuestionAnswered:     "the question has already been answered",
+		MsgQuestionBumpPrivate:  "only a public answer can bump the tender version",
+
+		MsgInvitationInvitee:      "specify either an organization or a user",
+		MsgInvitationPublicTender: "invitations are sent only for private tenders",
+		MsgInvitationOwnOrg:       "the organization of the tender cannot be invited to it",
+		MsgInvitationExists:       "the invitation has already been sent",
+		MsgInvitationNotPending:   "the invitation has already been accepted or declined",
+		MsgInvitationRevoked:      "the invitation has been revoked",
+		MsgInvitationNotAccepted:  "accept the invitation before submitting a bid",
+		MsgInvitationNotAddressee: "the invitation is addressed to someone else",
+
+		MsgNotOrganizationResponsible:   "the user is not responsible for the organization",
+		MsgSupplierSubject:              "set exactly one of supplierOrganizationId and supplierUsername",
+		MsgSupplierReasonRequired:       "a blacklist entry needs a reason",
+		MsgSupplierOwnOrg:               "an organization cannot be its own supplier",
+		MsgSupplierExpiresPast:          "the expiry must be in the future",
+		MsgSupplierBlacklisted:          "the supplier is blacklisted by the organization: %s",
+		MsgSupplierBlacklistedUntil:     "the supplier is blacklisted by the organization until %[2]s: %[1]s",
+		MsgSupplierNotQualified:         "the organization only accepts bids from qualified suppliers",
+		MsgSupplierQualificationExpired: "the supplier's qualification expired at %s",
+
+		MsgConflictAuthorResponsible: "%s is responsible for the tender's organization and is also on the bidding side",
+		MsgConflictSameOrganization:  "the same organization has already submitted bid %s",
+		MsgConflictSelfEvaluation:    "%s is deciding on a bid from their own side",
+
+		MsgFeedbackRating:       "the rating must be from 1 to 5",
+		MsgFeedbackTagDuplicate: "tag %s is given twice",
+		MsgFeedbackNoChanges:    "set at least one of feedback, rating and tags",
+		MsgFeedbackExists:       "you have already left feedback on this bid; edit or retract it instead",
+		MsgFeedbackNotFound:     "you have no current feedback on this bid",
+
+		MsgNotificationTypeDuplicate:   "event type %s is given twice",
+		MsgNotificationBidCreated:      "New bid on tender \"%s\"",
+		MsgNotificationBidApproved:     "Your bid on tender \"%s\" was approved",
+		MsgNotificationBidRejected:     "Your bid on tender \"%s\" was rejected",
+		MsgNotificationTenderClosed:    "Tender \"%s\" you bid on was closed",
+		MsgNotificationAuctionFinished: "The auction of tender \"%s\" has finished",
+		MsgNotificationBidsRevealed:    "The bids on sealed tender \"%s\" were revealed",
+		MsgNotificationEmailInvalid:    "%s is not a valid email address",
+	},
+}
+
+// Translate returns the message for key in locale. Missing translations fall back to
+// the fallback locale and then to the key itself so that a response is never empty.
+func Translate(locale Locale, key MessageKey, args ...any) string {
+	msg, ok := catalog[locale][key]
+	if !ok {
+		msg, ok = catalog[fallbackLocale][key]
+	}
+	if !ok {
+		return string(key)
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// T translates key into the locale negotiated for the request ctx belongs to.
+func T(ctx context.Context, key MessageKey, args ...any) string {
+	return Translate(LocaleFromContext(ctx), key, args...)
+}
+
+// LocalizedError is an error whose client visible text comes from the catalog. Its Error
+// method renders the English message, which is what ends up in logs.
+type LocalizedError struct {
+	Key  MessageKey
+	Args []any
+}
+
+func NewLocalizedError(key MessageKey, args ...any) *LocalizedError {
+	return &LocalizedError{Key: key, Args: args}
+}
+
+func (e *LocalizedError) Error() string {
+	return Translate(LocaleEN, e.Key, e.Args...)
+}
+
+// Localize renders the error in the given locale.
+func (e *LocalizedError) Localize(locale Locale) string {
+	return Translate(locale, e.Key, e.Args...)
+}
+
+type localeContextKey struct{}
+
+// LocaleFromContext returns the locale negotiated by the Localize middleware.
+func LocaleFromContext(ctx context.Context) Locale {
+	if l, ok := ctx.Value(localeContextKey{}).(Locale); ok {
+		return l
+	}
+	return fallbackLocale
+}
+
+// ContextWithLocale returns a copy of ctx carrying locale.
+func ContextWithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// Localize negotiates the response language from the Accept-Language header. Requests
+// without the header, or accepting none of the supported languages, get defaultLocale.
+func Localize(inner http.Handler, defaultLocale Locale) http.Handler {
+	tags := []language.Tag{language.Make(string(defaultLocale))}
+	for _, l := range supportedLocales {
+		if l != defaultLocale {
+			tags = append(tags, language.Make(string(l)))
+		}
+	}
+	matcher := language.NewMatcher(tags)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := negotiateLocale(matcher, r.Header.Get("Accept-Language"), defaultLocale)
+		w.Header().Set("Content-Language", string(locale))
+		inner.ServeHTTP(w, r.WithContext(ContextWithLocale(r.Context(), locale)))
+	})
+}
+
+func negotiateLocale(matcher language.Matcher, header string, defaultLocale Locale) Locale {
+	if header == "" {
+		return defaultLocale
+	}
+	accepted, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(accepted) == 0 {
+		return defaultLocale
+	}
+	tag, _, confidence := matcher.Match(accepted...)
+	if confidence == language.No {
+		return defaultLocale
+	}
+	base, _ := tag.Base()
+	if l, err := ParseLocale(base.String()); err == nil {
+		return l
+	}
+	return defaultLocale
+}
@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestCatalogComplete fails when a message exists in one language but not in another.
+func TestCatalogComplete(t *testing.T) {
+	keys := make(map[MessageKey]struct{})
+	for _, messages := range catalog {
+		for key := range messages {
+			keys[key] = struct{}{}
+		}
+	}
+
+	for _, locale := range supportedLocales {
+		messages, ok := catalog[locale]
+		if !ok {
+			t.Errorf("locale %q has no catalog", locale)
+			continue
+		}
+		for key := range keys {
+			msg, ok := messages[key]
+			if !ok {
+				t.Errorf("locale %q: missing message %q", locale, key)
+				continue
+			}
+			if strings.TrimSpace(msg) == "" {
+				t.Errorf("locale %q: empty message %q", locale, key)
+			}
+		}
+	}
+
+	for locale := range catalog {
+		if _, err := ParseLocale(string(locale)); err != nil {
+			t.Errorf("catalog has unsupported locale %q", locale)
+		}
+	}
+}
+
+// TestCatalogFormatVerbs makes sure every translation expects the same arguments.
+func TestCatalogFormatVerbs(t *testing.T) {
+	for key, want := range catalog[fallbackLocale] {
+		for _, locale := range supportedLocales {
+			got, ok := catalog[locale][key]
+			if !ok {
+				continue
+			}
+			if countVerbs(got) != countVerbs(want) {
+				t.Errorf("locale %q: message %q has %d format verbs, %q has %d",
+					locale, key, countVerbs(got), fallbackLocale, countVerbs(want))
+			}
+		}
+	}
+}
+
+func TestErrorCodesHaveMessages(t *testing.T) {
+	for code := range errorCodeStatus {
+		for _, locale := range supportedLocales {
+			if _, ok := catalog[locale][errorMessageKey(code)]; !ok {
+				t.Errorf("locale %q: no title for error code %s", locale, code)
+			}
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		defaultLocale Locale
+		want          Locale
+	}{
+		{name: "no header", header: "", defaultLocale: LocaleRU, want: LocaleRU},
+		{name: "no header english default", header: "", defaultLocale: LocaleEN, want: LocaleEN},
+		{name: "english", header: "en-US,en;q=0.9", defaultLocale: LocaleRU, want: LocaleEN},
+		{name: "russian", header: "ru-RU", defaultLocale: LocaleEN, want: LocaleRU},
+		{name: "quality order", header: "de;q=1.0, en;q=0.5, ru;q=0.8", defaultLocale: LocaleEN, want: LocaleRU},
+		{name: "unsupported", header: "fr-FR", defaultLocale: LocaleEN, want: LocaleEN},
+		{name: "malformed", header: ";;;", defaultLocale: LocaleRU, want: LocaleRU},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Locale
+			handler := Localize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = LocaleFromContext(r.Context())
+			}), tt.defaultLocale)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/ping", nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got != tt.want {
+				t.Errorf("locale = %q, want %q", got, tt.want)
+			}
+			if cl := rec.Header().Get("Content-Language"); cl != string(tt.want) {
+				t.Errorf("Content-Language = %q, want %q", cl, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblemIsLocalized(t *testing.T) {
+	handler := Localize(NotFoundHandler(), LocaleRU)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/unknown", nil)
+	req.Header.Set("Accept-Language", "en")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	want := Translate(LocaleEN, errorMessageKey(ErrCodeNotFound))
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("body %s does not contain %q", rec.Body.String(), want)
+	}
+}
+
+func countVerbs(msg string) int {
+	return strings.Count(msg, "%") - 2*strings.Count(msg, "%%")
+}
+
+func TestErrorHandlerDetailAndLog(t *testing.T) {
+	var logs bytes.Buffer
+	handler := WithLogger(Localize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("fail") {
+		case "validation":
+			DefaultErrorHandler(w, r, &ValidationError{Field: "status"}, nil)
+		default:
+			DefaultErrorHandler(w, r, errors.New("connection refused"), nil)
+		}
+	}), LocaleEN), slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/tenders?fail=validation", nil))
+	if want := Translate(LocaleEN, MsgInvalidField, "status"); !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("body %s does not contain %q", rec.Body.String(), want)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("a client error was logged: %s", logs.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/tenders?fail=internal", nil))
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("log %q: %v", logs.String(), err)
+	}
+	want := map[string]any{"code": string(ErrCodeInternal), "status": float64(http.StatusInternalServerError),
+		"path": "/api/tenders", "error": "connection refused"}
+	for field, value := range want {
+		if entry[field] != value {
+			t.Errorf("log field %s = %v, want %v", field, entry[field], value)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client chosen key that makes a retried request safe.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from an earlier request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyKeyMaxLength = 255
+	// idempotencyLockTimeout is how long a key stays reserved by a request that never
+	// completed, for example because its instance died; after that the key may be reused.
+	idempotencyLockTimeout = time.Minute
+)
+
+// idempotentOperations are the routes whose retries would otherwise create duplicates.
+var idempotentOperations = map[string]bool{
+	"CreateTender":      true,
+	"CreateBid":         true,
+	"SubmitBidDecision": true,
+}
+
+// IdempotencyStore remembers the responses of requests sent with an Idempotency-Key so that
+// a retry gets the original response instead of repeating the change.
+type IdempotencyStore struct {
+	pg  *Postgres
+	ttl time.Duration
+	log *slog.Logger
+}
+
+func NewIdempotencyStore(pg *Postgres, cfg IdempotencyConfig, log *slog.Logger) *IdempotencyStore {
+	return &IdempotencyStore{pg: pg, ttl: cfg.TTL, log: log.With(slog.String("op", "IdempotencyStore"))}
+}
+
+// storedResponse is a key as found by a request that could not reserve it. Status is zero
+// while the first request is still running.
+type storedResponse struct {
+	RequestHash []byte
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// Middleware handles the Idempotency-Key of the create and decision routes. The first request
+// with a key reserves it and its response is stored with a hash of the request; a retry with
+// the same request gets the stored response, while a different request with the same key is
+// rejected with 422. Server errors are not stored, so the request may be retried with the
+// same key. Requests without a key and other routes are passed through.
+func (s *IdempotencyStore) Middleware(next http.Handler) http.Handler {
+	return RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		route := mux.CurrentRoute(r)
+		if key == "" || route == nil || !idempotentOperations[route.GetName()] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > idempotencyKeyMaxLength {
+			DefaultErrorHandler(w, r, &ParsingError{Param: IdempotencyKeyHeader, Err: NewLocalizedError(MsgMaxLength, idempotencyKeyMaxLength)}, nil)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			DefaultErrorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// The response is stored even if the client has gone away meanwhile.
+		ctx := context.WithoutCancel(r.Context())
+		operation, hash := route.GetName(), requestHash(r, body)
+		stored, reserved, err := s.reserve(ctx, operation, key, hash)
+		if err != nil {
+			s.log.Error("failed to reserve the idempotency key", slog.String("operation", operation), slog.Any("error", err))
+			DefaultErrorHandler(w, r, NewAPIError(ErrCodeInternal, err), nil)
+			return
+		}
+		if !reserved {
+			s.replay(w, r, stored, hash)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if rec.status >= http.StatusInternalServerError {
+			err = s.release(ctx, operation, key)
+		} else {
+			err = s.complete(ctx, operation, key, rec)
+		}
+		if err != nil {
+			// The key expires on its own; until then retries are told it is in use.
+			s.log.Error("failed to store the idempotent response", slog.String("operation", operation), slog.Any("error", err))
+		}
+		rec.flush()
+	}))
+}
+
+// replay answers a request whose key is already taken.
+func (s *IdempotencyStore) replay(w http.ResponseWriter, r *http.Request, stored *storedResponse, hash []byte) {
+	switch {
+	case stored == nil || stored.Status == 0:
+		DefaultErrorHandler(w, r, NewAPIError(ErrCodeIdempotencyKeyInUse, nil), nil)
+	case !bytes.Equal(stored.RequestHash, hash):
+		DefaultErrorHandler(w, r, NewAPIError(ErrCodeIdempotencyKeyReused, nil), nil)
+	default:
+		if stored.ContentType != "" {
+			w.Header().Set("Content-Type", stored.ContentType)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(stored.Status)
+		_, _ = w.Write(stored.Body)
+	}
+}
+
+// reserve takes the key for this request, replacing an expired key or the reservation of a
+// request that never completed. If the key is taken, the stored response is returned instead;
+// it is nil if the key was released meanwhile.
+func (s *IdempotencyStore) reserve(ctx context.Context, operation string, key string, hash []byte) (*storedResponse, bool, error) {
+	var reserved bool
+	err := s.pg.Pool.QueryRow(ctx, `
+	INSERT INTO idempotency_keys (operation, key, request_hash, expires_at)
+	VALUES ($1, $2, $3, clock_timestamp() + $4 * INTERVAL '1 millisecond')
+	ON CONFLICT (operation, key) DO UPDATE
+	SET request_hash = EXCLUDED.request_hash, status = NULL, content_type = NULL, body = NULL,
+		created_at = clock_timestamp(), expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at <= clock_timestamp()
+		OR (idempotency_keys.status IS NULL AND idempotency_keys.created_at <= clock_timestamp() - $5 * INTERVAL '1 millisecond')
+	RETURNING true`,
+		operation, key, hash, s.ttl.Milliseconds(), idempotencyLockTimeout.Milliseconds()).Scan(&reserved)
+	if err == nil {
+		return nil, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+
+	var stored storedResponse
+	var status *int
+	var contentType *string
+	err = s.pg.Pool.QueryRow(ctx, `
+	SELECT request_hash, status, content_type, body FROM idempotency_keys WHERE operation = $1 AND key = $2`,
+		operation, key).Scan(&stored.RequestHash, &status, &contentType, &stored.Body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if status != nil {
+		stored.Status = *status
+	}
+	if contentType != nil {
+		stored.ContentType = *contentType
+	}
+	return &stored, false, nil
+}
+
+// complete stores the response of the request that reserved the key.
+func (s *IdempotencyStore) complete(ctx context.Context, operation string, key string, rec *responseRecorder) error {
+	_, err := s.pg.Pool.Exec(ctx, `
+	UPDATE idempotency_keys SET status = $3, content_type = $4, body = $5 WHERE operation = $1 AND key = $2`,
+		operation, key, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes())
+	return err
+}
+
+// release frees the key of a request that failed, so that it can be retried.
+func (s *IdempotencyStore) release(ctx context.Context, operation string, key string) error {
+	_, err := s.pg.Pool.Exec(ctx, `
+	DELETE FROM idempotency_keys WHERE operation = $1 AND key = $2 AND status IS NULL`, operation, key)
+	return err
+}
+
+// Cleanup is a WorkerFunc that deletes expired keys. Expired keys are ignored even before
+// they are deleted, so the interval does not affect the TTL.
+func (s *IdempotencyStore) Cleanup(ctx context.Context) error {
+	tag, err := s.pg.Pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= clock_timestamp()`)
+	if err != nil {
+		return err
+	}
+	if n := tag.RowsAffected(); n > 0 {
+		s.log.Debug("expired idempotency keys deleted", slog.Int64("keys", n))
+	}
+	return nil
+}
+
+// requestHash identifies what a request asks for: its method, path, query and body. The
+// query is hashed in canonical order, the body byte for byte.
+func requestHash(r *http.Request, body []byte) []byte {
+	h := sha256.New()
+	for _, part := range []string{r.Method, r.URL.Path, r.URL.Query().Encode()} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestHash(t *testing.T) {
+	hash := func(method, target, body string) []byte {
+		return requestHash(httptest.NewRequest(method, target, nil), []byte(body))
+	}
+	base := hash(http.MethodPut, "/api/bids/1/submit_decision?decision=Approved&username=user1", "")
+
+	if !bytes.Equal(base, hash(http.MethodPut, "/api/bids/1/submit_decision?username=user1&decision=Approved", "")) {
+		t.Error("the order of query parameters changed the hash")
+	}
+	others := map[string][]byte{
+		"decision": hash(http.MethodPut, "/api/bids/1/submit_decision?decision=Rejected&username=user1", ""),
+		"bid":      hash(http.MethodPut, "/api/bids/2/submit_decision?decision=Approved&username=user1", ""),
+		"body":     hash(http.MethodPut, "/api/bids/1/submit_decision?decision=Approved&username=user1", "{}"),
+		"method":   hash(http.MethodPost, "/api/bids/1/submit_decision?decision=Approved&username=user1", ""),
+	}
+	for name, other := range others {
+		if bytes.Equal(base, other) {
+			t.Errorf("a different %s gave the same hash", name)
+		}
+	}
+}
+
+// stubRoutes answers every route with the body it received.
+type stubRoutes struct{}
+
+func (stubRoutes) Routes() Routes {
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+	return Routes{
+		"CreateTender": Route{http.MethodPost, "/api/tenders/new", echo},
+		"CheckServer":  Route{http.MethodGet, "/api/ping", echo},
+	}
+}
+
+func TestIdempotencyMiddlewarePassThrough(t *testing.T) {
+	// Without a database: none of these requests may touch the store.
+	store := NewIdempotencyStore(nil, DefaultConfig().Idempotency, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	router := NewRouter(stubRoutes{})
+	router.Use(store.Middleware)
+
+	cases := []struct {
+		name, method, path, key string
+		want                    int
+	}{
+		{"no key", http.MethodPost, "/api/tenders/new", "", http.StatusOK},
+		{"other route", http.MethodGet, "/api/ping", "key-1", http.StatusOK},
+		{"key too long", http.MethodPost, "/api/tenders/new", strings.Repeat("k", idempotencyKeyMaxLength+1), http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(`{"name":"Tender 1"}`))
+		if tc.key != "" {
+			req.Header.Set(IdempotencyKeyHeader, tc.key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.name, rec.Code, rec.Body.String(), tc.want)
+		}
+		if tc.want == http.StatusOK && rec.Body.String() != `{"name":"Tender 1"}` {
+			t.Errorf("%s: the handler got body %q", tc.name, rec.Body.String())
+		}
+	}
+}
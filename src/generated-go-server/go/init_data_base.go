@@ -134,6 +134,12 @@ func InitDataBase(ctx context.Context, pg *Postgres) error {
 		return err
 	}
 
+	// The demo bids are written around the API, so nothing has counted them yet.
+	if err := RecountReputation(ctx, pg); err != nil {
+		log.Printf("Error counting the reputation of the demo bids: %v\n", err)
+		return err
+	}
+
 	log.Println("Database initialized successfully.")
 	return nil
 }
@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// invitationColumns are the columns read by scanInvitation from tender_invitations aliased i.
+const invitationColumns = `i.id, i.tender_id, COALESCE((SELECT name FROM tenders WHERE id = i.tender_id), ''),
+	i.organization_id, COALESCE((SELECT username FROM employee WHERE id = i.user_id), ''), i.status,
+	COALESCE((SELECT username FROM employee WHERE id = i.invited_by), ''), i.created_at, i.updated_at`
+
+// liveInvitationStatuses are the invitations that let the invitee see a private tender.
+// Bidding takes an accepted one.
+var liveInvitationStatuses = []InvitationStatus{INVITATION_PENDING, INVITATION_ACCEPTED}
+
+func scanInvitation(row pgx.Row) (Invitation, error) {
+	var invitation Invitation
+	var id, tenderId uuid.UUID
+	var organizationId *uuid.UUID
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&id, &tenderId, &invitation.TenderName, &organizationId, &invitation.Username,
+		&invitation.Status, &invitation.InvitedBy, &createdAt, &updatedAt)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	invitation.Id = id.String()
+	invitation.TenderId = tenderId.String()
+	if organizationId != nil {
+		invitation.OrganizationId = organizationId.String()
+	}
+	invitation.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	invitation.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	return invitation, nil
+}
+
+// checkInvitationTransition checks that an invitation in status from may move to status to.
+// The invitee accepts a pending invitation and may decline it until the end; the responsibles
+// revoke an invitation in any status. A revoked invitation is final.
+func checkInvitationTransition(from, to InvitationStatus) *APIError {
+	if from == INVITATION_REVOKED {
+		return NewAPIError(ErrCodeInvalidStatus, nil).WithDetail(MsgInvitationRevoked)
+	}
+	switch to {
+	case INVITATION_ACCEPTED:
+		if from != INVITATION_PENDING {
+			return NewAPIError(ErrCodeInvalidStatus, nil).WithDetail(MsgInvitationNotPending)
+		}
+	case INVITATION_DECLINED:
+		if from == INVITATION_DECLINED {
+			return NewAPIError(ErrCodeInvalidStatus, nil).WithDetail(MsgInvitationNotPending)
+		}
+	}
+	return nil
+}
+
+// recordInvitationChange appends the new status of the invitation to the history of the
+// invitation list. The caller holds the tender lock, which numbers the changes.
+func recordInvitationChange(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID, invitationId uuid.UUID, status InvitationStatus, changedBy uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+	INSERT INTO tender_invitation_changes (tender_id, version, invitation_id, status, changed_by)
+	SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4 FROM tender_invitation_changes WHERE tender_id = $1`,
+		tenderId, invitationId, string(status), changedBy)
+	return err
+}
+
+// userInvited reports whether an invitation to the tender in one of statuses is addressed to
+// the user or to an organization the user is responsible for.
+func (s *DefaultAPIService) userInvited(ctx context.Context, tenderId uuid.UUID, userId uuid.UUID, statuses []InvitationStatus) (bool, error) {
+	var invited bool
+	err := s.pg.Pool.QueryRow(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM tender_invitations
+		WHERE tender_id = $1 AND status = ANY($3) AND (user_id = $2 OR organization_id IN (
+			SELECT organization_id FROM organization_responsible WHERE user_id = $2)))`,
+		tenderId, userId, invitationStatusStrings(statuses)).Scan(&invited)
+	return invited, err
+}
+
+// organizationInvited reports whether an invitation to the tender in one of statuses is
+// addressed to the organization.
+func (s *DefaultAPIService) organizationInvited(ctx context.Context, tenderId uuid.UUID, organizationId uuid.UUID, statuses []InvitationStatus) (bool, error) {
+	var invited bool
+	err := s.pg.Pool.QueryRow(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM tender_invitations
+		WHERE tender_id = $1 AND organization_id = $2 AND status = ANY($3))`,
+		tenderId, organizationId, invitationStatusStrings(statuses)).Scan(&invited)
+	return invited, err
+}
+
+// privateTenderForRead lets the responsibles and the invitees that have not declined see a
+// published private tender.
+func (s *DefaultAPIService) privateTenderForRead(ctx context.Context, user *User, tender *Tender) (*Tender, error) {
+	tenderIdUUID, _ := s.ConvertIntoUUID(tender.Id)
+	invited, err := s.userInvited(ctx, tenderIdUUID, user.Id, liveInvitationStatuses)
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInternal, err)
+	}
+	if invited {
+		return tender, nil
+	}
+	err1, err2 := s.userHasRights(ctx, user.Id, tenderIdUUID)
+	if err1 != nil {
+		if errors.Is(err1, ErrUserNoRightsTender) {
+			return nil, NewAPIError(ErrCodeForbiddenNotInvited, err1)
+		}
+		return nil, NewAPIError(ErrCodeInternal, err2)
+	}
+	return tender, nil
+}
+
+// checkInvitedBidder checks that the author of a new bid on a private tender holds an accepted
+// invitation. On a private tender the invitation replaces the usual rights check.
+func (s *DefaultAPIService) checkInvitedBidder(ctx context.Context, tenderId uuid.UUID, authorType BidAuthorType, authorId uuid.UUID) *APIError {
+	var accepted, live bool
+	var err error
+	if authorType == ORGANIZATION {
+		if _, err := s.getOrganizationById(ctx, authorId); err != nil {
+			if errors.Is(err, ErrNoOrganization) {
+				return NewAPIError(ErrCodeOrganizationNotFound, err)
+			}
+			return NewAPIError(ErrCodeInternal, err)
+		}
+		if accepted, err = s.organizationInvited(ctx, tenderId, authorId, []InvitationStatus{INVITATION_ACCEPTED}); err == nil && !accepted {
+			live, err = s.organizationInvited(ctx, tenderId, authorId, liveInvitationStatuses)
+		}
+	} else {
+		if _, err := s.getUserById(ctx, authorId); err != nil {
+			if errors.Is(err, ErrNoUser) {
+				return NewAPIError(ErrCodeUserNotFound, err)
+			}
+			return NewAPIError(ErrCodeInternal, err)
+		}
+		if accepted, err = s.userInvited(ctx, tenderId, authorId, []InvitationStatus{INVITATION_ACCEPTED}); err == nil && !accepted {
+			live, err = s.userInvited(ctx, tenderId, authorId, liveInvitationStatuses)
+		}
+	}
+	switch {
+	case err != nil:
+		return NewAPIError(ErrCodeInternal, err)
+	case accepted:
+		return nil
+	case live:
+		return NewAPIError(ErrCodeForbiddenNotInvited, nil).WithDetail(MsgInvitationNotAccepted)
+	default:
+		return NewAPIError(ErrCodeForbiddenNotInvited, nil)
+	}
+}
+
+func invitationStatusStrings(statuses []InvitationStatus) []string {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	return values
+}
@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInvitationTransitions(t *testing.T) {
+	for _, tc := range []struct {
+		from, to InvitationStatus
+		ok       bool
+	}{
+		{INVITATION_PENDING, INVITATION_ACCEPTED, true},
+		{INVITATION_PENDING, INVITATION_DECLINED, true},
+		{INVITATION_PENDING, INVITATION_REVOKED, true},
+		{INVITATION_ACCEPTED, INVITATION_DECLINED, true},
+		{INVITATION_ACCEPTED, INVITATION_REVOKED, true},
+		{INVITATION_DECLINED, INVITATION_REVOKED, true},
+		{INVITATION_ACCEPTED, INVITATION_ACCEPTED, false},
+		{INVITATION_DECLINED, INVITATION_ACCEPTED, false},
+		{INVITATION_DECLINED, INVITATION_DECLINED, false},
+		{INVITATION_REVOKED, INVITATION_ACCEPTED, false},
+		{INVITATION_REVOKED, INVITATION_REVOKED, false},
+	} {
+		if err := checkInvitationTransition(tc.from, tc.to); (err == nil) != tc.ok {
+			t.Errorf("%s -> %s: got %v, want ok = %v", tc.from, tc.to, err, tc.ok)
+		}
+	}
+}
+
+func TestCreateInvitationRequestValidation(t *testing.T) {
+	router := NewRouter(NewInvitationAPIController(nil))
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{}`, http.StatusBadRequest},
+		{`{"organizationId":"` + importOrgID + `","username":"user2"}`, http.StatusBadRequest},
+		{`{"organizationId":"org"}`, http.StatusBadRequest},
+		{`{"username":"user2","role":"admin"}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/tenders/"+importOrgID+"/invitations?username=user1", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
@@ -23,11 +23,12 @@ func Logger(inner http.Handler, name string) http.Handler {
 		inner.ServeHTTP(w, r)
 
 		log.Printf(
-			"%s %s %s %s",
+			"%s %s %s %s request_id=%s",
 			r.Method,
 			r.RequestURI,
 			name,
 			time.Since(start),
+			RequestIDFromContext(r.Context()),
 		)
 	})
 }
@@ -0,0 +1,261 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// lotColumns are the columns read by scanLot.
+var lotColumns = []string{
+	"id", "tender_id", "name", "description", "budget_amount", "budget_currency", "status",
+	"COALESCE(awarded_bid_id::text, '')", "created_at",
+}
+
+// bidLotIdsColumn selects the lots of the bids row aliased bids, in the order of the tender.
+const bidLotIdsColumn = `ARRAY(
+	SELECT bl.lot_id::text FROM bid_lots bl JOIN lots l ON l.id = bl.lot_id
+	WHERE bl.bid_id = bids.bid_id ORDER BY l.created_at, l.id)`
+
+func scanLot(row pgx.Row) (Lot, error) {
+	var lot Lot
+	var id, tenderId uuid.UUID
+	var budget nullMoney
+	var createdAt time.Time
+
+	err := row.Scan(&id, &tenderId, &lot.Name, &lot.Description, &budget.Amount, &budget.Currency,
+		&lot.Status, &lot.AwardedBidId, &createdAt)
+	if err != nil {
+		return Lot{}, err
+	}
+
+	lot.Id = id.String()
+	lot.TenderId = tenderId.String()
+	lot.Budget = budget.Money()
+	lot.CreatedAt = createdAt.Format(time.RFC3339)
+	return lot, nil
+}
+
+// getTenderLots returns the lots of a tender in the order they were added.
+func (s *DefaultAPIService) getTenderLots(ctx context.Context, tenderId uuid.UUID) ([]Lot, error) {
+	const op = "getTenderLots"
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := s.builder.
+		Select(lotColumns...).
+		From("lots").
+		Where(squirrel.Eq{"tender_id": tenderId}).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		log.Error("failed to fetch lots", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	defer rows.Close()
+
+	lots := []Lot{}
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			log.Error("failed to scan lot", slog.Any("error", err))
+			return nil, ErrSQLQuery
+		}
+		lots = append(lots, lot)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating over rows", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	return lots, nil
+}
+
+// getLot returns ErrNotFound when the tender has no lot with this id.
+func (s *DefaultAPIService) getLot(ctx context.Context, tenderId uuid.UUID, lotId uuid.UUID) (*Lot, error) {
+	const op = "getLot"
+	log := s.log.With(slog.String("op", op))
+
+	sql, args, err := s.builder.
+		Select(lotColumns...).
+		From("lots").
+		Where(squirrel.Eq{"id": lotId, "tender_id": tenderId}).
+		ToSql()
+	if err != nil {
+		log.Error("failed to build SQL query", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+
+	lot, err := scanLot(s.pg.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		log.Error("failed to fetch lot", slog.Any("error", err))
+		return nil, ErrSQLQuery
+	}
+	return &lot, nil
+}
+
+// lotBudgetCurrency is the currency every lot budget of the tender must use: the currency of
+// the tender budget or, for tenders without one, of the first lot with a budget. It is empty
+// when neither exists yet.
+func lotBudgetCurrency(tender *Tender, lots []Lot) string {
+	if tender.Budget != nil {
+		return tender.Budget.Currency
+	}
+	for _, lot := range lots {
+		if lot.Budget != nil {
+			return lot.Budget.Currency
+		}
+	}
+	return ""
+}
+
+// checkLotBudget checks that a lot budget uses the currency of the tender budget and of the
+// other lots.
+func checkLotBudget(budget *Money, tender *Tender, lots []Lot) *APIError {
+	if budget == nil {
+		return nil
+	}
+	if cur := lotBudgetCurrency(tender, lots); cur != "" && cur != budget.Currency {
+		return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgLotBudgetCurrency, cur)
+	}
+	return nil
+}
+
+// checkBidLots checks the lots a new bid is placed on and its price. Bids on a tender with
+// lots must name at least one open lot of it; bids on a tender without lots name none. The
+// price must fit the tender budget and, when every chosen lot has a budget, their sum.
+func checkBidLots(lots []Lot, lotIds []string, price Money, tenderBudget *Money) *APIError {
+	if len(lots) == 0 {
+		if len(lotIds) > 0 {
+			return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgLotsAbsent)
+		}
+		return checkBidPrice(price, tenderBudget)
+	}
+	if len(lotIds) == 0 {
+		return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgLotsRequired)
+	}
+
+	byId := make(map[string]Lot, len(lots))
+	for _, lot := range lots {
+		byId[lot.Id] = lot
+	}
+
+	lotsBudget := &Money{}
+	for _, id := range lotIds {
+		lot, ok := byId[id]
+		if !ok {
+			return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgLotUnknown, id)
+		}
+		if lot.Status != LOT_OPEN {
+			return NewAPIError(ErrCodeLotNotOpen, nil).WithDetail(MsgLotNotOpen, id)
+		}
+
+		if lot.Budget == nil || lotsBudget == nil {
+			lotsBudget = nil
+			continue
+		}
+		lotsBudget.Amount = lotsBudget.Amount.Add(lot.Budget.Amount)
+		lotsBudget.Currency = lot.Budget.Currency
+	}
+
+	if err := checkBidPrice(price, tenderBudget); err != nil {
+		return err
+	}
+	if lotsBudget == nil {
+		return nil
+	}
+	if price.Currency != lotsBudget.Currency {
+		return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgPriceCurrencyMismatch, lotsBudget.Currency)
+	}
+	if price.Amount.GreaterThan(lotsBudget.Amount) {
+		return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgPriceExceedsLots, lotsBudget.Amount.String(), lotsBudget.Currency)
+	}
+	return nil
+}
+
+// lockTender locks the tender row until the end of tx and returns its status. Decisions and
+// lot cancellations of one tender take the lock so that exactly one of them sees the last
+// open lot settled and closes the tender.
+func lockTender(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID) (TenderStatus, error) {
+	var status TenderStatus
+	err := tx.QueryRow(ctx, `SELECT COALESCE(status, '') FROM tenders WHERE id = $1 FOR UPDATE`, tenderId).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return status, err
+}
+
+// closeTenderIfSettled closes the tender once none of its lots is open, which for a tender
+// without lots is right away. It reports whether the tender was closed.
+func closeTenderIfSettled(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+	UPDATE tenders SET status = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND status IS DISTINCT FROM $2
+		AND NOT EXISTS (SELECT 1 FROM lots WHERE tender_id = $1 AND status = $3)`,
+		tenderId, string(CLOSED), string(LOT_OPEN))
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	if err := recordEvent(ctx, tx, eventTenderClosed, tenderId, map[string]any{"tenderId": tenderId}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// awardLot awards the lot to the approved bid and closes the tender when no lot is left
+// open. A bid on a tender without lots has no lot to award, and the tender stays open until
+// its status is changed explicitly. It reports whether the tender was closed.
+func awardLot(ctx context.Context, tx pgx.Tx, tenderId, bidId uuid.UUID, lotId *uuid.UUID) (bool, error) {
+	if lotId == nil {
+		return false, nil
+	}
+	tag, err := tx.Exec(ctx,
+		`UPDATE lots SET status = $1, awarded_bid_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3 AND status = $4`,
+		string(LOT_AWARDED), bidId, *lotId, string(LOT_OPEN))
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, NewAPIError(ErrCodeLotNotOpen, nil).WithDetail(MsgLotNotOpen, lotId.String())
+	}
+	return closeTenderIfSettled(ctx, tx, tenderId)
+}
+
+// decisionLot resolves the lot a decision on bid is about. A bid on a single lot needs no
+// lotId; a bid on several lots must name one of them. Bids on tenders without lots get nil.
+func decisionLot(bid *Bid, lotId string) (*uuid.UUID, *APIError) {
+	if lotId == "" {
+		switch len(bid.LotIds) {
+		case 0:
+			return nil, nil
+		case 1:
+			lotId = bid.LotIds[0]
+		default:
+			return nil, NewAPIError(ErrCodeInvalidParameter, nil).WithDetail(MsgLotRequired)
+		}
+	}
+	if !slices.Contains(bid.LotIds, lotId) {
+		return nil, NewAPIError(ErrCodeInvalidParameter, nil).WithDetail(MsgLotNotInBid, lotId)
+	}
+	id, err := uuid.Parse(lotId)
+	if err != nil {
+		return nil, NewAPIError(ErrCodeInvalidParameter, err).WithDetail(MsgMustBeUUID)
+	}
+	return &id, nil
+}
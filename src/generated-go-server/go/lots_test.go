@@ -0,0 +1,150 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+const (
+	lotA = "6f1c2a8e-0d1b-4c5e-9f3a-1b2c3d4e5f60"
+	lotB = "6f1c2a8e-0d1b-4c5e-9f3a-1b2c3d4e5f61"
+	lotC = "6f1c2a8e-0d1b-4c5e-9f3a-1b2c3d4e5f62"
+)
+
+func testLot(id string, status LotStatus, budget *Money) Lot {
+	return Lot{Id: id, Status: status, Budget: budget}
+}
+
+func TestCheckBidLots(t *testing.T) {
+	budgetA, budgetB := money("600", "RUB"), money("400", "RUB")
+	tenderBudget := money("900", "RUB")
+	lots := []Lot{
+		testLot(lotA, LOT_OPEN, &budgetA),
+		testLot(lotB, LOT_OPEN, &budgetB),
+		testLot(lotC, LOT_AWARDED, nil),
+	}
+
+	for _, tc := range []struct {
+		name   string
+		lots   []Lot
+		lotIds []string
+		price  Money
+		budget *Money
+		want   ErrorCode
+	}{
+		{"no lots", nil, nil, money("100", "RUB"), nil, ""},
+		{"lots on a tender without lots", nil, []string{lotA}, money("100", "RUB"), nil, ErrCodeValidationFailed},
+		{"no lots chosen", lots, nil, money("100", "RUB"), nil, ErrCodeValidationFailed},
+		{"unknown lot", lots, []string{"6f1c2a8e-0d1b-4c5e-9f3a-1b2c3d4e5f6f"}, money("100", "RUB"), nil, ErrCodeValidationFailed},
+		{"awarded lot", lots, []string{lotC}, money("100", "RUB"), nil, ErrCodeLotNotOpen},
+		{"within the lot budget", lots, []string{lotA}, money("600", "RUB"), nil, ""},
+		{"above the lot budget", lots, []string{lotA}, money("600.01", "RUB"), nil, ErrCodeValidationFailed},
+		{"within the sum of lot budgets", lots, []string{lotA, lotB}, money("850", "RUB"), nil, ""},
+		{"above the tender budget", lots, []string{lotA, lotB}, money("950", "RUB"), &tenderBudget, ErrCodeValidationFailed},
+		{"lot without a budget", []Lot{testLot(lotA, LOT_OPEN, nil)}, []string{lotA}, money("5000", "USD"), nil, ""},
+		{"other currency", lots, []string{lotB}, money("10", "USD"), nil, ErrCodeValidationFailed},
+	} {
+		err := checkBidLots(tc.lots, tc.lotIds, tc.price, tc.budget)
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.want != "" && (err == nil || err.Code != tc.want):
+			t.Errorf("%s: err = %v, want %s", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestCheckLotBudget(t *testing.T) {
+	rub, usd := money("100", "RUB"), money("100", "USD")
+
+	if err := checkLotBudget(&usd, &Tender{}, nil); err != nil {
+		t.Errorf("first budget of a tender without one: %v", err)
+	}
+	if err := checkLotBudget(&usd, &Tender{Budget: &rub}, nil); err == nil {
+		t.Error("currency other than the tender budget was accepted")
+	}
+	if err := checkLotBudget(&usd, &Tender{}, []Lot{testLot(lotA, LOT_OPEN, nil), testLot(lotB, LOT_OPEN, &rub)}); err == nil {
+		t.Error("currency other than the other lots was accepted")
+	}
+}
+
+func TestDecisionLot(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		bidLots []string
+		lotId   string
+		want    string
+		wantErr bool
+	}{
+		{"tender without lots", nil, "", "", false},
+		{"single lot", []string{lotA}, "", lotA, false},
+		{"lot named", []string{lotA, lotB}, lotB, lotB, false},
+		{"several lots", []string{lotA, lotB}, "", "", true},
+		{"lot of another bid", []string{lotA}, lotB, "", true},
+		{"lot on a tender without lots", nil, lotA, "", true},
+	} {
+		got, err := decisionLot(&Bid{LotIds: tc.bidLots}, tc.lotId)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v", tc.name, err)
+			continue
+		}
+		gotId := ""
+		if got != nil {
+			gotId = got.String()
+		}
+		if gotId != tc.want {
+			t.Errorf("%s: lot = %q, want %q", tc.name, gotId, tc.want)
+		}
+	}
+}
+
+func TestAwardLot(t *testing.T) {
+	tenderId, bidId, lotId := uuid.New(), uuid.New(), uuid.MustParse(lotA)
+	for _, tc := range []struct {
+		name  string
+		lotId *uuid.UUID
+		award bool
+	}{
+		// An approval must not close a tender without lots: its status is changed explicitly.
+		{"tender without lots", nil, false},
+		{"lot", &lotId, true},
+	} {
+		tx := &scriptedTx{}
+		closed, err := awardLot(context.Background(), tx, tenderId, bidId, tc.lotId)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if awarded := len(tx.executed("UPDATE lots")) == 1; awarded != tc.award {
+			t.Errorf("%s: lot awarded = %v", tc.name, awarded)
+		}
+		closes := len(tx.executed("UPDATE tenders")) == 1
+		if closes != tc.award || closed != tc.award {
+			t.Errorf("%s: closed = %v, close statements %+v", tc.name, closed, tx.execs)
+		}
+	}
+}
+
+func TestCreateLotRequestValidation(t *testing.T) {
+	router := NewRouter(NewLotAPIController(nil))
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{"description":"трубы"}`, http.StatusUnprocessableEntity},
+		{`{"name":"Лот 1","description":"трубы","budget":{"amount":"10.005","currency":"RUB"}}`, http.StatusBadRequest},
+		{`{"name":"Лот 1","description":"трубы","deadline":"2024-10-01"}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/tenders/"+importOrgID+"/lots?username=user1", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
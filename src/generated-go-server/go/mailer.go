@@ -0,0 +1,194 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	mailDriverSMTP   = "smtp"
+	mailDriverFile   = "file"
+	mailDriverStdout = "stdout"
+)
+
+// EmailMessage is a plain text message to one recipient.
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers email. Send must give up when ctx is done; an error that retrying cannot
+// fix should satisfy permanentMailError.
+type Mailer interface {
+	Send(ctx context.Context, msg EmailMessage) error
+}
+
+// NewMailer returns the mailer selected by cfg.Driver, or nil if email is turned off.
+func NewMailer(cfg MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "":
+		return nil, nil
+	case mailDriverSMTP:
+		return NewSMTPMailer(cfg), nil
+	case mailDriverFile:
+		if err := os.MkdirAll(filepath.Dir(cfg.File), 0o755); err != nil {
+			return nil, fmt.Errorf("mail file: %w", err)
+		}
+		return NewFileMailer(cfg.File, cfg.From), nil
+	case mailDriverStdout:
+		return NewStreamMailer(os.Stdout, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// SMTPMailer sends email through an SMTP server, upgrading the connection with STARTTLS
+// when the server offers it.
+type SMTPMailer struct {
+	addr string
+	host string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(cfg MailConfig) *SMTPMailer {
+	m := &SMTPMailer{
+		addr: net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		host: cfg.SMTPHost,
+		from: cfg.From,
+	}
+	if cfg.SMTPUsername != "" {
+		m.auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg EmailMessage) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	// net/smtp knows nothing of contexts; the deadline bounds the whole conversation.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.auth != nil {
+		if err := c.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(formatEmail(m.from, msg, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// StreamMailer writes every message to a stream instead of sending it, for local testing.
+type StreamMailer struct {
+	from string
+	open func() (io.WriteCloser, error)
+
+	mu sync.Mutex
+}
+
+// NewStreamMailer writes the messages to w, for example os.Stdout.
+func NewStreamMailer(w io.Writer, from string) *StreamMailer {
+	return &StreamMailer{from: from, open: func() (io.WriteCloser, error) { return nopWriteCloser{w}, nil }}
+}
+
+// NewFileMailer appends the messages to the file at path.
+func NewFileMailer(path string, from string) *StreamMailer {
+	return &StreamMailer{from: from, open: func() (io.WriteCloser, error) {
+		return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	}}
+}
+
+func (m *StreamMailer) Send(ctx context.Context, msg EmailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, err := m.open()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(formatEmail(m.from, msg, time.Now()), "\r\n"...))
+	return errors.Join(err, w.Close())
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// formatEmail renders msg as a UTF-8 plain text message. Header values are Q-encoded, which
+// also keeps line breaks out of the headers.
+func formatEmail(from string, msg EmailMessage, date time.Time) []byte {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", mime.QEncoding.Encode("utf-8", from))
+	header("To", mime.QEncoding.Encode("utf-8", msg.To))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	qp.Write([]byte(msg.Body))
+	qp.Close()
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// permanentMailError reports whether the server rejected the message for good (an SMTP 5xx
+// reply), so that retrying is pointless.
+func permanentMailError(err error) bool {
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && protoErr.Code >= 500
+}
@@ -0,0 +1,695 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Migration is a single forward-only schema change. Versions must be unique and increasing.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// migrations is the ordered list of schema changes applied by Migrate.
+// The employee and organization tables are owned by db/init/init.sql and are not created here.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "tenders and bids",
+		SQL: `
+		CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+		DO $$
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'tender_status') THEN
+				CREATE TYPE tender_status AS ENUM ('Created', 'Published', 'Closed');
+			END IF;
+
+			IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'tender_service_type') THEN
+				CREATE TYPE tender_service_type AS ENUM ('Construction', 'Delivery', 'Manufacture');
+			END IF;
+		END $$;
+
+		CREATE TABLE IF NOT EXISTS tenders (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			name VARCHAR(255) NOT NULL,
+			description TEXT,
+			service_type tender_service_type NOT NULL,
+			status VARCHAR(20),
+			organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+			creator_username VARCHAR(50) NOT NULL,
+			version INT NOT NULL DEFAULT 1,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS tender_versions (
+			id SERIAL PRIMARY KEY,
+			tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			description TEXT,
+			service_type tender_service_type NOT NULL,
+			status VARCHAR(20),
+			organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+			creator_username VARCHAR(50) NOT NULL,
+			version INT NOT NULL DEFAULT 1,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS bids (
+			bid_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			name VARCHAR(100) NOT NULL,
+			description TEXT,
+			status VARCHAR(20),
+			tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE,
+			author_type VARCHAR(20) NOT NULL,
+			author_id UUID NOT NULL,
+			version INT DEFAULT 1 CHECK (version >= 1),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS bids_versions (
+			bid_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			name VARCHAR(100) NOT NULL,
+			description TEXT,
+			status VARCHAR(20),
+			tender_id UUID NOT NULL,
+			author_type VARCHAR(20) NOT NULL,
+			author_id UUID NOT NULL,
+			version INT DEFAULT 1 CHECK (version >= 1),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS bid_feedback (
+			feedback_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			bid_id UUID REFERENCES bids(bid_id) ON DELETE CASCADE,
+			feedback TEXT NOT NULL,
+			username VARCHAR(50) REFERENCES employee(username) ON DELETE CASCADE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS bid_decisions (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			bid_id UUID REFERENCES bids(bid_id) ON DELETE CASCADE,
+			decision VARCHAR(20) NOT NULL,
+			decided_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+	},
+	{
+		Version: 2,
+		Name:    "outbox events",
+		SQL: `
+		CREATE TABLE IF NOT EXISTS outbox_events (
+			id BIGSERIAL PRIMARY KEY,
+			event_type VARCHAR(64) NOT NULL,
+			aggregate_id UUID,
+			payload JSONB NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			processed_at TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (id) WHERE processed_at IS NULL;
+		`,
+	},
+	{
+		Version: 3,
+		Name:    "attachments",
+		// entity_id has no foreign key: a tender rollback deletes and re-inserts the tender row.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS attachments (
+			id UUID PRIMARY KEY,
+			entity_type VARCHAR(10) NOT NULL CHECK (entity_type IN ('tender', 'bid')),
+			entity_id UUID NOT NULL,
+			file_name VARCHAR(255) NOT NULL,
+			content_type VARCHAR(255) NOT NULL,
+			size BIGINT NOT NULL,
+			sha256 CHAR(64) NOT NULL,
+			storage_key VARCHAR(255) NOT NULL,
+			uploaded_by VARCHAR(50) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			removed_at TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS attachments_entity_idx ON attachments (entity_type, entity_id);
+
+		CREATE TABLE IF NOT EXISTS attachment_snapshots (
+			entity_type VARCHAR(10) NOT NULL,
+			entity_id UUID NOT NULL,
+			version INT NOT NULL,
+			attachment_id UUID NOT NULL REFERENCES attachments(id) ON DELETE CASCADE,
+			PRIMARY KEY (entity_type, entity_id, version, attachment_id)
+		);
+		`,
+	},
+	{
+		Version: 4,
+		Name:    "budgets and prices",
+		// Rows created before this migration keep NULL money: a tender without a budget accepts
+		// any price, and old bids are listed without one.
+		SQL: `
+		ALTER TABLE tenders
+			ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(20, 4),
+			ADD COLUMN IF NOT EXISTS budget_currency VARCHAR(3);
+		ALTER TABLE tender_versions
+			ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(20, 4),
+			ADD COLUMN IF NOT EXISTS budget_currency VARCHAR(3);
+		ALTER TABLE bids
+			ADD COLUMN IF NOT EXISTS price_amount NUMERIC(20, 4),
+			ADD COLUMN IF NOT EXISTS price_currency VARCHAR(3);
+		ALTER TABLE bids_versions
+			ADD COLUMN IF NOT EXISTS price_amount NUMERIC(20, 4),
+			ADD COLUMN IF NOT EXISTS price_currency VARCHAR(3);
+
+		CREATE INDEX IF NOT EXISTS bids_tender_price_idx ON bids (tender_id, price_amount);
+		`,
+	},
+	{
+		Version: 5,
+		Name:    "lots",
+		// lots.tender_id has no foreign key for the same reason as attachments.entity_id.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS lots (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			tender_id UUID NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			description TEXT NOT NULL,
+			budget_amount NUMERIC(20, 4),
+			budget_currency VARCHAR(3),
+			status VARCHAR(20) NOT NULL DEFAULT 'Open' CHECK (status IN ('Open', 'Awarded', 'Cancelled')),
+			awarded_bid_id UUID REFERENCES bids(bid_id) ON DELETE SET NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS lots_tender_idx ON lots (tender_id, created_at);
+
+		CREATE TABLE IF NOT EXISTS bid_lots (
+			bid_id UUID NOT NULL REFERENCES bids(bid_id) ON DELETE CASCADE,
+			lot_id UUID NOT NULL REFERENCES lots(id) ON DELETE CASCADE,
+			PRIMARY KEY (bid_id, lot_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS bid_lots_lot_idx ON bid_lots (lot_id);
+
+		ALTER TABLE bid_decisions ADD COLUMN IF NOT EXISTS lot_id UUID REFERENCES lots(id) ON DELETE CASCADE;
+		`,
+	},
+	{
+		Version: 6,
+		Name:    "evaluation criteria",
+		// evaluation_criteria.tender_id has no foreign key for the same reason as lots.tender_id.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS evaluation_criteria (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			tender_id UUID NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			weight INT NOT NULL CHECK (weight BETWEEN 1 AND 100),
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS evaluation_criteria_tender_idx ON evaluation_criteria (tender_id, created_at);
+
+		CREATE TABLE IF NOT EXISTS bid_scores (
+			bid_id UUID NOT NULL REFERENCES bids(bid_id) ON DELETE CASCADE,
+			criterion_id UUID NOT NULL REFERENCES evaluation_criteria(id) ON DELETE CASCADE,
+			evaluator_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+			score SMALLINT NOT NULL CHECK (score BETWEEN 0 AND 10),
+			comment TEXT NOT NULL DEFAULT '',
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (bid_id, criterion_id, evaluator_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS bid_scores_criterion_idx ON bid_scores (criterion_id);
+		`,
+	},
+	{
+		Version: 7,
+		Name:    "sealed bids",
+		// Sealed bid names are stored encrypted and no longer fit into VARCHAR(100); the length
+		// of the plain name is still checked by the API.
+		SQL: `
+		ALTER TABLE tenders
+			ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false,
+			ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ,
+			ADD COLUMN IF NOT EXISTS revealed_at TIMESTAMPTZ;
+
+		ALTER TABLE bids ALTER COLUMN name TYPE TEXT;
+		ALTER TABLE bids_versions ALTER COLUMN name TYPE TEXT;
+
+		CREATE INDEX IF NOT EXISTS tenders_unrevealed_idx ON tenders (submission_deadline)
+			WHERE sealed AND revealed_at IS NULL;
+		`,
+	},
+	{
+		Version: 8,
+		Name:    "auctions",
+		// auctions.tender_id has no foreign key for the same reason as lots.tender_id. best_price
+		// and offer_count duplicate auction_offers so that an offer is checked without scanning
+		// the history.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS auctions (
+			tender_id UUID PRIMARY KEY,
+			starts_at TIMESTAMPTZ NOT NULL,
+			ends_at TIMESTAMPTZ NOT NULL,
+			currency VARCHAR(3) NOT NULL,
+			start_price NUMERIC(20, 4),
+			min_decrement NUMERIC(20, 4) NOT NULL CHECK (min_decrement > 0),
+			extension_window_seconds INT NOT NULL,
+			extension_seconds INT NOT NULL,
+			best_price NUMERIC(20, 4),
+			best_bid_id UUID REFERENCES bids(bid_id) ON DELETE SET NULL,
+			offer_count INT NOT NULL DEFAULT 0,
+			winning_bid_id UUID REFERENCES bids(bid_id) ON DELETE SET NULL,
+			finished_at TIMESTAMPTZ,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS auction_offers (
+			id BIGSERIAL PRIMARY KEY,
+			tender_id UUID NOT NULL REFERENCES auctions(tender_id) ON DELETE CASCADE,
+			bid_id UUID NOT NULL REFERENCES bids(bid_id) ON DELETE CASCADE,
+			price NUMERIC(20, 4) NOT NULL,
+			placed_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS auction_offers_tender_idx ON auction_offers (tender_id, id);
+		`,
+	},
+	{
+		Version: 9,
+		Name:    "tender questions",
+		// An unanswered question has no visibility; tender_version is the version the answer
+		// published, if it bumped one.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS tender_questions (
+			id UUID PRIMARY KEY,
+			tender_id UUID NOT NULL,
+			author_id UUID REFERENCES employee(id) ON DELETE SET NULL,
+			question TEXT NOT NULL,
+			answer TEXT,
+			visibility VARCHAR(20),
+			answered_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+			answered_at TIMESTAMPTZ,
+			tender_version INT,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS tender_questions_tender_idx ON tender_questions (tender_id, created_at);
+		`,
+	},
+	{
+		Version: 10,
+		Name:    "tender invitations",
+		// An invitation names either an organization or an employee. At most one live
+		// invitation per invitee: a declined or revoked one can be followed by a new one.
+		// tender_invitation_changes is the append-only history of the list; version numbers
+		// the changes of a tender.
+		SQL: `
+		ALTER TABLE tenders ADD COLUMN IF NOT EXISTS private BOOLEAN NOT NULL DEFAULT false;
+
+		CREATE TABLE IF NOT EXISTS tender_invitations (
+			id UUID PRIMARY KEY,
+			tender_id UUID NOT NULL,
+			organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+			user_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+			status VARCHAR(20) NOT NULL,
+			invited_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			CHECK ((organization_id IS NULL) <> (user_id IS NULL))
+		);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS tender_invitations_organization_idx ON tender_invitations (tender_id, organization_id)
+			WHERE status IN ('Pending', 'Accepted');
+		CREATE UNIQUE INDEX IF NOT EXISTS tender_invitations_user_idx ON tender_invitations (tender_id, user_id)
+			WHERE status IN ('Pending', 'Accepted');
+
+		CREATE TABLE IF NOT EXISTS tender_invitation_changes (
+			tender_id UUID NOT NULL,
+			version INT NOT NULL,
+			invitation_id UUID NOT NULL REFERENCES tender_invitations(id) ON DELETE CASCADE,
+			status VARCHAR(20) NOT NULL,
+			changed_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+			changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (tender_id, version)
+		);
+		`,
+	},
+	{
+		Version: 11,
+		Name:    "organization suppliers",
+		// An entry names either an organization or an employee as a supplier of the
+		// organization, once per supplier. expires_at is exclusive; NULL never expires.
+		// organization_supplier_policy has a row only for organizations that changed it.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS organization_suppliers (
+			id UUID PRIMARY KEY,
+			organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+			supplier_organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+			supplier_user_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+			status VARCHAR(20) NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			expires_at TIMESTAMPTZ,
+			updated_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			CHECK ((supplier_organization_id IS NULL) <> (supplier_user_id IS NULL)),
+			UNIQUE (organization_id, supplier_organization_id),
+			UNIQUE (organization_id, supplier_user_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS organization_supplier_policy (
+			organization_id UUID PRIMARY KEY REFERENCES organization(id) ON DELETE CASCADE,
+			require_qualification BOOLEAN NOT NULL DEFAULT false,
+			updated_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+	},
+	{
+		Version: 12,
+		Name:    "tender conflicts of interest",
+		// tender_conflicts keeps the conflicts flagged under the Flag policy. user_id is the
+		// employee behind the conflict, related_bid_id the earlier bid of the same organization.
+		SQL: `
+		ALTER TABLE tenders ADD COLUMN IF NOT EXISTS conflict_policy VARCHAR(10) NOT NULL DEFAULT 'Off';
+
+		CREATE TABLE IF NOT EXISTS tender_conflicts (
+			id UUID PRIMARY KEY,
+			tender_id UUID NOT NULL,
+			bid_id UUID NOT NULL,
+			kind VARCHAR(30) NOT NULL,
+			user_id UUID REFERENCES employee(id) ON DELETE SET NULL,
+			related_bid_id UUID,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS tender_conflicts_tender_idx ON tender_conflicts (tender_id, created_at);
+		`,
+	},
+	{
+		Version: 13,
+		Name:    "reputation",
+		// reputation keeps the counters of every bid author; reputation_bids the outcome last
+		// counted for each bid, without a foreign key so that the history outlives the bid.
+		// rating is the score of a feedback, NULL for feedback without one. Existing bids are
+		// counted here and kept up to date by refreshReputation afterwards.
+		SQL: `
+		ALTER TABLE bid_feedback ADD COLUMN IF NOT EXISTS rating SMALLINT CHECK (rating BETWEEN 1 AND 5);
+
+		CREATE TABLE IF NOT EXISTS reputation_bids (
+			bid_id UUID PRIMARY KEY,
+			author_type VARCHAR(20) NOT NULL,
+			author_id UUID NOT NULL,
+			decided BOOLEAN NOT NULL DEFAULT false,
+			won BOOLEAN NOT NULL DEFAULT false,
+			canceled BOOLEAN NOT NULL DEFAULT false,
+			rating_sum INT NOT NULL DEFAULT 0,
+			rating_count INT NOT NULL DEFAULT 0
+		);
+
+		CREATE TABLE IF NOT EXISTS reputation (
+			author_type VARCHAR(20) NOT NULL,
+			author_id UUID NOT NULL,
+			bids INT NOT NULL DEFAULT 0,
+			decided INT NOT NULL DEFAULT 0,
+			wins INT NOT NULL DEFAULT 0,
+			cancellations INT NOT NULL DEFAULT 0,
+			rating_sum INT NOT NULL DEFAULT 0,
+			rating_count INT NOT NULL DEFAULT 0,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (author_type, author_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS reputation_author_idx ON reputation (author_id);
+
+		INSERT INTO reputation_bids (bid_id, author_type, author_id, decided, won, canceled)
+		SELECT b.bid_id, b.author_type, b.author_id,
+			EXISTS (SELECT 1 FROM bid_decisions d WHERE d.bid_id = b.bid_id),
+			EXISTS (SELECT 1 FROM bid_decisions d WHERE d.bid_id = b.bid_id AND d.decision = 'Approved'),
+			b.status = 'Canceled'
+		FROM bids b
+		ON CONFLICT (bid_id) DO NOTHING;
+
+		INSERT INTO reputation (author_type, author_id, bids, decided, wins, cancellations)
+		SELECT author_type, author_id, COUNT(*), COUNT(*) FILTER (WHERE decided), COUNT(*) FILTER (WHERE won),
+			COUNT(*) FILTER (WHERE canceled)
+		FROM reputation_bids GROUP BY author_type, author_id
+		ON CONFLICT (author_type, author_id) DO NOTHING;
+		`,
+	},
+	{
+		Version: 14,
+		Name:    "structured bid feedback",
+		// A responsible keeps one current feedback per bid; retracted ones stay for the history.
+		// Of the duplicates left before this rule, the latest stays current. bid_feedback_versions
+		// is the append-only history: every submit, edit and retraction adds a version.
+		SQL: `
+		ALTER TABLE bid_feedback ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+		ALTER TABLE bid_feedback ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+		ALTER TABLE bid_feedback ADD COLUMN IF NOT EXISTS retracted_at TIMESTAMPTZ;
+
+		UPDATE bid_feedback f SET retracted_at = CURRENT_TIMESTAMP
+		WHERE f.retracted_at IS NULL AND EXISTS (
+			SELECT 1 FROM bid_feedback g
+			WHERE g.bid_id = f.bid_id AND g.username = f.username AND g.retracted_at IS NULL
+				AND (g.created_at, g.feedback_id) > (f.created_at, f.feedback_id));
+
+		CREATE UNIQUE INDEX IF NOT EXISTS bid_feedback_author_idx ON bid_feedback (bid_id, username)
+			WHERE retracted_at IS NULL;
+
+		CREATE TABLE IF NOT EXISTS bid_feedback_versions (
+			feedback_id UUID NOT NULL REFERENCES bid_feedback(feedback_id) ON DELETE CASCADE,
+			version INT NOT NULL,
+			feedback TEXT NOT NULL,
+			rating SMALLINT,
+			tags TEXT[] NOT NULL DEFAULT '{}',
+			retracted BOOLEAN NOT NULL DEFAULT false,
+			changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (feedback_id, version)
+		);
+
+		INSERT INTO bid_feedback_versions (feedback_id, version, feedback, rating, tags, retracted, changed_at)
+		SELECT feedback_id, 1, feedback, rating, tags, retracted_at IS NOT NULL, COALESCE(created_at, CURRENT_TIMESTAMP)
+		FROM bid_feedback
+		ON CONFLICT (feedback_id, version) DO NOTHING;
+		`,
+	},
+	{
+		Version: 15,
+		Name:    "notifications",
+		// The notification dispatcher materializes outbox events into the inbox of every
+		// recipient, once per event. Events recorded before the inbox existed are not delivered.
+		// An employee without a preference row for an event type receives it.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS notifications (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+			event_id BIGINT NOT NULL,
+			event_type VARCHAR(64) NOT NULL,
+			tender_id UUID,
+			bid_id UUID,
+			payload JSONB NOT NULL DEFAULT '{}',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			read_at TIMESTAMPTZ,
+			UNIQUE (event_id, employee_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS notifications_inbox_idx ON notifications (employee_id, created_at DESC, id);
+		CREATE INDEX IF NOT EXISTS notifications_unread_idx ON notifications (employee_id) WHERE read_at IS NULL;
+
+		CREATE TABLE IF NOT EXISTS notification_preferences (
+			employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+			event_type VARCHAR(64) NOT NULL,
+			enabled BOOLEAN NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (employee_id, event_type)
+		);
+
+		UPDATE outbox_events SET processed_at = CURRENT_TIMESTAMP WHERE processed_at IS NULL;
+		`,
+	},
+	{
+		Version: 16,
+		Name:    "email notifications",
+		// Employees opt in by giving an address; enabled = false opts out without forgetting it.
+		// email_outbox is the delivery queue: a message is pending until sent_at or failed_at is set.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS notification_email_settings (
+			employee_id UUID PRIMARY KEY REFERENCES employee(id) ON DELETE CASCADE,
+			email VARCHAR(254) NOT NULL,
+			locale VARCHAR(8) NOT NULL DEFAULT 'ru',
+			enabled BOOLEAN NOT NULL DEFAULT true,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS email_outbox (
+			id BIGSERIAL PRIMARY KEY,
+			notification_id UUID REFERENCES notifications(id) ON DELETE SET NULL,
+			employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+			recipient VARCHAR(254) NOT NULL,
+			subject TEXT NOT NULL,
+			body TEXT NOT NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			last_error TEXT,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			sent_at TIMESTAMPTZ,
+			failed_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX IF NOT EXISTS email_outbox_pending_idx ON email_outbox (next_attempt_at, id)
+			WHERE sent_at IS NULL AND failed_at IS NULL;
+		CREATE INDEX IF NOT EXISTS email_outbox_employee_idx ON email_outbox (employee_id)
+			WHERE sent_at IS NULL AND failed_at IS NULL;
+		`,
+	},
+	{
+		Version: 17,
+		Name:    "idempotency keys",
+		// A key is reserved with a NULL status while its request runs; the response is stored
+		// when it completes. Keys are scoped by operation, so clients may reuse them across endpoints.
+		SQL: `
+		CREATE TABLE IF NOT EXISTS idempotency_keys (
+			operation VARCHAR(50) NOT NULL,
+			key VARCHAR(255) NOT NULL,
+			request_hash BYTEA NOT NULL,
+			status INT,
+			content_type VARCHAR(255),
+			body BYTEA,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			expires_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (operation, key)
+		);
+
+		CREATE INDEX IF NOT EXISTS idempotency_keys_expires_idx ON idempotency_keys (expires_at);
+		`,
+	},
+}
+
+// MigrationState describes how far the database schema is behind the code.
+type MigrationState struct {
+	Current int `json:"current"`
+	Latest  int `json:"latest"`
+	Pending int `json:"pending"`
+}
+
+func latestMigration() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+const createMigrationsTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+
+// migrationLockKey is the advisory lock that serializes the migrations of instances started
+// together. The value only has to be the same for every instance.
+const migrationLockKey int64 = 0x74656e646572
+
+// Migrate applies every migration newer than the current schema version, each in its own transaction.
+// Instances started together take turns: each migration is applied under an advisory lock, and one
+// already applied by another instance meanwhile is skipped.
+func Migrate(ctx context.Context, pg *Postgres) error {
+	const op = "Migrate"
+	log := pg.Log.With(slog.String("op", op))
+
+	err := pgx.BeginFunc(ctx, pg.Pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockKey); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, createMigrationsTable)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	state, err := GetMigrationState(ctx, pg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, m := range migrations {
+		if m.Version <= state.Current {
+			continue
+		}
+
+		var applied bool
+		err := pgx.BeginFunc(ctx, pg.Pool, func(tx pgx.Tx) error {
+			var err error
+			applied, err = applyMigration(ctx, tx, m)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("%s: migration %d (%s): %w", op, m.Version, m.Name, err)
+		}
+		if applied {
+			log.Info("migration applied", slog.Int("version", m.Version), slog.String("name", m.Name))
+		} else {
+			log.Info("migration already applied by another instance", slog.Int("version", m.Version), slog.String("name", m.Name))
+		}
+	}
+
+	return nil
+}
+
+// applyMigration applies m in tx unless another instance has applied it since the schema
+// version was read. The advisory lock is held until tx ends.
+func applyMigration(ctx context.Context, tx pgx.Tx, m Migration) (bool, error) {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockKey); err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, m.Version).Scan(&exists); err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, m.SQL); err != nil {
+		return false, err
+	}
+	_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name)
+	return err == nil, err
+}
+
+// GetMigrationState reports the applied and the latest known schema versions.
+func GetMigrationState(ctx context.Context, pg *Postgres) (MigrationState, error) {
+	state := MigrationState{Latest: latestMigration()}
+
+	err := pg.Pool.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&state.Current)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		// undefined_table: nothing has been applied yet
+		if !errors.As(err, &pgErr) || pgErr.Code != "42P01" {
+			return state, err
+		}
+	}
+
+	for _, m := range migrations {
+		if m.Version > state.Current {
+			state.Pending++
+		}
+	}
+
+	return state, nil
+}
@@ -0,0 +1,35 @@
+package openapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestApplyMigration(t *testing.T) {
+	m := Migration{Version: 7, Name: "test", SQL: "CREATE TABLE test_migration (id INT)"}
+
+	cases := []struct {
+		name    string
+		applied bool
+	}{
+		{"pending", false},
+		{"applied by another instance", true},
+	}
+	for _, tc := range cases {
+		tx := &scriptedTx{script: []scriptedRow{{"FROM schema_migrations", []any{tc.applied}}}}
+		applied, err := applyMigration(context.Background(), tx, m)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if applied == tc.applied {
+			t.Errorf("%s: applied = %v", tc.name, applied)
+		}
+		if len(tx.execs) == 0 || !strings.Contains(tx.execs[0].sql, "pg_advisory_xact_lock") {
+			t.Errorf("%s: the lock is not taken first: %+v", tc.name, tx.execs)
+		}
+		if ran := len(tx.executed(m.SQL)) == 1 && len(tx.executed("INSERT INTO schema_migrations")) == 1; ran == tc.applied {
+			t.Errorf("%s: executed %+v", tc.name, tx.execs)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package openapi
+
+import "io"
+
+// Attachment - Файл, приложенный к тендеру или предложению
+type Attachment struct {
+
+	// Уникальный идентификатор вложения, присвоенный сервером.
+	Id string `json:"id"`
+
+	// Имя файла при загрузке
+	Name string `json:"name"`
+
+	// MIME тип содержимого
+	ContentType string `json:"contentType"`
+
+	// Размер файла в байтах
+	Size int64 `json:"size"`
+
+	// Контрольная сумма SHA-256 содержимого в шестнадцатеричном виде
+	Sha256 string `json:"sha256"`
+
+	// Пользователь, загрузивший файл
+	UploadedBy string `json:"uploadedBy"`
+
+	// Серверная дата и время загрузки. Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
+
+// AttachmentContent - Вложение вместе с содержимым для скачивания
+type AttachmentContent struct {
+	Attachment
+
+	// Содержимое файла; закрывается после отправки
+	Content io.ReadCloser
+}
@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxAuctionExtension bounds the anti-sniping window and extension, in seconds.
+const maxAuctionExtension = 3600
+
+// AuctionStatus : Статус аукциона
+type AuctionStatus string
+
+// List of AuctionStatus
+const (
+	AUCTION_SCHEDULED AuctionStatus = "Scheduled"
+	AUCTION_RUNNING   AuctionStatus = "Running"
+	AUCTION_FINISHED  AuctionStatus = "Finished"
+)
+
+// AllowedAuctionStatusEnumValues is all the allowed values of AuctionStatus enum
+var AllowedAuctionStatusEnumValues = []AuctionStatus{
+	"Scheduled",
+	"Running",
+	"Finished",
+}
+
+// validAuctionStatusEnumValue provides a map of AuctionStatuss for fast verification of use input
+var validAuctionStatusEnumValues = map[AuctionStatus]struct{}{
+	"Scheduled": {},
+	"Running":   {},
+	"Finished":  {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v AuctionStatus) IsValid() bool {
+	_, ok := validAuctionStatusEnumValues[v]
+	return ok
+}
+
+// NewAuctionStatusFromValue returns a pointer to a valid AuctionStatus
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewAuctionStatusFromValue(v string) (AuctionStatus, error) {
+	ev := AuctionStatus(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for AuctionStatus: valid values are %v", v, AllowedAuctionStatusEnumValues)
+}
+
+// Auction - Состояние аукциона на понижение цены
+type Auction struct {
+
+	// Тендер, по которому проводится аукцион
+	TenderId string `json:"tenderId"`
+
+	Status AuctionStatus `json:"status"`
+
+	// Начало торгов в формате RFC3339
+	StartsAt string `json:"startsAt"`
+
+	// Окончание торгов в формате RFC3339 с учетом продлений
+	EndsAt string `json:"endsAt"`
+
+	// Начальная цена: первое ценовое предложение не может ее превышать
+	StartPrice *Money `json:"startPrice,omitempty"`
+
+	// Минимальный шаг понижения цены
+	MinDecrement Money `json:"minDecrement"`
+
+	// Ставка, сделанная позже чем за столько секунд до окончания, продлевает торги
+	ExtensionWindowSeconds int32 `json:"extensionWindowSeconds"`
+
+	// На сколько секунд от момента поздней ставки продлеваются торги
+	ExtensionSeconds int32 `json:"extensionSeconds"`
+
+	// Лучшая (наименьшая) цена на текущий момент
+	BestPrice *Money `json:"bestPrice,omitempty"`
+
+	// Число принятых ценовых предложений
+	OfferCount int32 `json:"offerCount"`
+
+	// Предложение-победитель, после окончания торгов
+	WinningBidId string `json:"winningBidId,omitempty"`
+
+	// Дата и время подведения итогов в формате RFC3339
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
+
+// CreateAuctionRequest - Назначение аукциона по тендеру
+type CreateAuctionRequest struct {
+
+	// Начало торгов в формате RFC3339
+	StartsAt string `json:"startsAt"`
+
+	// Окончание торгов в формате RFC3339
+	EndsAt string `json:"endsAt"`
+
+	// Начальная цена, необязательная
+	StartPrice *Money `json:"startPrice,omitempty"`
+
+	// Минимальный шаг понижения цены
+	MinDecrement Money `json:"minDecrement"`
+
+	// Окно защиты от ставок в последний момент, в секундах; 0 — значение по умолчанию
+	ExtensionWindowSeconds int32 `json:"extensionWindowSeconds,omitempty"`
+
+	// Продление торгов после поздней ставки, в секундах; 0 — значение по умолчанию
+	ExtensionSeconds int32 `json:"extensionSeconds,omitempty"`
+}
+
+// AssertCreateAuctionRequestRequired checks if the required fields are not zero-ed
+func AssertCreateAuctionRequestRequired(obj CreateAuctionRequest) error {
+	elements := map[string]interface{}{
+		"startsAt":     obj.StartsAt,
+		"endsAt":       obj.EndsAt,
+		"minDecrement": obj.MinDecrement,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertCreateAuctionRequestConstraints checks if the values respects the defined constraints
+func AssertCreateAuctionRequestConstraints(obj CreateAuctionRequest) error {
+	startsAt, err := time.Parse(time.RFC3339, obj.StartsAt)
+	if err != nil {
+		return &ParsingError{Param: "startsAt", Err: NewLocalizedError(MsgRFC3339)}
+	}
+	endsAt, err := time.Parse(time.RFC3339, obj.EndsAt)
+	if err != nil {
+		return &ParsingError{Param: "endsAt", Err: NewLocalizedError(MsgRFC3339)}
+	}
+	if !endsAt.After(startsAt) {
+		return &ParsingError{Param: "endsAt", Err: NewLocalizedError(MsgAuctionWindow)}
+	}
+	if err := AssertMoneyConstraints("minDecrement", obj.MinDecrement); err != nil {
+		return err
+	}
+	if obj.StartPrice != nil {
+		if err := AssertMoneyConstraints("startPrice", *obj.StartPrice); err != nil {
+			return err
+		}
+		if obj.StartPrice.Currency != obj.MinDecrement.Currency {
+			return &ParsingError{Param: "startPrice.currency", Err: NewLocalizedError(MsgAuctionCurrency, obj.MinDecrement.Currency)}
+		}
+	}
+	for param, value := range map[string]int32{
+		"extensionWindowSeconds": obj.ExtensionWindowSeconds,
+		"extensionSeconds":       obj.ExtensionSeconds,
+	} {
+		if value < 0 || value > maxAuctionExtension {
+			return &ParsingError{Param: param, Err: NewLocalizedError(MsgAuctionExtensionRange, 0, maxAuctionExtension)}
+		}
+	}
+	return nil
+}
+
+// AuctionOfferRequest - Ценовое предложение участника аукциона
+type AuctionOfferRequest struct {
+
+	// Предложение участника по этому тендеру
+	BidId string `json:"bidId"`
+
+	// Новая цена, не выше лучшей цены за вычетом минимального шага
+	Price Money `json:"price"`
+}
+
+// AssertAuctionOfferRequestRequired checks if the required fields are not zero-ed
+func AssertAuctionOfferRequestRequired(obj AuctionOfferRequest) error {
+	elements := map[string]interface{}{
+		"bidId": obj.BidId,
+		"price": obj.Price,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertAuctionOfferRequestConstraints checks if the values respects the defined constraints
+func AssertAuctionOfferRequestConstraints(obj AuctionOfferRequest) error {
+	return AssertMoneyConstraints("price", obj.Price)
+}
@@ -36,11 +36,23 @@ type Bid struct {
 	// Уникальный идентификатор автора предложения, присвоенный сервером.
 	AuthorId string `json:"authorId"`
   
+	// Цена предложения; у предложений, созданных до появления цен, отсутствует
+	Price *Money `json:"price,omitempty"`
+  
+	// Лоты тендера, на которые подано предложение
+	LotIds []string `json:"lotIds,omitempty"`
+  
 	// Номер версии посел правок
 	Version int32 `json:"version"`
   
 	// Серверная дата и время в момент, когда пользователь отправил предложение на создание. Передается в формате RFC3339.
 	CreatedAt string `json:"createdAt"`
+  
+	// Содержимое и цена скрыты до раскрытия предложений закрытого тендера
+	Sealed bool `json:"sealed,omitempty"`
+  
+	// Репутация автора; показывается ответственным за тендер в списке предложений
+	Reputation *ReputationMetrics `json:"reputation,omitempty"`
   }
   
 
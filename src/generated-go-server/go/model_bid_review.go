@@ -19,8 +19,26 @@ type BidReview struct {
 	// Описание предложения
 	Description string `json:"description"`
 
+	// Предложение, о котором оставлен отзыв
+	BidId string `json:"bidId,omitempty"`
+
+	// Ответственный, оставивший отзыв
+	ReviewerUsername string `json:"reviewerUsername,omitempty"`
+
+	// Оценка от 1 до 5
+	Rating int32 `json:"rating,omitempty"`
+
+	// Категории отзыва
+	Tags []FeedbackTag `json:"tags,omitempty"`
+
+	// Номер версии отзыва после правок
+	Version int32 `json:"version,omitempty"`
+
 	// Серверная дата и время в момент, когда пользователь отправил отзыв на предложение. Передается в формате RFC3339.
 	CreatedAt string `json:"createdAt"`
+
+	// Дата и время последнего изменения отзыва в формате RFC3339
+	UpdatedAt string `json:"updatedAt,omitempty"`
 }
 
 // AssertBidReviewRequired checks if the required fields are not zero-ed
@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"fmt"
+)
+
+// BidSort : Порядок списка предложений тендера. Минус перед полем означает сортировку по убыванию
+type BidSort string
+
+// List of BidSort
+const (
+	BID_SORT_CREATED_AT      BidSort = "createdAt"
+	BID_SORT_CREATED_AT_DESC BidSort = "-createdAt"
+	BID_SORT_PRICE           BidSort = "price"
+	BID_SORT_PRICE_DESC      BidSort = "-price"
+	BID_SORT_NAME            BidSort = "name"
+	BID_SORT_NAME_DESC       BidSort = "-name"
+)
+
+// AllowedBidSortEnumValues is all the allowed values of BidSort enum
+var AllowedBidSortEnumValues = []BidSort{
+	"createdAt",
+	"-createdAt",
+	"price",
+	"-price",
+	"name",
+	"-name",
+}
+
+// bidSortValues lists the allowed values for error messages.
+const bidSortValues = "'createdAt', '-createdAt', 'price', '-price', 'name', '-name'"
+
+// bidSortOrderBy maps every BidSort to its ORDER BY clause. Bids without a price come last
+// in both directions, and bid_id makes pages stable between requests.
+var bidSortOrderBy = map[BidSort]string{
+	"createdAt":  "created_at ASC, bid_id",
+	"-createdAt": "created_at DESC, bid_id",
+	"price":      "price_amount ASC NULLS LAST, created_at, bid_id",
+	"-price":     "price_amount DESC NULLS LAST, created_at, bid_id",
+	"name":       "name ASC, bid_id",
+	"-name":      "name DESC, bid_id",
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v BidSort) IsValid() bool {
+	_, ok := bidSortOrderBy[v]
+	return ok
+}
+
+// NewBidSortFromValue returns a pointer to a valid BidSort
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewBidSortFromValue(v string) (BidSort, error) {
+	ev := BidSort(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for BidSort: valid values are %v", v, AllowedBidSortEnumValues)
+}
@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"fmt"
+)
+
+// ConflictPolicy : Что делать с конфликтом интересов по тендеру
+type ConflictPolicy string
+
+// List of ConflictPolicy
+const (
+	CONFLICT_POLICY_OFF   ConflictPolicy = "Off"
+	CONFLICT_POLICY_FLAG  ConflictPolicy = "Flag"
+	CONFLICT_POLICY_BLOCK ConflictPolicy = "Block"
+)
+
+// AllowedConflictPolicyEnumValues is all the allowed values of ConflictPolicy enum
+var AllowedConflictPolicyEnumValues = []ConflictPolicy{
+	"Off",
+	"Flag",
+	"Block",
+}
+
+// validConflictPolicyEnumValue provides a map of ConflictPolicys for fast verification of use input
+var validConflictPolicyEnumValues = map[ConflictPolicy]struct{}{
+	"Off":   {},
+	"Flag":  {},
+	"Block": {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v ConflictPolicy) IsValid() bool {
+	_, ok := validConflictPolicyEnumValues[v]
+	return ok
+}
+
+// NewConflictPolicyFromValue returns a pointer to a valid ConflictPolicy
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewConflictPolicyFromValue(v string) (ConflictPolicy, error) {
+	ev := ConflictPolicy(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for ConflictPolicy: valid values are %v", v, AllowedConflictPolicyEnumValues)
+}
+
+// ConflictKind : Вид конфликта интересов
+type ConflictKind string
+
+// List of ConflictKind
+const (
+	CONFLICT_AUTHOR_RESPONSIBLE ConflictKind = "AuthorIsResponsible"
+	CONFLICT_SAME_ORGANIZATION  ConflictKind = "SameOrganization"
+	CONFLICT_SELF_EVALUATION    ConflictKind = "SelfEvaluation"
+)
+
+// TenderConflict - Конфликт интересов, отмеченный по тендеру
+type TenderConflict struct {
+
+	// Уникальный идентификатор отметки, присвоенный сервером
+	Id string `json:"id"`
+
+	// Тендер
+	TenderId string `json:"tenderId"`
+
+	// Предложение, на котором обнаружен конфликт
+	BidId string `json:"bidId"`
+
+	Kind ConflictKind `json:"kind"`
+
+	// Пользователь, из-за которого возник конфликт
+	Username string `json:"username,omitempty"`
+
+	// Ранее поданное предложение той же организации
+	RelatedBidId string `json:"relatedBidId,omitempty"`
+
+	// Описание конфликта на языке запроса
+	Detail string `json:"detail"`
+
+	// Дата и время обнаружения в формате RFC3339
+	CreatedAt string `json:"createdAt"`
+}
+
+// TenderConflictPolicy - Правило проверки конфликта интересов по тендеру
+type TenderConflictPolicy struct {
+	Policy ConflictPolicy `json:"policy"`
+}
+
+// AssertTenderConflictPolicyRequired checks if the required fields are not zero-ed
+func AssertTenderConflictPolicyRequired(obj TenderConflictPolicy) error {
+	elements := map[string]interface{}{
+		"policy": obj.Policy,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertTenderConflictPolicyConstraints checks if the values respects the defined constraints
+func AssertTenderConflictPolicyConstraints(obj TenderConflictPolicy) error {
+	if !obj.Policy.IsValid() {
+		return &ParsingError{Param: "policy", Err: NewLocalizedError(MsgOneOf, "'Off', 'Flag', 'Block'")}
+	}
+	return nil
+}
@@ -11,7 +11,6 @@
 package openapi
 
 import (
-	"fmt"
 	"github.com/google/uuid"
 )
 
@@ -30,6 +29,12 @@ type CreateBidRequest struct {
 
 	// Уникальный идентификатор автора предложения, присвоенный сервером.
 	AuthorId string `json:"authorId"`
+
+	// Цена предложения в валюте бюджета тендера
+	Price Money `json:"price"`
+
+	// Лоты тендера, на которые подается предложение. Обязательны, если у тендера есть лоты
+	LotIds []string `json:"lotIds,omitempty"`
 }
 
 // AssertCreateBidRequestRequired checks if the required fields are not zero-ed
@@ -40,6 +45,7 @@ func AssertCreateBidRequestRequired(obj CreateBidRequest) error {
 		"tenderId":    obj.TenderId,
 		"authorType":  obj.AuthorType,
 		"authorId":    obj.AuthorId,
+		"price":       obj.Price,
 	}
 	for name, el := range elements {
 		if isZero := IsZeroValue(el); isZero {
@@ -53,13 +59,13 @@ func AssertCreateBidRequestRequired(obj CreateBidRequest) error {
 // AssertCreateBidRequestConstraints checks if the values respects the defined constraints
 func AssertCreateBidRequestConstraints(obj CreateBidRequest) error {
 	if len(obj.Name) > 100 {
-		return fmt.Errorf("name exceeds the maximum length of 100 characters")
+		return &ParsingError{Param: "name", Err: NewLocalizedError(MsgMaxLength, 100)}
 	}
 	if len(obj.Description) > 500 {
-		return fmt.Errorf("description exceeds the maximum length of 500 characters")
+		return &ParsingError{Param: "description", Err: NewLocalizedError(MsgMaxLength, 500)}
 	}
 	if _, err := uuid.Parse(obj.TenderId); err != nil {
-		return fmt.Errorf("invalid tenderId: must be a valid UUID")
+		return &ParsingError{Param: "tenderId", Err: NewLocalizedError(MsgMustBeUUID)}
 	}
 	validAuthorTypes := map[string]bool{
 		"Organization": true,
@@ -67,11 +73,26 @@ func AssertCreateBidRequestConstraints(obj CreateBidRequest) error {
 	}
 
 	if !validAuthorTypes[obj.AuthorType.String()] {
-		return fmt.Errorf("invalid authorType: must be one of 'Organization' or 'User'")
+		return &ParsingError{Param: "authorType", Err: NewLocalizedError(MsgOneOf, "'Organization', 'User'")}
 	}
 
 	if _, err := uuid.Parse(obj.AuthorId); err != nil {
-		return fmt.Errorf("invalid authorId: must be a valid UUID")
+		return &ParsingError{Param: "authorId", Err: NewLocalizedError(MsgMustBeUUID)}
+	}
+
+	if err := AssertMoneyConstraints("price", obj.Price); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(obj.LotIds))
+	for _, id := range obj.LotIds {
+		if _, err := uuid.Parse(id); err != nil {
+			return &ParsingError{Param: "lotIds", Err: NewLocalizedError(MsgMustBeUUID)}
+		}
+		if seen[id] {
+			return &ParsingError{Param: "lotIds", Err: NewLocalizedError(MsgLotDuplicate, id)}
+		}
+		seen[id] = true
 	}
 
 	return nil
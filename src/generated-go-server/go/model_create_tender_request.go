@@ -11,8 +11,8 @@
 package openapi
 
 import (
-	"fmt"
 	"github.com/google/uuid"
+	"time"
 	"unicode/utf8"
 )
 
@@ -31,6 +31,21 @@ type CreateTenderRequest struct {
 
 	// Уникальный slug пользователя.
 	CreatorUsername string `json:"creatorUsername"`
+
+	// Бюджет тендера, необязательный
+	Budget *Money `json:"budget,omitempty"`
+
+	// Закрытый тендер: предложения хранятся зашифрованными и раскрываются после окончания приема
+	Sealed bool `json:"sealed,omitempty"`
+
+	// Окончание приема предложений в формате RFC3339. Обязательно для закрытого тендера
+	SubmissionDeadline string `json:"submissionDeadline,omitempty"`
+
+	// Тендер по приглашениям: виден и доступен для предложений только приглашенным
+	Private bool `json:"private,omitempty"`
+
+	// Правило проверки конфликта интересов: Off (по умолчанию), Flag или Block
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
 }
 
 // AssertCreateTenderRequestRequired checks if the required fields are not zero-ed
@@ -54,29 +69,47 @@ func AssertCreateTenderRequestRequired(obj CreateTenderRequest) error {
 // AssertCreateTenderRequestConstraints checks if the values respects the defined constraints
 func AssertCreateTenderRequestConstraints(obj CreateTenderRequest) error {
 	if len(obj.Name) == 0 {
-		return fmt.Errorf("name is required")
+		return &ParsingError{Param: "name", Err: NewLocalizedError(MsgRequired)}
 	}
 	if utf8.RuneCountInString(obj.Name) > 100 {
-		return fmt.Errorf("name exceeds the maximum length of 100 characters")
+		return &ParsingError{Param: "name", Err: NewLocalizedError(MsgMaxLength, 100)}
 	}
 
 	if utf8.RuneCountInString(obj.Description) == 0 {
-		return fmt.Errorf("description is required")
+		return &ParsingError{Param: "description", Err: NewLocalizedError(MsgRequired)}
 	}
 	if utf8.RuneCountInString(obj.Description) > 500 {
-		return fmt.Errorf("description exceeds the maximum length of 500 characters")
+		return &ParsingError{Param: "description", Err: NewLocalizedError(MsgMaxLength, 500)}
 	}
 
 	if ok := obj.ServiceType.IsValid(); !ok {
-		return fmt.Errorf("invalid serviceType: must be one of 'Construction', 'Delivery', 'Manufacture'")
+		return &ParsingError{Param: "serviceType", Err: NewLocalizedError(MsgOneOf, "'Construction', 'Delivery', 'Manufacture'")}
 	}
 
 	if _, err := uuid.Parse(obj.OrganizationId); err != nil {
-		return fmt.Errorf("organizationId is required and must be a valid UUID")
+		return &ParsingError{Param: "organizationId", Err: NewLocalizedError(MsgRequiredUUID)}
 	}
 
 	if utf8.RuneCountInString(obj.CreatorUsername) == 0 {
-		return fmt.Errorf("creatorUsername is required")
+		return &ParsingError{Param: "creatorUsername", Err: NewLocalizedError(MsgRequired)}
+	}
+
+	if obj.Budget != nil {
+		if err := AssertMoneyConstraints("budget", *obj.Budget); err != nil {
+			return err
+		}
+	}
+
+	if obj.SubmissionDeadline != "" {
+		if _, err := time.Parse(time.RFC3339, obj.SubmissionDeadline); err != nil {
+			return &ParsingError{Param: "submissionDeadline", Err: NewLocalizedError(MsgRFC3339)}
+		}
+	} else if obj.Sealed {
+		return &ParsingError{Param: "submissionDeadline", Err: NewLocalizedError(MsgSealedDeadline)}
+	}
+
+	if obj.ConflictPolicy != "" && !obj.ConflictPolicy.IsValid() {
+		return &ParsingError{Param: "conflictPolicy", Err: NewLocalizedError(MsgOneOf, "'Off', 'Flag', 'Block'")}
 	}
 
 	return nil
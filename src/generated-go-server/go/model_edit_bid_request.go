@@ -17,6 +17,9 @@ type EditBidRequest struct {
 
 	// Описание предложения
 	Description string `json:"description,omitempty"`
+
+	// Новая цена предложения
+	Price *Money `json:"price,omitempty"`
 }
 
 // AssertEditBidRequestRequired checks if the required fields are not zero-ed
@@ -26,5 +29,8 @@ func AssertEditBidRequestRequired(obj EditBidRequest) error {
 
 // AssertEditBidRequestConstraints checks if the values respects the defined constraints
 func AssertEditBidRequestConstraints(obj EditBidRequest) error {
+	if obj.Price != nil {
+		return AssertMoneyConstraints("price", *obj.Price)
+	}
 	return nil
 }
@@ -19,6 +19,9 @@ type EditTenderRequest struct {
 	Description string `json:"description,omitempty"`
 
 	ServiceType TenderServiceType `json:"serviceType,omitempty"`
+
+	// Новый бюджет тендера
+	Budget *Money `json:"budget,omitempty"`
 }
 
 // AssertEditTenderRequestRequired checks if the required fields are not zero-ed
@@ -28,5 +31,8 @@ func AssertEditTenderRequestRequired(obj EditTenderRequest) error {
 
 // AssertEditTenderRequestConstraints checks if the values respects the defined constraints
 func AssertEditTenderRequestConstraints(obj EditTenderRequest) error {
+	if obj.Budget != nil {
+		return AssertMoneyConstraints("budget", *obj.Budget)
+	}
 	return nil
 }
@@ -0,0 +1,202 @@
+package openapi
+
+import (
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Bounds of criterion weights and bid scores.
+const (
+	minCriterionWeight = 1
+	maxCriterionWeight = 100
+	minBidScore        = 0
+	maxBidScore        = 10
+)
+
+// EvaluationCriterion - Критерий оценки предложений тендера
+type EvaluationCriterion struct {
+
+	// Уникальный идентификатор критерия, присвоенный сервером
+	Id string `json:"id"`
+
+	// Тендер, к которому относится критерий
+	TenderId string `json:"tenderId"`
+
+	// Название критерия, например «Цена» или «Срок поставки»
+	Name string `json:"name"`
+
+	// Что и как оценивается
+	Description string `json:"description,omitempty"`
+
+	// Вес критерия в итоговой оценке
+	Weight int32 `json:"weight"`
+
+	// Дата и время создания в формате RFC3339
+	CreatedAt string `json:"createdAt"`
+}
+
+// CreateCriterionRequest - Новый критерий оценки
+type CreateCriterionRequest struct {
+
+	// Название критерия
+	Name string `json:"name"`
+
+	// Что и как оценивается
+	Description string `json:"description,omitempty"`
+
+	// Вес критерия от 1 до 100
+	Weight int32 `json:"weight"`
+}
+
+// AssertCreateCriterionRequestRequired checks if the required fields are not zero-ed
+func AssertCreateCriterionRequestRequired(obj CreateCriterionRequest) error {
+	elements := map[string]interface{}{
+		"name":   obj.Name,
+		"weight": obj.Weight,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertCreateCriterionRequestConstraints checks if the values respects the defined constraints
+func AssertCreateCriterionRequestConstraints(obj CreateCriterionRequest) error {
+	if utf8.RuneCountInString(obj.Name) > 100 {
+		return &ParsingError{Param: "name", Err: NewLocalizedError(MsgMaxLength, 100)}
+	}
+	if utf8.RuneCountInString(obj.Description) > 500 {
+		return &ParsingError{Param: "description", Err: NewLocalizedError(MsgMaxLength, 500)}
+	}
+	if obj.Weight < minCriterionWeight || obj.Weight > maxCriterionWeight {
+		return &ParsingError{Param: "weight", Err: NewLocalizedError(MsgWeightRange, minCriterionWeight, maxCriterionWeight)}
+	}
+	return nil
+}
+
+// BidScoreInput - Оценка предложения по одному критерию
+type BidScoreInput struct {
+
+	// Критерий оценки тендера
+	CriterionId string `json:"criterionId"`
+
+	// Оценка от 0 до 10
+	Score int32 `json:"score"`
+
+	// Обоснование оценки
+	Comment string `json:"comment,omitempty"`
+}
+
+// SubmitBidScoresRequest - Оценки предложения одним ответственным
+type SubmitBidScoresRequest struct {
+
+	// Оценки по критериям. Критерии, которых нет в списке, не меняются
+	Scores []BidScoreInput `json:"scores"`
+}
+
+// AssertSubmitBidScoresRequestRequired checks if the required fields are not zero-ed
+func AssertSubmitBidScoresRequestRequired(obj SubmitBidScoresRequest) error {
+	if len(obj.Scores) == 0 {
+		return &RequiredError{Field: "scores"}
+	}
+	for _, el := range obj.Scores {
+		if el.CriterionId == "" {
+			return &RequiredError{Field: "scores.criterionId"}
+		}
+	}
+	return nil
+}
+
+// AssertSubmitBidScoresRequestConstraints checks if the values respects the defined constraints
+func AssertSubmitBidScoresRequestConstraints(obj SubmitBidScoresRequest) error {
+	seen := make(map[string]struct{}, len(obj.Scores))
+	for _, el := range obj.Scores {
+		if _, err := uuid.Parse(el.CriterionId); err != nil {
+			return &ParsingError{Param: "scores.criterionId", Err: NewLocalizedError(MsgMustBeUUID)}
+		}
+		if _, ok := seen[el.CriterionId]; ok {
+			return &ParsingError{Param: "scores.criterionId", Err: NewLocalizedError(MsgCriterionDuplicate, el.CriterionId)}
+		}
+		seen[el.CriterionId] = struct{}{}
+
+		if el.Score < minBidScore || el.Score > maxBidScore {
+			return &ParsingError{Param: "scores.score", Err: NewLocalizedError(MsgScoreRange, minBidScore, maxBidScore)}
+		}
+		if utf8.RuneCountInString(el.Comment) > 500 {
+			return &ParsingError{Param: "scores.comment", Err: NewLocalizedError(MsgMaxLength, 500)}
+		}
+	}
+	return nil
+}
+
+// BidScore - Оценка предложения одним ответственным по одному критерию
+type BidScore struct {
+	CriterionId string `json:"criterionId"`
+
+	// Ответственный, поставивший оценку
+	Evaluator string `json:"evaluator"`
+
+	Score int32 `json:"score"`
+
+	Comment string `json:"comment,omitempty"`
+
+	// Оценка сильно расходится с оценками других ответственных
+	Outlier bool `json:"outlier,omitempty"`
+
+	// Дата и время последнего изменения в формате RFC3339
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// CriterionResult - Оценки предложения по критерию
+type CriterionResult struct {
+	CriterionId string `json:"criterionId"`
+
+	// Средняя оценка ответственных
+	Average float64 `json:"average"`
+
+	Scores []BidScore `json:"scores"`
+}
+
+// EvaluatorResult - Взвешенная оценка предложения одним ответственным
+type EvaluatorResult struct {
+	Evaluator string `json:"evaluator"`
+
+	// Взвешенная оценка по критериям, которые оценил ответственный
+	Score float64 `json:"score"`
+
+	// Ответственный оценил предложение по всем критериям
+	Complete bool `json:"complete"`
+}
+
+// BidRanking - Место предложения в рейтинге тендера
+type BidRanking struct {
+	BidId string `json:"bidId"`
+
+	BidName string `json:"bidName"`
+
+	// Место в рейтинге. У предложений без оценок отсутствует
+	Rank int32 `json:"rank,omitempty"`
+
+	// Взвешенная оценка от 0 до 10. У предложений без оценок отсутствует
+	Score *float64 `json:"score,omitempty"`
+
+	// Предложение оценено хотя бы одним ответственным по каждому критерию
+	Complete bool `json:"complete"`
+
+	Criteria []CriterionResult `json:"criteria"`
+
+	Evaluators []EvaluatorResult `json:"evaluators"`
+}
+
+// TenderRanking - Рейтинг опубликованных предложений тендера по взвешенной оценке
+type TenderRanking struct {
+	TenderId string `json:"tenderId"`
+
+	Criteria []EvaluationCriterion `json:"criteria"`
+
+	Bids []BidRanking `json:"bids"`
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"fmt"
+)
+
+// ExportFormat : Формат выгрузки
+type ExportFormat string
+
+// List of ExportFormat
+const (
+	EXPORT_CSV  ExportFormat = "csv"
+	EXPORT_XLSX ExportFormat = "xlsx"
+)
+
+// AllowedExportFormatEnumValues is all the allowed values of ExportFormat enum
+var AllowedExportFormatEnumValues = []ExportFormat{
+	"csv",
+	"xlsx",
+}
+
+// validExportFormatEnumValue provides a map of ExportFormats for fast verification of use input
+var validExportFormatEnumValues = map[ExportFormat]struct{}{
+	"csv":  {},
+	"xlsx": {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v ExportFormat) IsValid() bool {
+	_, ok := validExportFormatEnumValues[v]
+	return ok
+}
+
+// NewExportFormatFromValue returns a pointer to a valid ExportFormat
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewExportFormatFromValue(v string) (ExportFormat, error) {
+	ev := ExportFormat(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for ExportFormat: valid values are %v", v, AllowedExportFormatEnumValues)
+}
+
+// AssertExportFormatRequired checks if the required fields are not zero-ed
+func AssertExportFormatRequired(obj ExportFormat) error {
+	return nil
+}
+
+// AssertExportFormatConstraints checks if the values respects the defined constraints
+func AssertExportFormatConstraints(obj ExportFormat) error {
+	return nil
+}
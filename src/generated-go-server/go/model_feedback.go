@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"fmt"
+)
+
+// maxFeedbackLength limits the text of a feedback, as the bidFeedback query parameter does.
+const maxFeedbackLength = 1000
+
+// FeedbackTag : Категория отзыва о предложении
+type FeedbackTag string
+
+// List of FeedbackTag
+const (
+	FEEDBACK_TAG_PRICE         FeedbackTag = "Price"
+	FEEDBACK_TAG_QUALITY       FeedbackTag = "Quality"
+	FEEDBACK_TAG_TIMELINESS    FeedbackTag = "Timeliness"
+	FEEDBACK_TAG_COMMUNICATION FeedbackTag = "Communication"
+	FEEDBACK_TAG_DOCUMENTATION FeedbackTag = "Documentation"
+)
+
+// AllowedFeedbackTagEnumValues is all the allowed values of FeedbackTag enum
+var AllowedFeedbackTagEnumValues = []FeedbackTag{
+	"Price",
+	"Quality",
+	"Timeliness",
+	"Communication",
+	"Documentation",
+}
+
+// validFeedbackTagEnumValue provides a map of FeedbackTags for fast verification of use input
+var validFeedbackTagEnumValues = map[FeedbackTag]struct{}{
+	"Price":         {},
+	"Quality":       {},
+	"Timeliness":    {},
+	"Communication": {},
+	"Documentation": {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v FeedbackTag) IsValid() bool {
+	_, ok := validFeedbackTagEnumValues[v]
+	return ok
+}
+
+// NewFeedbackTagFromValue returns a pointer to a valid FeedbackTag
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewFeedbackTagFromValue(v string) (FeedbackTag, error) {
+	ev := FeedbackTag(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for FeedbackTag: valid values are %v", v, AllowedFeedbackTagEnumValues)
+}
+
+// feedbackTagValues lists the tags for MsgOneOf.
+const feedbackTagValues = "'Price', 'Quality', 'Timeliness', 'Communication', 'Documentation'"
+
+// checkFeedbackTags checks that every tag is known and given once.
+func checkFeedbackTags(tags []FeedbackTag) *LocalizedError {
+	seen := make(map[FeedbackTag]bool, len(tags))
+	for _, tag := range tags {
+		if !tag.IsValid() {
+			return NewLocalizedError(MsgOneOf, feedbackTagValues)
+		}
+		if seen[tag] {
+			return NewLocalizedError(MsgFeedbackTagDuplicate, tag)
+		}
+		seen[tag] = true
+	}
+	return nil
+}
+
+// BidFeedback - Отзыв ответственного за тендер о предложении
+type BidFeedback struct {
+
+	// Уникальный идентификатор отзыва, присвоенный сервером
+	Id string `json:"id"`
+
+	// Предложение, о котором оставлен отзыв
+	BidId string `json:"bidId"`
+
+	// Ответственный, оставивший отзыв
+	Username string `json:"username"`
+
+	// Текст отзыва
+	Feedback string `json:"feedback"`
+
+	// Оценка от 1 до 5
+	Rating int32 `json:"rating,omitempty"`
+
+	// Категории отзыва
+	Tags []FeedbackTag `json:"tags"`
+
+	// Номер версии после правок
+	Version int32 `json:"version"`
+
+	// Отзыв отозван автором
+	Retracted bool `json:"retracted,omitempty"`
+
+	// Дата и время создания в формате RFC3339
+	CreatedAt string `json:"createdAt"`
+
+	// Дата и время последнего изменения в формате RFC3339
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// BidFeedbackVersion - Версия отзыва в истории изменений
+type BidFeedbackVersion struct {
+
+	// Отзыв
+	FeedbackId string `json:"feedbackId"`
+
+	// Номер версии
+	Version int32 `json:"version"`
+
+	// Текст отзыва в этой версии
+	Feedback string `json:"feedback"`
+
+	// Оценка в этой версии
+	Rating int32 `json:"rating,omitempty"`
+
+	// Категории в этой версии
+	Tags []FeedbackTag `json:"tags"`
+
+	// Версия отзывает отзыв
+	Retracted bool `json:"retracted,omitempty"`
+
+	// Дата и время изменения в формате RFC3339
+	ChangedAt string `json:"changedAt"`
+}
+
+// EditBidFeedbackRequest - Изменение своего отзыва; незаданные поля не меняются
+type EditBidFeedbackRequest struct {
+
+	// Новый текст отзыва
+	Feedback string `json:"feedback,omitempty"`
+
+	// Новая оценка от 1 до 5
+	Rating int32 `json:"rating,omitempty"`
+
+	// Новые категории; пустой список снимает все категории
+	Tags []FeedbackTag `json:"tags,omitempty"`
+}
+
+// AssertEditBidFeedbackRequestRequired checks if the required fields are not zero-ed
+func AssertEditBidFeedbackRequestRequired(obj EditBidFeedbackRequest) error {
+	return nil
+}
+
+// AssertEditBidFeedbackRequestConstraints checks if the values respects the defined constraints
+func AssertEditBidFeedbackRequestConstraints(obj EditBidFeedbackRequest) error {
+	if obj.Feedback == "" && obj.Rating == 0 && obj.Tags == nil {
+		return &ParsingError{Param: "feedback", Err: NewLocalizedError(MsgFeedbackNoChanges)}
+	}
+	if len([]rune(obj.Feedback)) > maxFeedbackLength {
+		return &ParsingError{Param: "feedback", Err: NewLocalizedError(MsgMaxLength, maxFeedbackLength)}
+	}
+	if obj.Rating != 0 && (obj.Rating < 1 || obj.Rating > 5) {
+		return &ParsingError{Param: "rating", Err: NewLocalizedError(MsgFeedbackRating)}
+	}
+	if err := checkFeedbackTags(obj.Tags); err != nil {
+		return &ParsingError{Param: "tags", Err: err}
+	}
+	return nil
+}
+
+// BidReviewSummary - Сводка отзывов на предложения автора по тендеру
+type BidReviewSummary struct {
+
+	// Тендер
+	TenderId string `json:"tenderId"`
+
+	// Автор предложений
+	AuthorUsername string `json:"authorUsername"`
+
+	// Число действующих отзывов
+	Reviews int32 `json:"reviews"`
+
+	// Число отзывов с оценкой
+	Ratings int32 `json:"ratings"`
+
+	// Средняя оценка; отсутствует, пока оценок нет
+	AverageRating *float64 `json:"averageRating,omitempty"`
+
+	// Число оценок 1, 2, 3, 4 и 5
+	RatingCounts [5]int32 `json:"ratingCounts"`
+
+	// Число отзывов по каждой категории
+	Tags map[FeedbackTag]int32 `json:"tags"`
+}
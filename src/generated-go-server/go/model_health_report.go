@@ -0,0 +1,30 @@
+package openapi
+
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthReport - Состояние сервиса и его зависимостей
+type HealthReport struct {
+	Status string `json:"status"`
+
+	Checks map[string]DependencyHealth `json:"checks,omitempty"`
+}
+
+// DependencyHealth - Состояние одной зависимости
+type DependencyHealth struct {
+	Status string `json:"status"`
+
+	// Время ответа зависимости в миллисекундах
+	LatencyMs float64 `json:"latencyMs,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	Migrations *MigrationState `json:"migrations,omitempty"`
+
+	Workers []WorkerStatus `json:"workers,omitempty"`
+
+	// Количество необработанных событий в outbox
+	Backlog *int64 `json:"backlog,omitempty"`
+}
@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// InvitationStatus : Статус приглашения к тендеру
+type InvitationStatus string
+
+// List of InvitationStatus
+const (
+	INVITATION_PENDING  InvitationStatus = "Pending"
+	INVITATION_ACCEPTED InvitationStatus = "Accepted"
+	INVITATION_DECLINED InvitationStatus = "Declined"
+	INVITATION_REVOKED  InvitationStatus = "Revoked"
+)
+
+// AllowedInvitationStatusEnumValues is all the allowed values of InvitationStatus enum
+var AllowedInvitationStatusEnumValues = []InvitationStatus{
+	"Pending",
+	"Accepted",
+	"Declined",
+	"Revoked",
+}
+
+// validInvitationStatusEnumValue provides a map of InvitationStatuss for fast verification of use input
+var validInvitationStatusEnumValues = map[InvitationStatus]struct{}{
+	"Pending":  {},
+	"Accepted": {},
+	"Declined": {},
+	"Revoked":  {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v InvitationStatus) IsValid() bool {
+	_, ok := validInvitationStatusEnumValues[v]
+	return ok
+}
+
+// NewInvitationStatusFromValue returns a pointer to a valid InvitationStatus
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewInvitationStatusFromValue(v string) (InvitationStatus, error) {
+	ev := InvitationStatus(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for InvitationStatus: valid values are %v", v, AllowedInvitationStatusEnumValues)
+}
+
+// Invitation - Приглашение организации или сотрудника к тендеру по приглашениям
+type Invitation struct {
+
+	// Уникальный идентификатор приглашения, присвоенный сервером
+	Id string `json:"id"`
+
+	// Тендер, к которому приглашают
+	TenderId string `json:"tenderId"`
+
+	// Название тендера
+	TenderName string `json:"tenderName"`
+
+	// Приглашенная организация
+	OrganizationId string `json:"organizationId,omitempty"`
+
+	// Приглашенный сотрудник
+	Username string `json:"username,omitempty"`
+
+	Status InvitationStatus `json:"status"`
+
+	// Ответственный, отправивший приглашение
+	InvitedBy string `json:"invitedBy,omitempty"`
+
+	// Дата и время приглашения в формате RFC3339
+	CreatedAt string `json:"createdAt"`
+
+	// Дата и время последнего изменения статуса в формате RFC3339
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// InvitationChange - Изменение списка приглашений тендера
+type InvitationChange struct {
+
+	// Номер изменения в истории списка приглашений тендера
+	Version int32 `json:"version"`
+
+	// Измененное приглашение
+	InvitationId string `json:"invitationId"`
+
+	// Приглашенная организация
+	OrganizationId string `json:"organizationId,omitempty"`
+
+	// Приглашенный сотрудник
+	Username string `json:"username,omitempty"`
+
+	Status InvitationStatus `json:"status"`
+
+	// Пользователь, внесший изменение
+	ChangedBy string `json:"changedBy,omitempty"`
+
+	// Дата и время изменения в формате RFC3339
+	ChangedAt string `json:"changedAt"`
+}
+
+// CreateInvitationRequest - Приглашение к тендеру: организация или сотрудник
+type CreateInvitationRequest struct {
+
+	// Приглашаемая организация
+	OrganizationId string `json:"organizationId,omitempty"`
+
+	// Приглашаемый сотрудник
+	Username string `json:"username,omitempty"`
+}
+
+// AssertCreateInvitationRequestRequired checks if the required fields are not zero-ed
+func AssertCreateInvitationRequestRequired(obj CreateInvitationRequest) error {
+	return nil
+}
+
+// AssertCreateInvitationRequestConstraints checks if the values respects the defined constraints
+func AssertCreateInvitationRequestConstraints(obj CreateInvitationRequest) error {
+	if (obj.OrganizationId == "") == (obj.Username == "") {
+		return &ParsingError{Param: "organizationId", Err: NewLocalizedError(MsgInvitationInvitee)}
+	}
+	if obj.OrganizationId != "" {
+		if _, err := uuid.Parse(obj.OrganizationId); err != nil {
+			return &ParsingError{Param: "organizationId", Err: NewLocalizedError(MsgRequiredUUID)}
+		}
+	}
+	return nil
+}
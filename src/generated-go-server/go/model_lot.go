@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// LotStatus : Статус лота
+type LotStatus string
+
+// List of LotStatus
+const (
+	LOT_OPEN      LotStatus = "Open"
+	LOT_AWARDED   LotStatus = "Awarded"
+	LOT_CANCELLED LotStatus = "Cancelled"
+)
+
+// AllowedLotStatusEnumValues is all the allowed values of LotStatus enum
+var AllowedLotStatusEnumValues = []LotStatus{
+	"Open",
+	"Awarded",
+	"Cancelled",
+}
+
+// validLotStatusEnumValue provides a map of LotStatuss for fast verification of use input
+var validLotStatusEnumValues = map[LotStatus]struct{}{
+	"Open":      {},
+	"Awarded":   {},
+	"Cancelled": {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v LotStatus) IsValid() bool {
+	_, ok := validLotStatusEnumValues[v]
+	return ok
+}
+
+// NewLotStatusFromValue returns a pointer to a valid LotStatus
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewLotStatusFromValue(v string) (LotStatus, error) {
+	ev := LotStatus(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for LotStatus: valid values are %v", v, AllowedLotStatusEnumValues)
+}
+
+// Lot - Лот тендера: часть работ, которую можно присудить отдельному поставщику
+type Lot struct {
+
+	// Уникальный идентификатор лота, присвоенный сервером
+	Id string `json:"id"`
+
+	// Тендер, к которому относится лот
+	TenderId string `json:"tenderId"`
+
+	// Название лота
+	Name string `json:"name"`
+
+	// Описание лота
+	Description string `json:"description"`
+
+	// Бюджет лота в валюте бюджета тендера
+	Budget *Money `json:"budget,omitempty"`
+
+	Status LotStatus `json:"status"`
+
+	// Одобренное предложение, которому присужден лот
+	AwardedBidId string `json:"awardedBidId,omitempty"`
+
+	// Дата и время создания в формате RFC3339
+	CreatedAt string `json:"createdAt"`
+}
+
+// CreateLotRequest - Новый лот тендера
+type CreateLotRequest struct {
+
+	// Название лота
+	Name string `json:"name"`
+
+	// Описание лота
+	Description string `json:"description"`
+
+	// Бюджет лота, необязательный
+	Budget *Money `json:"budget,omitempty"`
+}
+
+// AssertCreateLotRequestRequired checks if the required fields are not zero-ed
+func AssertCreateLotRequestRequired(obj CreateLotRequest) error {
+	elements := map[string]interface{}{
+		"name":        obj.Name,
+		"description": obj.Description,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertCreateLotRequestConstraints checks if the values respects the defined constraints
+func AssertCreateLotRequestConstraints(obj CreateLotRequest) error {
+	return assertLotFields(obj.Name, obj.Description, obj.Budget)
+}
+
+// EditLotRequest - Изменение лота; пустые поля не меняются
+type EditLotRequest struct {
+
+	// Название лота
+	Name string `json:"name,omitempty"`
+
+	// Описание лота
+	Description string `json:"description,omitempty"`
+
+	// Новый бюджет лота
+	Budget *Money `json:"budget,omitempty"`
+}
+
+// AssertEditLotRequestRequired checks if the required fields are not zero-ed
+func AssertEditLotRequestRequired(obj EditLotRequest) error {
+	return nil
+}
+
+// AssertEditLotRequestConstraints checks if the values respects the defined constraints
+func AssertEditLotRequestConstraints(obj EditLotRequest) error {
+	return assertLotFields(obj.Name, obj.Description, obj.Budget)
+}
+
+func assertLotFields(name, description string, budget *Money) error {
+	if utf8.RuneCountInString(name) > 100 {
+		return &ParsingError{Param: "name", Err: NewLocalizedError(MsgMaxLength, 100)}
+	}
+	if utf8.RuneCountInString(description) > 500 {
+		return &ParsingError{Param: "description", Err: NewLocalizedError(MsgMaxLength, 500)}
+	}
+	if budget != nil {
+		return AssertMoneyConstraints("budget", *budget)
+	}
+	return nil
+}
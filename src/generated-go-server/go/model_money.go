@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/currency"
+)
+
+// maxMoneyAmount is the first amount that does not fit the NUMERIC(20, 4) money columns.
+var maxMoneyAmount = decimal.New(1, 16)
+
+// Money - Денежная сумма
+type Money struct {
+
+	// Сумма. Передается строкой, чтобы не терять точность, например "1500000.50"
+	Amount decimal.Decimal `json:"amount"`
+
+	// Код валюты ISO 4217, например RUB
+	Currency string `json:"currency"`
+}
+
+// AssertMoneyConstraints checks that the currency is a known ISO 4217 code and that the
+// amount is positive and has no more fractional digits than the currency allows. param is
+// the name of the field holding the money, e.g. "budget".
+func AssertMoneyConstraints(param string, obj Money) error {
+	unit, err := currency.ParseISO(obj.Currency)
+	if err != nil || unit.String() != obj.Currency {
+		return &ParsingError{Param: param + ".currency", Err: NewLocalizedError(MsgMoneyCurrency, obj.Currency)}
+	}
+	if !obj.Amount.IsPositive() {
+		return &ParsingError{Param: param + ".amount", Err: NewLocalizedError(MsgMoneyPositive)}
+	}
+	if obj.Amount.GreaterThanOrEqual(maxMoneyAmount) {
+		return &ParsingError{Param: param + ".amount", Err: NewLocalizedError(MsgMoneyTooLarge, maxMoneyAmount.String())}
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	if !obj.Amount.Equal(obj.Amount.Truncate(int32(scale))) {
+		return &ParsingError{Param: param + ".amount", Err: NewLocalizedError(MsgMoneyScale, obj.Currency, scale)}
+	}
+	return nil
+}
+
+// nullMoney scans a nullable amount and currency column pair.
+type nullMoney struct {
+	Amount   decimal.NullDecimal
+	Currency *string
+}
+
+// Money returns nil for rows stored without money, e.g. tenders without a budget.
+func (m nullMoney) Money() *Money {
+	if !m.Amount.Valid || m.Currency == nil {
+		return nil
+	}
+	return &Money{Amount: m.Amount.Decimal, Currency: *m.Currency}
+}
+
+// moneyValues returns the values of the amount and currency columns for m.
+func moneyValues(m *Money) (any, any) {
+	if m == nil {
+		return nil, nil
+	}
+	return m.Amount.String(), m.Currency
+}
+
+// checkBidPrice compares a bid price with the budget of its tender. Tenders without a budget
+// accept any price.
+func checkBidPrice(price Money, budget *Money) *APIError {
+	if budget == nil {
+		return nil
+	}
+	if price.Currency != budget.Currency {
+		return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgPriceCurrencyMismatch, budget.Currency)
+	}
+	if price.Amount.GreaterThan(budget.Amount) {
+		return NewAPIError(ErrCodeValidationFailed, nil).WithDetail(MsgPriceExceedsBudget, budget.Amount.String(), budget.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func money(amount, currency string) Money {
+	return Money{Amount: decimal.RequireFromString(amount), Currency: currency}
+}
+
+func TestAssertMoneyConstraints(t *testing.T) {
+	for _, tc := range []struct {
+		money Money
+		field string
+	}{
+		{money("1500000.50", "RUB"), ""},
+		{money("10", "JPY"), ""},
+		{money("1.125", "KWD"), ""},
+		{money("1.50", "JPY"), "price.amount"},
+		{money("0.001", "RUB"), "price.amount"},
+		{money("0", "RUB"), "price.amount"},
+		{money("-5", "RUB"), "price.amount"},
+		{money("1e16", "RUB"), "price.amount"},
+		{money("10", "rub"), "price.currency"},
+		{money("10", "ABC"), "price.currency"},
+		{Money{Amount: decimal.NewFromInt(10)}, "price.currency"},
+	} {
+		err := AssertMoneyConstraints("price", tc.money)
+		var parsingErr *ParsingError
+		switch {
+		case tc.field == "" && err != nil:
+			t.Errorf("%s %s: unexpected error %v", tc.money.Amount, tc.money.Currency, err)
+		case tc.field != "" && (!errors.As(err, &parsingErr) || parsingErr.Param != tc.field):
+			t.Errorf("%s %s: err = %v, want a ParsingError for %s", tc.money.Amount, tc.money.Currency, err, tc.field)
+		}
+	}
+}
+
+func TestCheckBidPrice(t *testing.T) {
+	budget := money("1000.00", "RUB")
+
+	if err := checkBidPrice(money("999999", "USD"), nil); err != nil {
+		t.Errorf("no budget: %v", err)
+	}
+	if err := checkBidPrice(money("1000", "RUB"), &budget); err != nil {
+		t.Errorf("price equal to the budget: %v", err)
+	}
+	if err := checkBidPrice(money("1000.01", "RUB"), &budget); err == nil || err.Code != ErrCodeValidationFailed {
+		t.Errorf("price above the budget: err = %v", err)
+	}
+	if err := checkBidPrice(money("10", "USD"), &budget); err == nil || err.Code != ErrCodeValidationFailed {
+		t.Errorf("other currency: err = %v", err)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var m Money
+	if err := json.Unmarshal([]byte(`{"amount":"0.1","currency":"RUB"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	m.Amount = m.Amount.Add(decimal.RequireFromString("0.2"))
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"amount":"0.3","currency":"RUB"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestParseTenderImportCSVBudget(t *testing.T) {
+	data := "name,budgetAmount,budgetCurrency\n" +
+		"a,1500.50,RUB\n" +
+		"b,,\n" +
+		"c,much,RUB\n"
+
+	rows, err := ParseTenderImportCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := rows[0].Request.Budget; b == nil || b.Amount.String() != "1500.5" || b.Currency != "RUB" {
+		t.Errorf("row 1 budget = %+v", b)
+	}
+	if rows[1].Request.Budget != nil || rows[1].Err != nil {
+		t.Errorf("row 2 = %+v, want no budget", rows[1])
+	}
+	if rows[2].Err == nil {
+		t.Errorf("row 3 = %+v, want an amount error", rows[2])
+	}
+}
+
+func TestGetBidsForTenderSort(t *testing.T) {
+	router := NewRouter(NewDefaultAPIController(nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/bids/"+importOrgID+"/list?username=user1&sort=cheapest", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "sort") {
+		t.Errorf("got %d %s, want 400 for the sort parameter", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+// NotificationEventType : Тип события, о котором сообщает уведомление
+type NotificationEventType string
+
+// List of NotificationEventType
+const (
+	NOTIFICATION_BID_CREATED      NotificationEventType = eventBidCreated
+	NOTIFICATION_BID_APPROVED     NotificationEventType = eventBidApproved
+	NOTIFICATION_BID_REJECTED     NotificationEventType = eventBidRejected
+	NOTIFICATION_TENDER_CLOSED    NotificationEventType = eventTenderClosed
+	NOTIFICATION_AUCTION_FINISHED NotificationEventType = eventAuctionFinished
+	NOTIFICATION_BIDS_REVEALED    NotificationEventType = eventBidsRevealed
+)
+
+// AllowedNotificationEventTypeEnumValues is all the allowed values of NotificationEventType enum
+var AllowedNotificationEventTypeEnumValues = []NotificationEventType{
+	"bid.created",
+	"bid.approved",
+	"bid.rejected",
+	"tender.closed",
+	"tender.auction_finished",
+	"tender.bids_revealed",
+}
+
+// validNotificationEventTypeEnumValue provides a map of NotificationEventTypes for fast verification of use input
+var validNotificationEventTypeEnumValues = map[NotificationEventType]struct{}{
+	"bid.created":             {},
+	"bid.approved":            {},
+	"bid.rejected":            {},
+	"tender.closed":           {},
+	"tender.auction_finished": {},
+	"tender.bids_revealed":    {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v NotificationEventType) IsValid() bool {
+	_, ok := validNotificationEventTypeEnumValues[v]
+	return ok
+}
+
+// NewNotificationEventTypeFromValue returns a pointer to a valid NotificationEventType
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewNotificationEventTypeFromValue(v string) (NotificationEventType, error) {
+	ev := NotificationEventType(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for NotificationEventType: valid values are %v", v, AllowedNotificationEventTypeEnumValues)
+}
+
+// notificationEventTypeValues lists the event types for MsgOneOf.
+const notificationEventTypeValues = "'bid.created', 'bid.approved', 'bid.rejected', 'tender.closed', 'tender.auction_finished', 'tender.bids_revealed'"
+
+// Notification - Уведомление во входящих сотрудника
+type Notification struct {
+
+	// Уникальный идентификатор уведомления, присвоенный сервером
+	Id string `json:"id"`
+
+	EventType NotificationEventType `json:"eventType"`
+
+	// Текст уведомления на языке запроса
+	Message string `json:"message"`
+
+	// Тендер, к которому относится событие
+	TenderId string `json:"tenderId,omitempty"`
+
+	// Название тендера
+	TenderName string `json:"tenderName,omitempty"`
+
+	// Предложение, к которому относится событие
+	BidId string `json:"bidId,omitempty"`
+
+	// Данные события
+	Data map[string]any `json:"data"`
+
+	// Уведомление прочитано
+	Read bool `json:"read"`
+
+	// Дата и время события в формате RFC3339
+	CreatedAt string `json:"createdAt"`
+
+	// Дата и время прочтения в формате RFC3339
+	ReadAt string `json:"readAt,omitempty"`
+}
+
+// NotificationInbox - Страница входящих уведомлений
+type NotificationInbox struct {
+
+	// Число непрочитанных уведомлений во входящих
+	Unread int32 `json:"unread"`
+
+	// Число уведомлений, подходящих под фильтр
+	Total int32 `json:"total"`
+
+	// Уведомления, новые первыми
+	Notifications []Notification `json:"notifications"`
+}
+
+// NotificationsMarked - Результат отметки уведомлений прочитанными
+type NotificationsMarked struct {
+
+	// Число уведомлений, отмеченных прочитанными
+	Marked int32 `json:"marked"`
+}
+
+// NotificationPreference - Настройка получения уведомлений одного типа
+type NotificationPreference struct {
+	EventType NotificationEventType `json:"eventType"`
+
+	// Получать уведомления этого типа
+	Enabled bool `json:"enabled"`
+}
+
+// UpdateNotificationPreferencesRequest - Изменение настроек уведомлений; незаданные типы не меняются
+type UpdateNotificationPreferencesRequest struct {
+
+	// Новые настройки по типам событий
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+// AssertUpdateNotificationPreferencesRequestRequired checks if the required fields are not zero-ed
+func AssertUpdateNotificationPreferencesRequestRequired(obj UpdateNotificationPreferencesRequest) error {
+	if obj.Preferences == nil {
+		return &RequiredError{Field: "preferences"}
+	}
+	return nil
+}
+
+// AssertUpdateNotificationPreferencesRequestConstraints checks if the values respects the defined constraints
+func AssertUpdateNotificationPreferencesRequestConstraints(obj UpdateNotificationPreferencesRequest) error {
+	seen := make(map[NotificationEventType]bool, len(obj.Preferences))
+	for _, preference := range obj.Preferences {
+		if !preference.EventType.IsValid() {
+			return &ParsingError{Param: "eventType", Err: NewLocalizedError(MsgOneOf, notificationEventTypeValues)}
+		}
+		if seen[preference.EventType] {
+			return &ParsingError{Param: "eventType", Err: NewLocalizedError(MsgNotificationTypeDuplicate, preference.EventType)}
+		}
+		seen[preference.EventType] = true
+	}
+	return nil
+}
+
+// EmailSettings - Настройки писем с уведомлениями
+type EmailSettings struct {
+
+	// Адрес, на который отправляются письма; пусто, если не задан
+	Email string `json:"email"`
+
+	// Отправлять письма
+	Enabled bool `json:"enabled"`
+
+	// Язык писем
+	Locale string `json:"locale"`
+}
+
+// UpdateEmailSettingsRequest - Изменение настроек писем с уведомлениями
+type UpdateEmailSettingsRequest struct {
+
+	// Адрес, на который отправляются письма
+	Email string `json:"email"`
+
+	// Отправлять письма, по умолчанию true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Язык писем, по умолчанию ru
+	Locale string `json:"locale,omitempty"`
+}
+
+// AssertUpdateEmailSettingsRequestRequired checks if the required fields are not zero-ed
+func AssertUpdateEmailSettingsRequestRequired(obj UpdateEmailSettingsRequest) error {
+	if obj.Email == "" {
+		return &RequiredError{Field: "email"}
+	}
+	return nil
+}
+
+// AssertUpdateEmailSettingsRequestConstraints checks if the values respects the defined constraints
+func AssertUpdateEmailSettingsRequestConstraints(obj UpdateEmailSettingsRequest) error {
+	if len(obj.Email) > 254 {
+		return &ParsingError{Param: "email", Err: NewLocalizedError(MsgMaxLength, 254)}
+	}
+	if address, err := mail.ParseAddress(obj.Email); err != nil || address.Address != obj.Email {
+		return &ParsingError{Param: "email", Err: NewLocalizedError(MsgNotificationEmailInvalid, obj.Email)}
+	}
+	if obj.Locale != "" {
+		if _, err := ParseLocale(obj.Locale); err != nil {
+			return &ParsingError{Param: "locale", Err: NewLocalizedError(MsgOneOf, "'ru', 'en'")}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package openapi
+
+// Problem - Описание ошибки в формате RFC 7807 (application/problem+json)
+type Problem struct {
+
+	// URI, идентифицирующий тип ошибки
+	Type string `json:"type"`
+
+	// Краткое описание ошибки на языке клиента
+	Title string `json:"title"`
+
+	// HTTP статус ответа
+	Status int `json:"status"`
+
+	// Подробности конкретного случая ошибки
+	Detail string `json:"detail,omitempty"`
+
+	// Путь запроса, при обработке которого произошла ошибка
+	Instance string `json:"instance,omitempty"`
+
+	// Стабильный машиночитаемый код ошибки
+	Code ErrorCode `json:"code"`
+
+	// Идентификатор запроса из заголовка X-Request-ID
+	RequestID string `json:"requestId,omitempty"`
+
+	// Совпадает с title, оставлено для клиентов ErrorResponse
+	Reason string `json:"reason"`
+
+	// Нарушения контракта OpenAPI по каждому полю запроса
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Violation - Несоответствие одного параметра запроса спецификации OpenAPI
+type Violation struct {
+
+	// Расположение параметра: path, query, header или body
+	In string `json:"in"`
+
+	// Номер строки файла импорта, к которой относится нарушение
+	Line int `json:"line,omitempty"`
+
+	// Имя параметра или путь к полю тела запроса через точку
+	Field string `json:"field"`
+
+	// Описание нарушения
+	Message string `json:"message"`
+}
@@ -0,0 +1,48 @@
+package openapi
+
+// ReputationMetrics - Показатели репутации автора предложений
+type ReputationMetrics struct {
+
+	// Всего поданных предложений
+	Bids int32 `json:"bids"`
+
+	// Предложения, по которым принято хотя бы одно решение
+	DecidedBids int32 `json:"decidedBids"`
+
+	// Предложения, получившие одобрение
+	Wins int32 `json:"wins"`
+
+	// Отмененные автором предложения
+	Cancellations int32 `json:"cancellations"`
+
+	// Число оценок в отзывах
+	Ratings int32 `json:"ratings"`
+
+	// Доля побед среди предложений с решением; отсутствует, пока решений нет
+	WinRate *float64 `json:"winRate,omitempty"`
+
+	// Доля отмененных предложений; отсутствует, пока предложений нет
+	CancellationRate *float64 `json:"cancellationRate,omitempty"`
+
+	// Средняя оценка в отзывах от 1 до 5; отсутствует, пока оценок нет
+	AverageRating *float64 `json:"averageRating,omitempty"`
+}
+
+// Reputation - Репутация пользователя или организации как автора предложений
+type Reputation struct {
+
+	// Тип автора предложений
+	AuthorType BidAuthorType `json:"authorType"`
+
+	// Идентификатор автора предложений
+	AuthorId string `json:"authorId"`
+
+	// Показатели предложений самого автора
+	Metrics ReputationMetrics `json:"metrics"`
+
+	// Для организации: сводные показатели предложений ее ответственных, поданных от своего имени
+	Employees *ReputationMetrics `json:"employees,omitempty"`
+
+	// Дата и время последнего изменения в формате RFC3339; отсутствует, пока предложений нет
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxSupplierReasonLength limits the reason of a supplier list entry.
+const maxSupplierReasonLength = 1000
+
+// SupplierStatus : Статус поставщика в списке организации
+type SupplierStatus string
+
+// List of SupplierStatus
+const (
+	SUPPLIER_QUALIFIED   SupplierStatus = "Qualified"
+	SUPPLIER_BLACKLISTED SupplierStatus = "Blacklisted"
+)
+
+// AllowedSupplierStatusEnumValues is all the allowed values of SupplierStatus enum
+var AllowedSupplierStatusEnumValues = []SupplierStatus{
+	"Qualified",
+	"Blacklisted",
+}
+
+// validSupplierStatusEnumValue provides a map of SupplierStatuss for fast verification of use input
+var validSupplierStatusEnumValues = map[SupplierStatus]struct{}{
+	"Qualified":   {},
+	"Blacklisted": {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v SupplierStatus) IsValid() bool {
+	_, ok := validSupplierStatusEnumValues[v]
+	return ok
+}
+
+// NewSupplierStatusFromValue returns a pointer to a valid SupplierStatus
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewSupplierStatusFromValue(v string) (SupplierStatus, error) {
+	ev := SupplierStatus(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for SupplierStatus: valid values are %v", v, AllowedSupplierStatusEnumValues)
+}
+
+// SupplierEntry - Поставщик в списке организации: квалифицированный или в черном списке
+type SupplierEntry struct {
+
+	// Уникальный идентификатор записи, присвоенный сервером
+	Id string `json:"id"`
+
+	// Организация, которая ведет список
+	OrganizationId string `json:"organizationId"`
+
+	// Организация-поставщик
+	SupplierOrganizationId string `json:"supplierOrganizationId,omitempty"`
+
+	// Сотрудник-поставщик
+	SupplierUsername string `json:"supplierUsername,omitempty"`
+
+	Status SupplierStatus `json:"status"`
+
+	// Причина включения в список
+	Reason string `json:"reason,omitempty"`
+
+	// Дата и время окончания действия записи в формате RFC3339, если запись срочная
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
+	// Запись действует: срок не задан или еще не истек
+	Active bool `json:"active"`
+
+	// Ответственный, последним изменивший запись
+	UpdatedBy string `json:"updatedBy,omitempty"`
+
+	// Дата и время последнего изменения в формате RFC3339
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// SupplierList - Список поставщиков организации
+type SupplierList struct {
+
+	// Организация, которая ведет список
+	OrganizationId string `json:"organizationId"`
+
+	// Предложения по тендерам организации принимаются только от квалифицированных поставщиков
+	RequireQualification bool `json:"requireQualification"`
+
+	Suppliers []SupplierEntry `json:"suppliers"`
+}
+
+// SetSupplierRequest - Запись о поставщике: организация или сотрудник
+type SetSupplierRequest struct {
+
+	// Организация-поставщик
+	SupplierOrganizationId string `json:"supplierOrganizationId,omitempty"`
+
+	// Сотрудник-поставщик
+	SupplierUsername string `json:"supplierUsername,omitempty"`
+
+	Status SupplierStatus `json:"status"`
+
+	// Причина включения в список, обязательна для черного списка
+	Reason string `json:"reason,omitempty"`
+
+	// Дата и время окончания действия записи в формате RFC3339
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
+// AssertSetSupplierRequestRequired checks if the required fields are not zero-ed
+func AssertSetSupplierRequestRequired(obj SetSupplierRequest) error {
+	elements := map[string]interface{}{
+		"status": obj.Status,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertSetSupplierRequestConstraints checks if the values respects the defined constraints
+func AssertSetSupplierRequestConstraints(obj SetSupplierRequest) error {
+	if (obj.SupplierOrganizationId == "") == (obj.SupplierUsername == "") {
+		return &ParsingError{Param: "supplierOrganizationId", Err: NewLocalizedError(MsgSupplierSubject)}
+	}
+	if obj.SupplierOrganizationId != "" {
+		if _, err := uuid.Parse(obj.SupplierOrganizationId); err != nil {
+			return &ParsingError{Param: "supplierOrganizationId", Err: NewLocalizedError(MsgMustBeUUID)}
+		}
+	}
+	if !obj.Status.IsValid() {
+		return &ParsingError{Param: "status", Err: NewLocalizedError(MsgOneOf, "'Qualified', 'Blacklisted'")}
+	}
+	if len([]rune(obj.Reason)) > maxSupplierReasonLength {
+		return &ParsingError{Param: "reason", Err: NewLocalizedError(MsgMaxLength, maxSupplierReasonLength)}
+	}
+	if obj.Status == SUPPLIER_BLACKLISTED && obj.Reason == "" {
+		return &ParsingError{Param: "reason", Err: NewLocalizedError(MsgSupplierReasonRequired)}
+	}
+	if obj.ExpiresAt != "" {
+		if _, err := time.Parse(time.RFC3339, obj.ExpiresAt); err != nil {
+			return &ParsingError{Param: "expiresAt", Err: NewLocalizedError(MsgRFC3339)}
+		}
+	}
+	return nil
+}
+
+// SupplierPolicy - Правило допуска поставщиков организации
+type SupplierPolicy struct {
+
+	// Предложения по тендерам организации принимаются только от квалифицированных поставщиков
+	RequireQualification bool `json:"requireQualification"`
+}
+
+// AssertSupplierPolicyRequired checks if the required fields are not zero-ed
+func AssertSupplierPolicyRequired(obj SupplierPolicy) error {
+	return nil
+}
+
+// AssertSupplierPolicyConstraints checks if the values respects the defined constraints
+func AssertSupplierPolicyConstraints(obj SupplierPolicy) error {
+	return nil
+}
+
+// BidEligibility - Может ли пользователь или организация создать предложение по тендеру
+type BidEligibility struct {
+
+	// Тендер, по которому выполнена проверка
+	TenderId string `json:"tenderId"`
+
+	// Тип автора предложения
+	AuthorType BidAuthorType `json:"authorType"`
+
+	// Идентификатор автора предложения
+	AuthorId string `json:"authorId"`
+
+	// Предложение будет принято
+	Allowed bool `json:"allowed"`
+
+	// Код ошибки, с которой будет отклонено предложение
+	Code ErrorCode `json:"code,omitempty"`
+
+	// Причина отказа на языке запроса
+	Detail string `json:"detail,omitempty"`
+}
@@ -33,6 +33,24 @@ type Tender struct {
 	// Уникальный идентификатор организации, присвоенный сервером.
 	OrganizationId string `json:"organizationId,omitempty"`
 
+	// Бюджет тендера; предложения не могут его превышать
+	Budget *Money `json:"budget,omitempty"`
+
+	// Закрытый тендер: содержимое предложений скрыто от организации до окончания приема
+	Sealed bool `json:"sealed,omitempty"`
+
+	// Окончание приема предложений в формате RFC3339
+	SubmissionDeadline string `json:"submissionDeadline,omitempty"`
+
+	// Когда предложения закрытого тендера были раскрыты, в формате RFC3339
+	RevealedAt string `json:"revealedAt,omitempty"`
+
+	// Тендер по приглашениям: виден и доступен для предложений только приглашенным
+	Private bool `json:"private,omitempty"`
+
+	// Правило проверки конфликта интересов: Off, Flag или Block
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
 	// Номер версии посел правок
 	Version int32 `json:"version"`
 
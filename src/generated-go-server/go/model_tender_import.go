@@ -0,0 +1,26 @@
+package openapi
+
+// TenderImportRow - Строка файла импорта тендеров
+type TenderImportRow struct {
+
+	// Номер строки в файле, начиная с 1
+	Line int
+
+	Request CreateTenderRequest
+
+	// Ошибка разбора строки; такая строка не проверяется дальше
+	Err error
+}
+
+// TenderImportResult - Результат импорта тендеров
+type TenderImportResult struct {
+
+	// Проверка без сохранения
+	DryRun bool `json:"dryRun"`
+
+	// Количество строк, прошедших проверку
+	Count int32 `json:"count"`
+
+	// Созданные тендеры в порядке строк файла; пустой список при проверке без сохранения
+	Tenders []Tender `json:"tenders"`
+}
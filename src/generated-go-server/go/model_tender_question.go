@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// QuestionVisibility : Кому виден ответ на вопрос
+type QuestionVisibility string
+
+// List of QuestionVisibility
+const (
+	QUESTION_PUBLIC  QuestionVisibility = "Public"
+	QUESTION_PRIVATE QuestionVisibility = "Private"
+)
+
+// AllowedQuestionVisibilityEnumValues is all the allowed values of QuestionVisibility enum
+var AllowedQuestionVisibilityEnumValues = []QuestionVisibility{
+	"Public",
+	"Private",
+}
+
+// validQuestionVisibilityEnumValue provides a map of QuestionVisibilitys for fast verification of use input
+var validQuestionVisibilityEnumValues = map[QuestionVisibility]struct{}{
+	"Public":  {},
+	"Private": {},
+}
+
+// IsValid return true if the value is valid for the enum, false otherwise
+func (v QuestionVisibility) IsValid() bool {
+	_, ok := validQuestionVisibilityEnumValues[v]
+	return ok
+}
+
+// NewQuestionVisibilityFromValue returns a pointer to a valid QuestionVisibility
+// for the value passed as argument, or an error if the value passed is not allowed by the enum
+func NewQuestionVisibilityFromValue(v string) (QuestionVisibility, error) {
+	ev := QuestionVisibility(v)
+	if ev.IsValid() {
+		return ev, nil
+	}
+
+	return "", fmt.Errorf("invalid value '%v' for QuestionVisibility: valid values are %v", v, AllowedQuestionVisibilityEnumValues)
+}
+
+const (
+	maxQuestionLength = 1000
+	maxAnswerLength   = 5000
+)
+
+// TenderQuestion - Вопрос по тендеру и ответ на него
+type TenderQuestion struct {
+
+	// Уникальный идентификатор вопроса, присвоенный сервером
+	Id string `json:"id"`
+
+	// Тендер, по которому задан вопрос
+	TenderId string `json:"tenderId"`
+
+	// Текст вопроса
+	Question string `json:"question"`
+
+	// Автор вопроса; виден только ему самому и ответственным за тендер
+	AuthorUsername string `json:"authorUsername,omitempty"`
+
+	// Текст ответа, если вопрос отвечен
+	Answer string `json:"answer,omitempty"`
+
+	Visibility QuestionVisibility `json:"visibility,omitempty"`
+
+	// Ответственный, давший ответ
+	AnsweredBy string `json:"answeredBy,omitempty"`
+
+	// Дата и время ответа в формате RFC3339
+	AnsweredAt string `json:"answeredAt,omitempty"`
+
+	// Версия тендера, созданная публикацией ответа
+	TenderVersion int32 `json:"tenderVersion,omitempty"`
+
+	// Дата и время создания в формате RFC3339
+	CreatedAt string `json:"createdAt"`
+}
+
+// AskQuestionRequest - Новый вопрос по тендеру
+type AskQuestionRequest struct {
+
+	// Текст вопроса
+	Question string `json:"question"`
+}
+
+// AssertAskQuestionRequestRequired checks if the required fields are not zero-ed
+func AssertAskQuestionRequestRequired(obj AskQuestionRequest) error {
+	elements := map[string]interface{}{
+		"question": obj.Question,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertAskQuestionRequestConstraints checks if the values respects the defined constraints
+func AssertAskQuestionRequestConstraints(obj AskQuestionRequest) error {
+	if utf8.RuneCountInString(obj.Question) > maxQuestionLength {
+		return &ParsingError{Param: "question", Err: NewLocalizedError(MsgMaxLength, maxQuestionLength)}
+	}
+	return nil
+}
+
+// AnswerQuestionRequest - Ответ на вопрос по тендеру
+type AnswerQuestionRequest struct {
+
+	// Текст ответа
+	Answer string `json:"answer"`
+
+	Visibility QuestionVisibility `json:"visibility"`
+
+	// Увеличить версию тендера, чтобы участники знали об изменении условий; только для публичных ответов
+	BumpVersion bool `json:"bumpVersion,omitempty"`
+}
+
+// AssertAnswerQuestionRequestRequired checks if the required fields are not zero-ed
+func AssertAnswerQuestionRequestRequired(obj AnswerQuestionRequest) error {
+	elements := map[string]interface{}{
+		"answer":     obj.Answer,
+		"visibility": obj.Visibility,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertAnswerQuestionRequestConstraints checks if the values respects the defined constraints
+func AssertAnswerQuestionRequestConstraints(obj AnswerQuestionRequest) error {
+	if utf8.RuneCountInString(obj.Answer) > maxAnswerLength {
+		return &ParsingError{Param: "answer", Err: NewLocalizedError(MsgMaxLength, maxAnswerLength)}
+	}
+	if !obj.Visibility.IsValid() {
+		return &ParsingError{Param: "visibility", Err: NewLocalizedError(MsgOneOf, "'Public', 'Private'")}
+	}
+	if obj.BumpVersion && obj.Visibility != QUESTION_PUBLIC {
+		return &ParsingError{Param: "bumpVersion", Err: NewLocalizedError(MsgQuestionBumpPrivate)}
+	}
+	return nil
+}
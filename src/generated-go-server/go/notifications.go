@@ -0,0 +1,279 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// eventBidCreated is the outbox event recorded when a bid is submitted on a tender.
+	eventBidCreated = "bid.created"
+	// eventBidApproved and eventBidRejected are the outbox events recorded for decisions.
+	eventBidApproved = "bid.approved"
+	eventBidRejected = "bid.rejected"
+	// eventTenderClosed is the outbox event recorded when a tender is closed.
+	eventTenderClosed = "tender.closed"
+	// notificationBatchSize is how many outbox events one run of the dispatcher handles.
+	notificationBatchSize = 100
+)
+
+// notificationMessages are the catalog keys of the inbox texts; each takes the tender name.
+var notificationMessages = map[NotificationEventType]MessageKey{
+	NOTIFICATION_BID_CREATED:      MsgNotificationBidCreated,
+	NOTIFICATION_BID_APPROVED:     MsgNotificationBidApproved,
+	NOTIFICATION_BID_REJECTED:     MsgNotificationBidRejected,
+	NOTIFICATION_TENDER_CLOSED:    MsgNotificationTenderClosed,
+	NOTIFICATION_AUCTION_FINISHED: MsgNotificationAuctionFinished,
+	NOTIFICATION_BIDS_REVEALED:    MsgNotificationBidsRevealed,
+}
+
+// notificationMessage renders the inbox text of an event in locale.
+func notificationMessage(locale Locale, eventType NotificationEventType, tenderName string) string {
+	key, ok := notificationMessages[eventType]
+	if !ok {
+		return string(eventType)
+	}
+	return Translate(locale, key, tenderName)
+}
+
+// recordEvent appends an event to the outbox in the transaction that caused it, so that the
+// event is delivered exactly when the change is committed.
+func recordEvent(ctx context.Context, tx pgx.Tx, eventType string, aggregateId uuid.UUID, payload map[string]any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	INSERT INTO outbox_events (event_type, aggregate_id, payload) VALUES ($1, $2, $3)`,
+		eventType, aggregateId, data)
+	return err
+}
+
+// bidEventPayload is the payload of the bid events. The author tells the dispatcher whom to
+// notify even if the bid is gone by the time the event is delivered.
+func bidEventPayload(tenderId uuid.UUID, bidId uuid.UUID, authorType BidAuthorType, authorId string) map[string]any {
+	return map[string]any{
+		"tenderId":   tenderId,
+		"bidId":      bidId,
+		"authorType": authorType,
+		"authorId":   authorId,
+	}
+}
+
+// notificationColumns are the columns read by scanNotification from notifications aliased n.
+const notificationColumns = `n.id, n.event_type, n.tender_id, COALESCE((SELECT name FROM tenders WHERE id = n.tender_id), ''),
+	n.bid_id, n.payload, n.created_at, n.read_at`
+
+func scanNotification(row pgx.Row, locale Locale) (Notification, error) {
+	var notification Notification
+	var id uuid.UUID
+	var tenderId, bidId *uuid.UUID
+	var createdAt time.Time
+	var readAt *time.Time
+
+	err := row.Scan(&id, &notification.EventType, &tenderId, &notification.TenderName,
+		&bidId, &notification.Data, &createdAt, &readAt)
+	if err != nil {
+		return Notification{}, err
+	}
+
+	notification.Id = id.String()
+	if tenderId != nil {
+		notification.TenderId = tenderId.String()
+	}
+	if bidId != nil {
+		notification.BidId = bidId.String()
+	}
+	if notification.Data == nil {
+		notification.Data = map[string]any{}
+	}
+	notification.Message = notificationMessage(locale, notification.EventType, notification.TenderName)
+	notification.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	if readAt != nil {
+		notification.Read = true
+		notification.ReadAt = readAt.UTC().Format(time.RFC3339)
+	}
+	return notification, nil
+}
+
+// outboxEvent is an event read from the outbox by the dispatcher.
+type outboxEvent struct {
+	Id        int64
+	Type      NotificationEventType
+	Payload   map[string]any
+	CreatedAt time.Time
+}
+
+// uuid returns the id stored under key in the payload, or nil if it is missing or malformed.
+func (e outboxEvent) uuid(key string) *uuid.UUID {
+	value, _ := e.Payload[key].(string)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// Recipient queries take the tender id, or the author type and id for the bid author.
+const (
+	tenderResponsiblesQuery = `
+	SELECT r.user_id FROM tenders t JOIN organization_responsible r ON r.organization_id = t.organization_id
+	WHERE t.id = $1`
+	tenderBiddersQuery = `
+	SELECT b.author_id FROM bids b
+	WHERE b.tender_id = $1 AND b.author_type = 'User' AND b.status IS DISTINCT FROM 'Canceled'
+	UNION
+	SELECT r.user_id FROM bids b JOIN organization_responsible r ON r.organization_id = b.author_id
+	WHERE b.tender_id = $1 AND b.author_type = 'Organization' AND b.status IS DISTINCT FROM 'Canceled'`
+	bidAuthorQuery = `
+	SELECT id FROM employee WHERE id = $2 AND $1 = 'User'
+	UNION
+	SELECT user_id FROM organization_responsible WHERE organization_id = $2 AND $1 = 'Organization'`
+)
+
+// eventRecipients lists the employees to notify of the event: the responsibles of the tender
+// for new and revealed bids, the author of the bid for decisions, and everyone with a bid
+// still standing for the end of the tender or its auction.
+func eventRecipients(ctx context.Context, tx pgx.Tx, event outboxEvent) ([]uuid.UUID, error) {
+	var query string
+	var args []any
+	switch event.Type {
+	case NOTIFICATION_BID_CREATED, NOTIFICATION_BIDS_REVEALED:
+		query, args = tenderResponsiblesQuery, []any{event.uuid("tenderId")}
+	case NOTIFICATION_TENDER_CLOSED, NOTIFICATION_AUCTION_FINISHED:
+		query, args = tenderBiddersQuery, []any{event.uuid("tenderId")}
+	case NOTIFICATION_BID_APPROVED, NOTIFICATION_BID_REJECTED:
+		authorType, _ := event.Payload["authorType"].(string)
+		query, args = bidAuthorQuery, []any{authorType, event.uuid("authorId")}
+	default:
+		return nil, nil
+	}
+
+	// A missing id is passed as NULL and matches nobody.
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
+
+// NotificationDispatcher materializes outbox events into the inboxes of their recipients.
+// Each event is handled in its own transaction and marked processed with its notifications,
+// so several instances may run the dispatcher side by side.
+type NotificationDispatcher struct {
+	pg    *Postgres
+	log   *slog.Logger
+	email bool
+}
+
+// NotificationDispatcherOption configures optional dispatcher features.
+type NotificationDispatcherOption func(*NotificationDispatcher)
+
+// WithEmailDelivery also queues an email for every notification of a recipient who turned
+// email on. Without it nothing is queued, so the queue does not grow while mail is off.
+func WithEmailDelivery() NotificationDispatcherOption {
+	return func(d *NotificationDispatcher) {
+		d.email = true
+	}
+}
+
+func NewNotificationDispatcher(pg *Postgres, log *slog.Logger, opts ...NotificationDispatcherOption) *NotificationDispatcher {
+	d := &NotificationDispatcher{pg: pg, log: log.With(slog.String("op", "NotificationDispatcher"))}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run is a WorkerFunc that delivers the oldest pending outbox events.
+func (d *NotificationDispatcher) Run(ctx context.Context) error {
+	rows, err := d.pg.Pool.Query(ctx, `
+	SELECT id FROM outbox_events WHERE processed_at IS NULL
+	ORDER BY id
+	LIMIT $1`, notificationBatchSize)
+	if err != nil {
+		return err
+	}
+	eventIds, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, eventId := range eventIds {
+		if err := d.dispatch(ctx, eventId); err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", eventId, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dispatch delivers one event to every recipient that has not turned its type off.
+func (d *NotificationDispatcher) dispatch(ctx context.Context, eventId int64) error {
+	tx, err := d.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var event outboxEvent
+	err = tx.QueryRow(ctx, `
+	SELECT id, event_type, payload, created_at FROM outbox_events
+	WHERE id = $1 AND processed_at IS NULL
+	FOR UPDATE SKIP LOCKED`, eventId).Scan(&event.Id, &event.Type, &event.Payload, &event.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Delivered or being delivered by another instance.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	recipients, err := eventRecipients(ctx, tx, event)
+	if err != nil {
+		return fmt.Errorf("recipients: %w", err)
+	}
+	var notified []notifiedEmployee
+	if len(recipients) > 0 {
+		rows, err := tx.Query(ctx, `
+		INSERT INTO notifications (employee_id, event_id, event_type, tender_id, bid_id, payload, created_at)
+		SELECT r.id, $2::bigint, $3::varchar, $4::uuid, $5::uuid, $6::jsonb, $7::timestamptz FROM unnest($1::uuid[]) AS r(id)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM notification_preferences p
+			WHERE p.employee_id = r.id AND p.event_type = $3 AND NOT p.enabled)
+		ON CONFLICT (event_id, employee_id) DO NOTHING
+		RETURNING id, employee_id`,
+			recipients, event.Id, string(event.Type), event.uuid("tenderId"), event.uuid("bidId"), event.Payload, event.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("save notifications: %w", err)
+		}
+		notified, err = pgx.CollectRows(rows, pgx.RowToStructByPos[notifiedEmployee])
+		if err != nil {
+			return fmt.Errorf("save notifications: %w", err)
+		}
+	}
+
+	emails := 0
+	if d.email {
+		if emails, err = enqueueEmails(ctx, tx, event, notified); err != nil {
+			return fmt.Errorf("queue emails: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE outbox_events SET processed_at = clock_timestamp() WHERE id = $1`, event.Id); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	d.log.Debug("event dispatched", slog.Int64("event_id", event.Id), slog.String("event_type", string(event.Type)),
+		slog.Int("notifications", len(notified)), slog.Int("emails", emails))
+	return nil
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNotificationEventTypes(t *testing.T) {
+	for _, eventType := range AllowedNotificationEventTypeEnumValues {
+		if !eventType.IsValid() {
+			t.Errorf("%s is not valid", eventType)
+		}
+		if _, ok := notificationMessages[eventType]; !ok {
+			t.Errorf("%s has no message", eventType)
+		}
+		if !strings.Contains(notificationEventTypeValues, "'"+string(eventType)+"'") {
+			t.Errorf("%s is missing from notificationEventTypeValues", eventType)
+		}
+	}
+
+	got := notificationMessage(LocaleEN, NOTIFICATION_BID_APPROVED, "Tender 1")
+	if got != `Your bid on tender "Tender 1" was approved` {
+		t.Errorf("approved message = %q", got)
+	}
+	got = notificationMessage(LocaleRU, NOTIFICATION_TENDER_CLOSED, "Tender 1")
+	if got != "Тендер «Tender 1», в котором вы участвуете, закрыт" {
+		t.Errorf("closed message = %q", got)
+	}
+}
+
+func TestOutboxEventIDs(t *testing.T) {
+	tenderId := uuid.New()
+	event := outboxEvent{Payload: map[string]any{"tenderId": tenderId.String(), "bidId": "not-a-uuid"}}
+
+	if got := event.uuid("tenderId"); got == nil || *got != tenderId {
+		t.Errorf("tenderId = %v, want %s", got, tenderId)
+	}
+	if got := event.uuid("bidId"); got != nil {
+		t.Errorf("malformed bidId = %v, want nil", got)
+	}
+	if got := event.uuid("authorId"); got != nil {
+		t.Errorf("missing authorId = %v, want nil", got)
+	}
+}
+
+func TestUpdateNotificationPreferencesRequestConstraints(t *testing.T) {
+	cases := []struct {
+		name  string
+		req   UpdateNotificationPreferencesRequest
+		valid bool
+	}{
+		{"empty", UpdateNotificationPreferencesRequest{Preferences: []NotificationPreference{}}, true},
+		{"valid", UpdateNotificationPreferencesRequest{Preferences: []NotificationPreference{
+			{EventType: NOTIFICATION_BID_CREATED}, {EventType: NOTIFICATION_BID_APPROVED, Enabled: true}}}, true},
+		{"unknown", UpdateNotificationPreferencesRequest{Preferences: []NotificationPreference{{EventType: "bid.updated"}}}, false},
+		{"duplicate", UpdateNotificationPreferencesRequest{Preferences: []NotificationPreference{
+			{EventType: NOTIFICATION_TENDER_CLOSED}, {EventType: NOTIFICATION_TENDER_CLOSED, Enabled: true}}}, false},
+	}
+	for _, tc := range cases {
+		err := AssertUpdateNotificationPreferencesRequestConstraints(tc.req)
+		if tc.valid {
+			if err != nil {
+				t.Errorf("%s: got %v", tc.name, err)
+			}
+			continue
+		}
+		var parsingErr *ParsingError
+		if !errors.As(err, &parsingErr) || parsingErr.Param != "eventType" {
+			t.Errorf("%s: got %v, want an error on eventType", tc.name, err)
+		}
+	}
+}
+
+func TestNotificationRequestValidation(t *testing.T) {
+	router := NewRouter(NewNotificationAPIController(nil))
+	cases := []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodGet, "/api/notifications", "", http.StatusUnprocessableEntity},
+		{http.MethodGet, "/api/notifications?username=user1&limit=51", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/notifications?username=user1&offset=-1", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/notifications?username=user1&unreadOnly=maybe", "", http.StatusBadRequest},
+		{http.MethodPut, "/api/notifications/read_all", "", http.StatusUnprocessableEntity},
+		{http.MethodPut, "/api/notifications/" + importOrgID + "/read", "", http.StatusUnprocessableEntity},
+		{http.MethodPut, "/api/notifications/preferences?username=user1", `{}`, http.StatusUnprocessableEntity},
+		{http.MethodPut, "/api/notifications/preferences?username=user1", `{"preferences": [{"eventType": "bid.updated", "enabled": false}]}`, http.StatusBadRequest},
+		{http.MethodPut, "/api/notifications/preferences?username=user1", `{"preferences": [], "email": true}`, http.StatusBadRequest},
+		{http.MethodGet, "/api/notifications/email", "", http.StatusUnprocessableEntity},
+		{http.MethodPut, "/api/notifications/email?username=user1", `{"enabled": false}`, http.StatusUnprocessableEntity},
+		{http.MethodPut, "/api/notifications/email?username=user1", `{"email": "not an address"}`, http.StatusBadRequest},
+		{http.MethodPut, "/api/notifications/email?username=user1", `{"email": "Bob <bob@example.com>"}`, http.StatusBadRequest},
+		{http.MethodPut, "/api/notifications/email?username=user1", `{"email": "bob@example.com", "locale": "de"}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s %s %s: got %d %s, want %d", tc.method, tc.path, tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
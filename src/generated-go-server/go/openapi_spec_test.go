@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/GIT_USER_ID/GIT_REPO_ID/api"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// specControllers returns every controller registered by main.
+func specControllers(t *testing.T) []Router {
+	t.Helper()
+
+	docs, err := NewDocsAPIController(api.OpenAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []Router{
+		NewDefaultAPIController(nil),
+		NewHealthAPIController(nil),
+		NewExportAPIController(nil),
+		NewImportAPIController(nil),
+		NewAttachmentAPIController(nil),
+		NewLotAPIController(nil),
+		NewEvaluationAPIController(nil),
+		NewAuctionAPIController(nil),
+		NewQuestionAPIController(nil),
+		NewInvitationAPIController(nil),
+		NewSupplierAPIController(nil),
+		NewConflictAPIController(nil),
+		NewReputationAPIController(nil),
+		NewFeedbackAPIController(nil),
+		NewNotificationAPIController(nil),
+		docs,
+	}
+}
+
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(api.OpenAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Validate(loader.Context, openapi3.DisableExamplesValidation()); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// TestRoutesMatchSpec fails when a route is registered but not documented, or documented
+// but not implemented.
+func TestRoutesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+
+	base := ""
+	if len(doc.Servers) > 0 {
+		u, err := url.Parse(doc.Servers[0].URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		base = strings.TrimSuffix(u.Path, "/")
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+base+path] = true
+		}
+	}
+
+	registered := make(map[string]bool)
+	for _, c := range specControllers(t) {
+		for name, route := range c.Routes() {
+			key := route.Method + " " + route.Pattern
+			if registered[key] {
+				t.Errorf("route %s (%s) is registered twice", key, name)
+			}
+			registered[key] = true
+		}
+	}
+
+	var missing, unimplemented []string
+	for key := range registered {
+		if !documented[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			unimplemented = append(unimplemented, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unimplemented)
+
+	for _, key := range missing {
+		t.Errorf("route %s is not described in api/openapi.yaml", key)
+	}
+	for _, key := range unimplemented {
+		t.Errorf("operation %s from api/openapi.yaml has no route", key)
+	}
+}
+
+func TestServeSpec(t *testing.T) {
+	docs, err := NewDocsAPIController(api.OpenAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := NewRouter(docs)
+
+	tests := []struct {
+		path        string
+		contentType string
+	}{
+		{path: "/api/openapi.yaml", contentType: "application/yaml"},
+		{path: "/api/openapi.json", contentType: "application/json"},
+		{path: "/api/docs", contentType: "text/html; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.contentType)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	var spec struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	if spec.OpenAPI == "" || len(spec.Paths) != len(loadSpec(t).Paths.Map()) {
+		t.Errorf("openapi.json does not match openapi.yaml: version %q, %d paths", spec.OpenAPI, len(spec.Paths))
+	}
+}
+
+// TestErrorResponsesAreProblems checks that every documented error is the problem document
+// written by DefaultErrorHandler, and that such a document satisfies the schema.
+func TestErrorResponsesAreProblems(t *testing.T) {
+	doc := loadSpec(t)
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			for status, resp := range op.Responses.Map() {
+				// Readiness reports the state of every dependency in its own format.
+				if status < "400" || resp.Value == nil || op.OperationID == "readyCheck" {
+					continue
+				}
+				media := resp.Value.Content.Get(problemContentType)
+				if len(resp.Value.Content) != 1 || media == nil || media.Schema == nil || media.Schema.Ref != "#/components/schemas/problem" {
+					t.Errorf("%s %s %s: error response is not %s problem", method, path, status, problemContentType)
+				}
+			}
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/tenders/1/status", nil)
+	p := newProblem(r, ErrCodeValidationFailed, "detail")
+	p.Violations = []Violation{{In: "query", Field: "limit", Message: "too large"}}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Components.Schemas["problem"].Value.VisitJSON(value); err != nil {
+		t.Errorf("problem %s does not match the schema: %v", data, err)
+	}
+}
@@ -0,0 +1,350 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode is a stable machine-readable identifier of an error. Clients should branch
+// on it instead of on the human readable message.
+type ErrorCode string
+
+const (
+	ErrCodeInvalidParameter        ErrorCode = "INVALID_PARAMETER"
+	ErrCodeMissingParameter        ErrorCode = "MISSING_PARAMETER"
+	ErrCodeValidationFailed        ErrorCode = "VALIDATION_FAILED"
+	ErrCodeInvalidID               ErrorCode = "INVALID_ID"
+	ErrCodeInvalidStatus           ErrorCode = "INVALID_STATUS"
+	ErrCodeUserNotFound            ErrorCode = "USER_NOT_FOUND"
+	ErrCodeOrganizationNotFound    ErrorCode = "ORGANIZATION_NOT_FOUND"
+	ErrCodeForbiddenNotResponsible ErrorCode = "FORBIDDEN_NOT_RESPONSIBLE"
+	ErrCodeForbiddenNotAuthor      ErrorCode = "FORBIDDEN_NOT_AUTHOR"
+	ErrCodeForbiddenOrganization   ErrorCode = "FORBIDDEN_ORGANIZATION"
+	ErrCodeNotFound                ErrorCode = "NOT_FOUND"
+	ErrCodeTenderNotFound          ErrorCode = "TENDER_NOT_FOUND"
+	ErrCodeBidNotFound             ErrorCode = "BID_NOT_FOUND"
+	ErrCodeVersionNotFound         ErrorCode = "VERSION_NOT_FOUND"
+	ErrCodeReviewsNotFound         ErrorCode = "REVIEWS_NOT_FOUND"
+	ErrCodeAttachmentNotFound      ErrorCode = "ATTACHMENT_NOT_FOUND"
+	ErrCodeLotNotFound             ErrorCode = "LOT_NOT_FOUND"
+	ErrCodeLotNotOpen              ErrorCode = "LOT_NOT_OPEN"
+	ErrCodeTenderClosed            ErrorCode = "TENDER_CLOSED"
+	ErrCodeCriterionNotFound       ErrorCode = "CRITERION_NOT_FOUND"
+	ErrCodeEvaluationStarted       ErrorCode = "EVALUATION_STARTED"
+	ErrCodeBidsSealed              ErrorCode = "BIDS_SEALED"
+	ErrCodeSubmissionClosed        ErrorCode = "SUBMISSION_CLOSED"
+	ErrCodeAuctionNotFound         ErrorCode = "AUCTION_NOT_FOUND"
+	ErrCodeAuctionNotRunning       ErrorCode = "AUCTION_NOT_RUNNING"
+	ErrCodeOfferTooHigh            ErrorCode = "OFFER_TOO_HIGH"
+	ErrCodeQuestionNotFound        ErrorCode = "QUESTION_NOT_FOUND"
+	ErrCodeInvitationNotFound      ErrorCode = "INVITATION_NOT_FOUND"
+	ErrCodeForbiddenNotInvited     ErrorCode = "FORBIDDEN_NOT_INVITED"
+	ErrCodeSupplierNotFound        ErrorCode = "SUPPLIER_NOT_FOUND"
+	ErrCodeSupplierBlacklisted     ErrorCode = "SUPPLIER_BLACKLISTED"
+	ErrCodeSupplierNotQualified    ErrorCode = "SUPPLIER_NOT_QUALIFIED"
+	ErrCodeConflictOfInterest      ErrorCode = "CONFLICT_OF_INTEREST"
+	ErrCodeFeedbackNotFound        ErrorCode = "FEEDBACK_NOT_FOUND"
+	ErrCodeFeedbackExists          ErrorCode = "FEEDBACK_EXISTS"
+	ErrCodeNotificationNotFound    ErrorCode = "NOTIFICATION_NOT_FOUND"
+	ErrCodeIdempotencyKeyReused    ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyKeyInUse     ErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+	ErrCodePayloadTooLarge         ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnsupportedMediaType    ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	ErrCodeMethodNotAllowed        ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrCodeAlreadyExists           ErrorCode = "ALREADY_EXISTS"
+	ErrCodeInternal                ErrorCode = "INTERNAL_ERROR"
+	ErrCodeServiceUnavailable      ErrorCode = "SERVICE_UNAVAILABLE"
+)
+
+const problemContentType = "application/problem+json"
+
+// errorCodeStatus is the HTTP status returned for each error code.
+var errorCodeStatus = map[ErrorCode]int{
+	ErrCodeInvalidParameter:        http.StatusBadRequest,
+	ErrCodeMissingParameter:        http.StatusUnprocessableEntity,
+	ErrCodeValidationFailed:        http.StatusBadRequest,
+	ErrCodeInvalidID:               http.StatusBadRequest,
+	ErrCodeInvalidStatus:           http.StatusBadRequest,
+	ErrCodeUserNotFound:            http.StatusUnauthorized,
+	ErrCodeOrganizationNotFound:    http.StatusUnauthorized,
+	ErrCodeForbiddenNotResponsible: http.StatusForbidden,
+	ErrCodeForbiddenNotAuthor:      http.StatusForbidden,
+	ErrCodeForbiddenOrganization:   http.StatusForbidden,
+	ErrCodeNotFound:                http.StatusNotFound,
+	ErrCodeTenderNotFound:          http.StatusNotFound,
+	ErrCodeBidNotFound:             http.StatusNotFound,
+	ErrCodeVersionNotFound:         http.StatusNotFound,
+	ErrCodeReviewsNotFound:         http.StatusNotFound,
+	ErrCodeAttachmentNotFound:      http.StatusNotFound,
+	ErrCodeLotNotFound:             http.StatusNotFound,
+	ErrCodeLotNotOpen:              http.StatusConflict,
+	ErrCodeTenderClosed:            http.StatusConflict,
+	ErrCodeCriterionNotFound:       http.StatusNotFound,
+	ErrCodeEvaluationStarted:       http.StatusConflict,
+	ErrCodeBidsSealed:              http.StatusConflict,
+	ErrCodeSubmissionClosed:        http.StatusConflict,
+	ErrCodeAuctionNotFound:         http.StatusNotFound,
+	ErrCodeAuctionNotRunning:       http.StatusConflict,
+	ErrCodeOfferTooHigh:            http.StatusConflict,
+	ErrCodeQuestionNotFound:        http.StatusNotFound,
+	ErrCodeInvitationNotFound:      http.StatusNotFound,
+	ErrCodeForbiddenNotInvited:     http.StatusForbidden,
+	ErrCodeSupplierNotFound:        http.StatusNotFound,
+	ErrCodeSupplierBlacklisted:     http.StatusForbidden,
+	ErrCodeSupplierNotQualified:    http.StatusForbidden,
+	ErrCodeConflictOfInterest:      http.StatusConflict,
+	ErrCodeFeedbackNotFound:        http.StatusNotFound,
+	ErrCodeFeedbackExists:          http.StatusConflict,
+	ErrCodeNotificationNotFound:    http.StatusNotFound,
+	ErrCodeIdempotencyKeyReused:    http.StatusUnprocessableEntity,
+	ErrCodeIdempotencyKeyInUse:     http.StatusConflict,
+	ErrCodePayloadTooLarge:         http.StatusRequestEntityTooLarge,
+	ErrCodeUnsupportedMediaType:    http.StatusUnsupportedMediaType,
+	ErrCodeMethodNotAllowed:        http.StatusMethodNotAllowed,
+	ErrCodeAlreadyExists:           http.StatusConflict,
+	ErrCodeInternal:                http.StatusInternalServerError,
+	ErrCodeServiceUnavailable:      http.StatusServiceUnavailable,
+}
+
+// sentinelErrorCodes maps the sentinel errors from error.go to error codes. It is consulted
+// for errors that reach the error handler without an explicit code.
+var sentinelErrorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrNotFound, ErrCodeNotFound},
+	{ErrAlreadyExists, ErrCodeAlreadyExists},
+	{ErrSQLQuery, ErrCodeInternal},
+	{ErrEmptyResultSet, ErrCodeNotFound},
+	{ErrNoUser, ErrCodeUserNotFound},
+	{ErrUserNoRightsTender, ErrCodeForbiddenNotResponsible},
+	{ErrUserNoRightsBid, ErrCodeForbiddenNotAuthor},
+	{ErrNoOrganization, ErrCodeOrganizationNotFound},
+	{ErrOrgNoRightsTender, ErrCodeForbiddenOrganization},
+}
+
+// APIError is an error with a stable code, returned by services to describe a failed request.
+type APIError struct {
+	Code ErrorCode
+	// Detail is an optional explanation of this particular occurrence, shown to the client
+	// in the request's language.
+	Detail *LocalizedError
+	// Violations lists the invalid fields, already in the request's language.
+	Violations []Violation
+	// Err is the underlying cause. It is logged but never shown to the client.
+	Err error
+}
+
+// NewAPIError creates an APIError with the given code wrapping err (which may be nil).
+func NewAPIError(code ErrorCode, err error) *APIError {
+	return &APIError{Code: code, Err: err}
+}
+
+func (e *APIError) Error() string {
+	msg := string(e.Code)
+	if e.Detail != nil {
+		msg += ": " + e.Detail.Error()
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// WithDetail sets the client visible detail of the error from the message catalog.
+func (e *APIError) WithDetail(key MessageKey, args ...any) *APIError {
+	e.Detail = NewLocalizedError(key, args...)
+	return e
+}
+
+// WithViolations attaches the invalid fields of the request to the error.
+func (e *APIError) WithViolations(violations ...Violation) *APIError {
+	e.Violations = append(e.Violations, violations...)
+	return e
+}
+
+// Status returns the HTTP status of the error code.
+func (e *APIError) Status() int {
+	return statusForCode(e.Code)
+}
+
+// errorResult is the service-side shorthand for failing a request with an error code.
+func errorResult(code ErrorCode, err error) (ImplResponse, error) {
+	apiErr := NewAPIError(code, err)
+	return Response(apiErr.Status(), nil), apiErr
+}
+
+// apiErrorResult is errorResult for an error built by a helper: an *APIError keeps its code,
+// anything else is reported as an internal error.
+func apiErrorResult(err error) (ImplResponse, error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return Response(apiErr.Status(), nil), apiErr
+	}
+	return errorResult(ErrCodeInternal, err)
+}
+
+// errorDetailResult is errorResult with a client visible explanation.
+func errorDetailResult(code ErrorCode, key MessageKey, args ...any) (ImplResponse, error) {
+	apiErr := NewAPIError(code, nil).WithDetail(key, args...)
+	return Response(apiErr.Status(), nil), apiErr
+}
+
+func statusForCode(code ErrorCode) int {
+	if status, ok := errorCodeStatus[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// codeForStatus picks a generic error code for a bare HTTP status.
+func codeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeInvalidParameter
+	case http.StatusUnauthorized:
+		return ErrCodeUserNotFound
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusServiceUnavailable:
+		return ErrCodeServiceUnavailable
+	default:
+		return ErrCodeInternal
+	}
+}
+
+// problemFromError converts any error that reaches the error handler into a Problem.
+func problemFromError(r *http.Request, err error, result *ImplResponse) Problem {
+	locale := LocaleFromContext(r.Context())
+	var (
+		code       ErrorCode
+		detail     string
+		violations []Violation
+	)
+
+	var apiErr *APIError
+	var parsingErr *ParsingError
+	var requiredErr *RequiredError
+	var validErr *ValidationError
+
+	switch {
+	case errors.As(err, &apiErr):
+		code = apiErr.Code
+		if apiErr.Detail != nil {
+			detail = apiErr.Detail.Localize(locale)
+		}
+		violations = apiErr.Violations
+	case errors.As(err, &parsingErr):
+		code, detail = ErrCodeInvalidParameter, localizeParsingError(parsingErr, locale)
+	case errors.As(err, &requiredErr):
+		code, detail = ErrCodeMissingParameter, Translate(locale, MsgRequiredField, requiredErr.Field)
+	case errors.As(err, &validErr):
+		code, detail = ErrCodeValidationFailed, Translate(locale, MsgInvalidField, validErr.Field)
+	default:
+		for _, s := range sentinelErrorCodes {
+			if errors.Is(err, s.err) {
+				code = s.code
+				break
+			}
+		}
+		if code == "" && result != nil && result.Code >= http.StatusBadRequest {
+			code = codeForStatus(result.Code)
+		}
+		if code == "" {
+			code = ErrCodeInternal
+		}
+	}
+
+	p := newProblem(r, code, detail)
+	p.Violations = violations
+	return p
+}
+
+// localizeParsingError translates the message of a parsing error when it comes from the catalog.
+// Errors of the generated parsers are shown as is.
+func localizeParsingError(e *ParsingError, locale Locale) string {
+	var locErr *LocalizedError
+	if !errors.As(e.Err, &locErr) {
+		return e.Error()
+	}
+	if e.Param == "" {
+		return locErr.Localize(locale)
+	}
+	return e.Param + ": " + locErr.Localize(locale)
+}
+
+func newProblem(r *http.Request, code ErrorCode, detail string) Problem {
+	title := Translate(LocaleFromContext(r.Context()), errorMessageKey(code))
+	return Problem{
+		Type:      "urn:tender-service:error:" + strings.ToLower(strings.ReplaceAll(string(code), "_", "-")),
+		Title:     title,
+		Status:    statusForCode(code),
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: RequestIDFromContext(r.Context()),
+		Reason:    title,
+	}
+}
+
+// WriteProblem writes p as an application/problem+json response.
+func WriteProblem(w http.ResponseWriter, p Problem) error {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
+
+// NotFoundHandler answers requests to unknown routes with a problem response.
+func NotFoundHandler() http.Handler {
+	return RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = WriteProblem(w, newProblem(r, ErrCodeNotFound, ""))
+	}))
+}
+
+// MethodNotAllowedHandler answers requests with an unsupported method with a problem response.
+func MethodNotAllowedHandler() http.Handler {
+	return RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = WriteProblem(w, newProblem(r, ErrCodeMethodNotAllowed, ""))
+	}))
+}
+
+type loggerContextKey struct{}
+
+// WithLogger makes log the logger of the error handler for the requests to inner. Without it
+// the default slog logger is used.
+func WithLogger(inner http.Handler, log *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), loggerContextKey{}, log)))
+	})
+}
+
+func loggerFromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+// logProblem logs the server errors; client errors are the client's business.
+func logProblem(r *http.Request, p Problem, err error) {
+	if p.Status < http.StatusInternalServerError {
+		return
+	}
+	loggerFromContext(r.Context()).Error("request failed",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("request_id", p.RequestID),
+		slog.String("code", string(p.Code)),
+		slog.Int("status", p.Status),
+		slog.Any("error", err))
+}
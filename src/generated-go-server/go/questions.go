@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// questionColumns are the columns read by scanQuestion from tender_questions aliased q.
+const questionColumns = `q.id, q.tender_id, q.question, q.author_id,
+	COALESCE((SELECT username FROM employee WHERE id = q.author_id), ''),
+	COALESCE(q.answer, ''), COALESCE(q.visibility, ''),
+	COALESCE((SELECT username FROM employee WHERE id = q.answered_by), ''),
+	q.answered_at, q.tender_version, q.created_at`
+
+// questionRow is a question as stored. Who asked it is not shown to everyone, so it is turned
+// into a TenderQuestion only for a given reader.
+type questionRow struct {
+	Id             uuid.UUID
+	TenderId       uuid.UUID
+	Question       string
+	AuthorId       *uuid.UUID
+	AuthorUsername string
+	Answer         string
+	Visibility     QuestionVisibility
+	AnsweredBy     string
+	AnsweredAt     *time.Time
+	TenderVersion  *int32
+	CreatedAt      time.Time
+}
+
+func scanQuestion(row pgx.Row) (questionRow, error) {
+	var q questionRow
+	err := row.Scan(&q.Id, &q.TenderId, &q.Question, &q.AuthorId, &q.AuthorUsername,
+		&q.Answer, &q.Visibility, &q.AnsweredBy, &q.AnsweredAt, &q.TenderVersion, &q.CreatedAt)
+	return q, err
+}
+
+// askedBy reports whether the user asked the question. A nil user is an anonymous reader.
+func (q questionRow) askedBy(userId *uuid.UUID) bool {
+	return userId != nil && q.AuthorId != nil && *q.AuthorId == *userId
+}
+
+// visibleTo reports whether the question is listed to a reader: the responsibles see every
+// question, the others their own ones and the questions answered publicly.
+func (q questionRow) visibleTo(userId *uuid.UUID, responsible bool) bool {
+	return responsible || q.askedBy(userId) || (q.Answer != "" && q.Visibility == QUESTION_PUBLIC)
+}
+
+// toQuestion returns the question as seen by the reader. Bidders do not learn who else asked:
+// the author is shown only to the responsibles and to the author.
+func (q questionRow) toQuestion(userId *uuid.UUID, responsible bool) TenderQuestion {
+	question := TenderQuestion{
+		Id:         q.Id.String(),
+		TenderId:   q.TenderId.String(),
+		Question:   q.Question,
+		Answer:     q.Answer,
+		Visibility: q.Visibility,
+		AnsweredBy: q.AnsweredBy,
+		AnsweredAt: formatOptionalTime(q.AnsweredAt),
+		CreatedAt:  q.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if q.TenderVersion != nil {
+		question.TenderVersion = *q.TenderVersion
+	}
+	if responsible || q.askedBy(userId) {
+		question.AuthorUsername = q.AuthorUsername
+	}
+	return question
+}
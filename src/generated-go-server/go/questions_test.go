@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestQuestionVisibility(t *testing.T) {
+	author, other := uuid.New(), uuid.New()
+	version := int32(3)
+	unanswered := questionRow{Id: uuid.New(), TenderId: uuid.New(), AuthorId: &author, AuthorUsername: "user1", Question: "Сроки?", CreatedAt: schedulerEpoch}
+	private := unanswered
+	private.Answer, private.Visibility = "До мая", QUESTION_PRIVATE
+	public := unanswered
+	public.Answer, public.Visibility, public.AnsweredBy, public.TenderVersion = "До июня", QUESTION_PUBLIC, "user2", &version
+
+	for _, tc := range []struct {
+		name        string
+		question    questionRow
+		user        *uuid.UUID
+		responsible bool
+		want        bool
+	}{
+		{"unanswered, to its author", unanswered, &author, false, true},
+		{"unanswered, to another bidder", unanswered, &other, false, false},
+		{"unanswered, to a responsible", unanswered, &other, true, true},
+		{"private answer, to another bidder", private, &other, false, false},
+		{"private answer, to its author", private, &author, false, true},
+		{"public answer, to another bidder", public, &other, false, true},
+		{"public answer, to an anonymous reader", public, nil, false, true},
+		{"private answer, to an anonymous reader", private, nil, false, false},
+	} {
+		if got := tc.question.visibleTo(tc.user, tc.responsible); got != tc.want {
+			t.Errorf("%s: visible = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	if got := public.toQuestion(&other, false); got.AuthorUsername != "" || got.TenderVersion != 3 || got.AnsweredBy != "user2" {
+		t.Errorf("public answer to another bidder: %+v", got)
+	}
+	if got := public.toQuestion(&author, false); got.AuthorUsername != "user1" {
+		t.Errorf("the author does not see their name: %+v", got)
+	}
+	if got := public.toQuestion(nil, true); got.AuthorUsername != "user1" || got.CreatedAt != schedulerEpoch.Format(time.RFC3339) {
+		t.Errorf("a responsible: %+v", got)
+	}
+}
+
+func TestAnswerQuestionRequestValidation(t *testing.T) {
+	router := NewRouter(NewQuestionAPIController(nil))
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{"visibility":"Public"}`, http.StatusUnprocessableEntity},
+		{`{"answer":"Да","visibility":"Everyone"}`, http.StatusBadRequest},
+		{`{"answer":"Да","visibility":"Private","bumpVersion":true}`, http.StatusBadRequest},
+		{`{"answer":"` + strings.Repeat("а", maxAnswerLength+1) + `","visibility":"Public"}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/tenders/"+importOrgID+"/questions/"+importOrgID+"/answer?username=user1", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%.60s: got %d %s, want %d", tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// bidOutcome is what a bid contributes to the reputation of its author. reputation_bids keeps
+// the outcome last counted for every bid, so that a change is applied as a difference.
+type bidOutcome struct {
+	AuthorType  BidAuthorType
+	AuthorId    uuid.UUID
+	Decided     bool
+	Won         bool
+	Canceled    bool
+	RatingSum   int32
+	RatingCount int32
+}
+
+// reputationCounters are the counters kept per author in the reputation table.
+type reputationCounters struct {
+	Bids          int32
+	Decided       int32
+	Wins          int32
+	Cancellations int32
+	RatingSum     int32
+	RatingCount   int32
+}
+
+func (o bidOutcome) counters() reputationCounters {
+	c := reputationCounters{Bids: 1, RatingSum: o.RatingSum, RatingCount: o.RatingCount}
+	if o.Decided {
+		c.Decided = 1
+	}
+	if o.Won {
+		c.Wins = 1
+	}
+	if o.Canceled {
+		c.Cancellations = 1
+	}
+	return c
+}
+
+func (c reputationCounters) minus(o reputationCounters) reputationCounters {
+	return reputationCounters{
+		Bids:          c.Bids - o.Bids,
+		Decided:       c.Decided - o.Decided,
+		Wins:          c.Wins - o.Wins,
+		Cancellations: c.Cancellations - o.Cancellations,
+		RatingSum:     c.RatingSum - o.RatingSum,
+		RatingCount:   c.RatingCount - o.RatingCount,
+	}
+}
+
+// metrics derives the published rates from the counters. A rate without a base is left out
+// rather than reported as zero.
+func (c reputationCounters) metrics() ReputationMetrics {
+	m := ReputationMetrics{
+		Bids:          c.Bids,
+		DecidedBids:   c.Decided,
+		Wins:          c.Wins,
+		Cancellations: c.Cancellations,
+		Ratings:       c.RatingCount,
+	}
+	if c.Decided > 0 {
+		m.WinRate = roundedRatio(c.Wins, c.Decided, 1000)
+	}
+	if c.Bids > 0 {
+		m.CancellationRate = roundedRatio(c.Cancellations, c.Bids, 1000)
+	}
+	if c.RatingCount > 0 {
+		m.AverageRating = roundedRatio(c.RatingSum, c.RatingCount, 100)
+	}
+	return m
+}
+
+func roundedRatio(part int32, whole int32, scale float64) *float64 {
+	r := math.Round(float64(part)/float64(whole)*scale) / scale
+	return &r
+}
+
+// reputationChange is a difference to add to the counters of an author.
+type reputationChange struct {
+	AuthorType BidAuthorType
+	AuthorId   uuid.UUID
+	Delta      reputationCounters
+}
+
+// reputationChanges turns the outcome of a bid counted before, nil for a new bid, and its
+// current outcome into the changes of the counters. A bid that moved to another author is
+// taken off the previous one.
+func reputationChanges(previous *bidOutcome, current bidOutcome) []reputationChange {
+	if previous == nil {
+		return []reputationChange{{AuthorType: current.AuthorType, AuthorId: current.AuthorId, Delta: current.counters()}}
+	}
+	if previous.AuthorType != current.AuthorType || previous.AuthorId != current.AuthorId {
+		return []reputationChange{
+			{AuthorType: previous.AuthorType, AuthorId: previous.AuthorId, Delta: reputationCounters{}.minus(previous.counters())},
+			{AuthorType: current.AuthorType, AuthorId: current.AuthorId, Delta: current.counters()},
+		}
+	}
+	delta := current.counters().minus(previous.counters())
+	if delta == (reputationCounters{}) {
+		return nil
+	}
+	return []reputationChange{{AuthorType: current.AuthorType, AuthorId: current.AuthorId, Delta: delta}}
+}
+
+// bidOutcomeColumns are the columns read into a bidOutcome from bids aliased b.
+const bidOutcomeColumns = `b.author_type, b.author_id,
+	EXISTS (SELECT 1 FROM bid_decisions d WHERE d.bid_id = b.bid_id),
+	EXISTS (SELECT 1 FROM bid_decisions d WHERE d.bid_id = b.bid_id AND d.decision = 'Approved'),
+	b.status = 'Canceled',
+	(SELECT COALESCE(SUM(f.rating), 0)::int FROM bid_feedback f WHERE f.bid_id = b.bid_id AND f.retracted_at IS NULL),
+	(SELECT COUNT(f.rating)::int FROM bid_feedback f WHERE f.bid_id = b.bid_id AND f.retracted_at IS NULL)`
+
+// refreshReputation counts the current outcome of the bid in the reputation of its author. It
+// runs in the transaction that changed the bid, its decisions or its feedback. A bid that no
+// longer exists keeps counting as it was last seen: reputation is a record of what happened.
+func refreshReputation(ctx context.Context, tx pgx.Tx, bidId uuid.UUID) error {
+	var current bidOutcome
+	err := tx.QueryRow(ctx, `SELECT `+bidOutcomeColumns+` FROM bids b WHERE b.bid_id = $1`, bidId).Scan(
+		&current.AuthorType, &current.AuthorId, &current.Decided, &current.Won, &current.Canceled,
+		&current.RatingSum, &current.RatingCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	var previous *bidOutcome
+	var counted bidOutcome
+	err = tx.QueryRow(ctx, `
+	SELECT author_type, author_id, decided, won, canceled, rating_sum, rating_count
+	FROM reputation_bids WHERE bid_id = $1 FOR UPDATE`, bidId).Scan(
+		&counted.AuthorType, &counted.AuthorId, &counted.Decided, &counted.Won, &counted.Canceled,
+		&counted.RatingSum, &counted.RatingCount)
+	switch {
+	case err == nil:
+		previous = &counted
+	case !errors.Is(err, pgx.ErrNoRows):
+		return err
+	}
+
+	changes := reputationChanges(previous, current)
+	if len(changes) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `
+	INSERT INTO reputation_bids (bid_id, author_type, author_id, decided, won, canceled, rating_sum, rating_count)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (bid_id) DO UPDATE SET author_type = EXCLUDED.author_type, author_id = EXCLUDED.author_id,
+		decided = EXCLUDED.decided, won = EXCLUDED.won, canceled = EXCLUDED.canceled,
+		rating_sum = EXCLUDED.rating_sum, rating_count = EXCLUDED.rating_count`,
+		bidId, string(current.AuthorType), current.AuthorId, current.Decided, current.Won, current.Canceled,
+		current.RatingSum, current.RatingCount); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if _, err := tx.Exec(ctx, `
+		INSERT INTO reputation (author_type, author_id, bids, decided, wins, cancellations, rating_sum, rating_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (author_type, author_id) DO UPDATE SET bids = reputation.bids + EXCLUDED.bids,
+			decided = reputation.decided + EXCLUDED.decided, wins = reputation.wins + EXCLUDED.wins,
+			cancellations = reputation.cancellations + EXCLUDED.cancellations,
+			rating_sum = reputation.rating_sum + EXCLUDED.rating_sum,
+			rating_count = reputation.rating_count + EXCLUDED.rating_count,
+			updated_at = CURRENT_TIMESTAMP`,
+			string(c.AuthorType), c.AuthorId, c.Delta.Bids, c.Delta.Decided, c.Delta.Wins, c.Delta.Cancellations,
+			c.Delta.RatingSum, c.Delta.RatingCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecountReputation counts every existing bid again and rebuilds the counters from the per-bid
+// outcomes. It is for bids written around the API, such as the demo data.
+func RecountReputation(ctx context.Context, pg *Postgres) error {
+	tx, err := pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+	INSERT INTO reputation_bids (bid_id, author_type, author_id, decided, won, canceled, rating_sum, rating_count)
+	SELECT b.bid_id, `+bidOutcomeColumns+` FROM bids b
+	ON CONFLICT (bid_id) DO UPDATE SET author_type = EXCLUDED.author_type, author_id = EXCLUDED.author_id,
+		decided = EXCLUDED.decided, won = EXCLUDED.won, canceled = EXCLUDED.canceled,
+		rating_sum = EXCLUDED.rating_sum, rating_count = EXCLUDED.rating_count`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+	INSERT INTO reputation (author_type, author_id, bids, decided, wins, cancellations, rating_sum, rating_count)
+	SELECT author_type, author_id, COUNT(*), COUNT(*) FILTER (WHERE decided), COUNT(*) FILTER (WHERE won),
+		COUNT(*) FILTER (WHERE canceled), SUM(rating_sum), SUM(rating_count)
+	FROM reputation_bids GROUP BY author_type, author_id
+	ON CONFLICT (author_type, author_id) DO UPDATE SET bids = EXCLUDED.bids, decided = EXCLUDED.decided,
+		wins = EXCLUDED.wins, cancellations = EXCLUDED.cancellations, rating_sum = EXCLUDED.rating_sum,
+		rating_count = EXCLUDED.rating_count, updated_at = CURRENT_TIMESTAMP`); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// reputationColumns are the columns read by scanReputationCounters from reputation aliased r.
+const reputationColumns = `r.bids, r.decided, r.wins, r.cancellations, r.rating_sum, r.rating_count`
+
+func scanReputationCounters(row pgx.Row, extra ...any) (reputationCounters, error) {
+	var c reputationCounters
+	dest := append([]any{&c.Bids, &c.Decided, &c.Wins, &c.Cancellations, &c.RatingSum, &c.RatingCount}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return reputationCounters{}, err
+	}
+	return c, nil
+}
+
+// reputationKey identifies an author among the reputations of several bids.
+func reputationKey(authorType BidAuthorType, authorId string) string {
+	return string(authorType) + "/" + authorId
+}
+
+// bidReputations reads the reputation of the authors of the bids, keyed by reputationKey.
+// Authors without a counted bid are missing from the result.
+func (s *DefaultAPIService) bidReputations(ctx context.Context, bids []Bid) (map[string]*ReputationMetrics, error) {
+	reputations := make(map[string]*ReputationMetrics)
+	if len(bids) == 0 {
+		return reputations, nil
+	}
+	authorIds := make([]uuid.UUID, 0, len(bids))
+	for _, bid := range bids {
+		id, err := uuid.Parse(bid.AuthorId)
+		if err != nil {
+			continue
+		}
+		authorIds = append(authorIds, id)
+	}
+
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT `+reputationColumns+`, r.author_type, r.author_id FROM reputation r
+	WHERE r.author_id = ANY($1)`, authorIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var authorType BidAuthorType
+		var authorId uuid.UUID
+		counters, err := scanReputationCounters(rows, &authorType, &authorId)
+		if err != nil {
+			return nil, err
+		}
+		metrics := counters.metrics()
+		reputations[reputationKey(authorType, authorId.String())] = &metrics
+	}
+	return reputations, rows.Err()
+}
+
+// authorReputation reads the reputation of an author; an author without bids has zero counters.
+// For an organization the counters of its responsibles are summed up as well.
+func (s *DefaultAPIService) authorReputation(ctx context.Context, authorType BidAuthorType, authorId uuid.UUID) (*Reputation, error) {
+	reputation := &Reputation{AuthorType: authorType, AuthorId: authorId.String()}
+
+	var updatedAt time.Time
+	counters, err := scanReputationCounters(s.pg.Pool.QueryRow(ctx, `
+	SELECT `+reputationColumns+`, r.updated_at FROM reputation r
+	WHERE r.author_type = $1 AND r.author_id = $2`, string(authorType), authorId), &updatedAt)
+	switch {
+	case err == nil:
+		reputation.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, err
+	}
+	reputation.Metrics = counters.metrics()
+
+	if authorType == ORGANIZATION {
+		employees, err := scanReputationCounters(s.pg.Pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(r.bids), 0)::int, COALESCE(SUM(r.decided), 0)::int, COALESCE(SUM(r.wins), 0)::int,
+			COALESCE(SUM(r.cancellations), 0)::int, COALESCE(SUM(r.rating_sum), 0)::int, COALESCE(SUM(r.rating_count), 0)::int
+		FROM reputation r
+		WHERE r.author_type = 'User' AND r.author_id IN (
+			SELECT user_id FROM organization_responsible WHERE organization_id = $1)`, authorId))
+		if err != nil {
+			return nil, err
+		}
+		metrics := employees.metrics()
+		reputation.Employees = &metrics
+	}
+	return reputation, nil
+}
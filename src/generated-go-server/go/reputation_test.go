@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestReputationChanges(t *testing.T) {
+	author := uuid.MustParse(importOrgID)
+	created := bidOutcome{AuthorType: ORGANIZATION, AuthorId: author}
+
+	changes := reputationChanges(nil, created)
+	if len(changes) != 1 || changes[0].Delta != (reputationCounters{Bids: 1}) {
+		t.Fatalf("new bid: got %+v", changes)
+	}
+
+	if changes := reputationChanges(&created, created); changes != nil {
+		t.Errorf("unchanged bid: got %+v", changes)
+	}
+
+	won := created
+	won.Decided, won.Won, won.RatingSum, won.RatingCount = true, true, 9, 2
+	changes = reputationChanges(&created, won)
+	want := reputationCounters{Decided: 1, Wins: 1, RatingSum: 9, RatingCount: 2}
+	if len(changes) != 1 || changes[0].Delta != want {
+		t.Errorf("won bid: got %+v, want %+v", changes, want)
+	}
+
+	// A rollback to a version without the cancellation takes it back.
+	canceled := created
+	canceled.Canceled = true
+	changes = reputationChanges(&canceled, created)
+	if len(changes) != 1 || changes[0].Delta != (reputationCounters{Cancellations: -1}) {
+		t.Errorf("restored bid: got %+v", changes)
+	}
+
+	moved := won
+	moved.AuthorType, moved.AuthorId = USER, uuid.New()
+	changes = reputationChanges(&won, moved)
+	if len(changes) != 2 {
+		t.Fatalf("moved bid: got %+v", changes)
+	}
+	if changes[0].AuthorId != author || changes[0].Delta != (reputationCounters{}).minus(won.counters()) {
+		t.Errorf("moved bid, previous author: got %+v", changes[0])
+	}
+	if changes[1].AuthorId != moved.AuthorId || changes[1].Delta != moved.counters() {
+		t.Errorf("moved bid, current author: got %+v", changes[1])
+	}
+}
+
+func TestReputationMetrics(t *testing.T) {
+	empty := reputationCounters{}.metrics()
+	if empty.WinRate != nil || empty.CancellationRate != nil || empty.AverageRating != nil {
+		t.Errorf("no bids: got %+v", empty)
+	}
+
+	m := reputationCounters{Bids: 3, Decided: 3, Wins: 1, Cancellations: 1, RatingSum: 14, RatingCount: 3}.metrics()
+	if m.WinRate == nil || *m.WinRate != 0.333 {
+		t.Errorf("win rate = %v", m.WinRate)
+	}
+	if m.CancellationRate == nil || *m.CancellationRate != 0.333 {
+		t.Errorf("cancellation rate = %v", m.CancellationRate)
+	}
+	if m.AverageRating == nil || *m.AverageRating != 4.67 {
+		t.Errorf("average rating = %v", m.AverageRating)
+	}
+
+	undecided := reputationCounters{Bids: 2}.metrics()
+	if undecided.WinRate != nil || undecided.CancellationRate == nil || *undecided.CancellationRate != 0 {
+		t.Errorf("undecided bids: got %+v", undecided)
+	}
+}
+
+func TestReputationRequestValidation(t *testing.T) {
+	router := NewRouter(NewReputationAPIController(nil))
+	cases := []struct {
+		path string
+		want int
+	}{
+		{"/api/reputation/Team/" + importOrgID + "?username=user1", http.StatusBadRequest},
+		{"/api/reputation/Organization/" + importOrgID, http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.path, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
@@ -46,10 +46,13 @@ const errMsgMaxValueConstraint = "provided parameter is not respecting maximum v
 // NewRouter creates a new router for any number of api routers
 func NewRouter(routers ...Router) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
+	router.NotFoundHandler = NotFoundHandler()
+	router.MethodNotAllowedHandler = MethodNotAllowedHandler()
 	for _, api := range routers {
 		for name, route := range api.Routes() {
 			var handler http.Handler = route.HandlerFunc
 			handler = Logger(handler, name)
+			handler = RequestID(handler)
 
 			router.
 				Methods(route.Method).
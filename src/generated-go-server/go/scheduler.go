@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// schedulerRetryDelay is how long the scheduler waits before running a failed job again.
+const schedulerRetryDelay = 30 * time.Second
+
+// JobFunc is a job run once by the Scheduler.
+type JobFunc func(ctx context.Context) error
+
+// Scheduler runs one-off jobs at a set time, unlike Workers, which run jobs periodically.
+// Every job has a key, and scheduling a key again moves its job: this is how an auction
+// extended by a late offer pushes back its close. Jobs live in memory only; their owners
+// schedule them again from the database on start.
+type Scheduler struct {
+	clock  Clock
+	log    *slog.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	jobs    map[string]*scheduledJob
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+type scheduledJob struct {
+	at    time.Time
+	fn    JobFunc
+	timer Timer
+}
+
+func NewScheduler(clock Clock, log *slog.Logger) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		clock:  clock,
+		log:    log.With(slog.String("op", "Scheduler")),
+		ctx:    ctx,
+		cancel: cancel,
+		jobs:   make(map[string]*scheduledJob),
+	}
+}
+
+// Schedule runs fn at the given time, replacing the job scheduled under key if there is one.
+// A time in the past runs the job right away. A job that fails is retried after
+// schedulerRetryDelay unless it has been scheduled again in the meantime.
+func (s *Scheduler) Schedule(key string, at time.Time, fn JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.jobs[key]; ok {
+		old.timer.Stop()
+	}
+	s.schedule(key, at, fn)
+}
+
+// schedule adds the job; s.mu must be held.
+func (s *Scheduler) schedule(key string, at time.Time, fn JobFunc) {
+	if s.stopped {
+		return
+	}
+	job := &scheduledJob{at: at, fn: fn}
+	job.timer = s.clock.AfterFunc(at.Sub(s.clock.Now()), func() { s.run(key, job) })
+	s.jobs[key] = job
+}
+
+// Cancel drops the job scheduled under key. A job that is already running is not stopped.
+func (s *Scheduler) Cancel(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job, ok := s.jobs[key]; ok {
+		job.timer.Stop()
+		delete(s.jobs, key)
+	}
+}
+
+// Next returns the time the job scheduled under key is due.
+func (s *Scheduler) Next(key string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	return job.at, true
+}
+
+// Stop drops every pending job, cancels the context of the running ones and waits for them.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	for key, job := range s.jobs {
+		job.timer.Stop()
+		delete(s.jobs, key)
+	}
+	s.mu.Unlock()
+
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(key string, job *scheduledJob) {
+	s.mu.Lock()
+	if s.stopped || s.jobs[key] != job {
+		// Moved or cancelled after the timer had already fired.
+		s.mu.Unlock()
+		return
+	}
+	delete(s.jobs, key)
+	s.wg.Add(1)
+	s.mu.Unlock()
+	defer s.wg.Done()
+
+	err := job.fn(s.ctx)
+	if err == nil || s.ctx.Err() != nil {
+		return
+	}
+	s.log.Error("scheduled job failed", slog.String("job", key), slog.Any("error", err))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, rescheduled := s.jobs[key]; !rescheduled {
+		s.schedule(key, s.clock.Now().Add(schedulerRetryDelay), job.fn)
+	}
+}
@@ -0,0 +1,128 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+var schedulerEpoch = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testScheduler(t *testing.T) (*Scheduler, *FakeClock) {
+	t.Helper()
+	clock := NewFakeClock(schedulerEpoch)
+	s := NewScheduler(clock, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(s.Stop)
+	return s, clock
+}
+
+func TestFakeClockOrder(t *testing.T) {
+	clock := NewFakeClock(schedulerEpoch)
+	var fired []string
+	var seen []time.Time
+	record := func(name string) func() {
+		return func() {
+			fired = append(fired, name)
+			seen = append(seen, clock.Now())
+		}
+	}
+
+	clock.AfterFunc(3*time.Second, record("c"))
+	clock.AfterFunc(time.Second, record("a"))
+	stopped := clock.AfterFunc(2*time.Second, record("stopped"))
+	clock.AfterFunc(time.Second, func() {
+		record("b")()
+		clock.AfterFunc(time.Second, record("chained"))
+	})
+	if !stopped.Stop() || stopped.Stop() {
+		t.Error("Stop should report true once")
+	}
+
+	clock.Advance(2 * time.Second)
+	if want := []string{"a", "b", "chained"}; !slices.Equal(fired, want) {
+		t.Errorf("fired %v, want %v", fired, want)
+	}
+	if !seen[2].Equal(schedulerEpoch.Add(2 * time.Second)) {
+		t.Errorf("chained timer saw %v", seen[2])
+	}
+	if clock.Pending() != 1 {
+		t.Errorf("pending = %d, want 1", clock.Pending())
+	}
+
+	clock.Advance(time.Hour)
+	if fired[len(fired)-1] != "c" || !clock.Now().Equal(schedulerEpoch.Add(time.Hour+2*time.Second)) {
+		t.Errorf("fired %v at %v", fired, clock.Now())
+	}
+}
+
+func TestSchedulerMovesJobs(t *testing.T) {
+	s, clock := testScheduler(t)
+	var runs []time.Time
+	job := func(ctx context.Context) error {
+		runs = append(runs, clock.Now())
+		return nil
+	}
+
+	s.Schedule("auction", schedulerEpoch.Add(time.Minute), job)
+	clock.Advance(50 * time.Second)
+	// A late offer pushes the end back.
+	s.Schedule("auction", schedulerEpoch.Add(3*time.Minute), job)
+	if next, ok := s.Next("auction"); !ok || !next.Equal(schedulerEpoch.Add(3*time.Minute)) {
+		t.Errorf("Next = %v, %v", next, ok)
+	}
+
+	clock.Advance(time.Minute)
+	if len(runs) != 0 {
+		t.Fatalf("the moved job ran at %v", runs)
+	}
+	clock.Advance(time.Hour)
+	if len(runs) != 1 || !runs[0].Equal(schedulerEpoch.Add(3*time.Minute)) {
+		t.Errorf("runs = %v, want one at the new time", runs)
+	}
+	if _, ok := s.Next("auction"); ok {
+		t.Error("a job that ran is still scheduled")
+	}
+
+	s.Schedule("cancelled", clock.Now().Add(time.Second), job)
+	s.Cancel("cancelled")
+	s.Schedule("overdue", clock.Now().Add(-time.Hour), job)
+	clock.Advance(time.Minute)
+	if len(runs) != 2 {
+		t.Errorf("runs = %v, want the overdue job only", runs)
+	}
+}
+
+func TestSchedulerRetriesFailedJobs(t *testing.T) {
+	s, clock := testScheduler(t)
+	attempts := 0
+	s.Schedule("flaky", schedulerEpoch.Add(time.Second), func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+
+	clock.Advance(time.Second)
+	if attempts != 1 {
+		t.Fatalf("attempts = %d", attempts)
+	}
+	if next, ok := s.Next("flaky"); !ok || !next.Equal(clock.Now().Add(schedulerRetryDelay)) {
+		t.Errorf("retry scheduled at %v, %v", next, ok)
+	}
+	clock.Advance(2 * schedulerRetryDelay)
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if _, ok := s.Next("flaky"); ok {
+		t.Error("a job that succeeded is still scheduled")
+	}
+
+	s.Stop()
+	s.Schedule("late", clock.Now(), func(ctx context.Context) error { t.Error("ran after Stop"); return nil })
+	clock.Advance(time.Second)
+}
@@ -0,0 +1,329 @@
+package openapi
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// sealingKeySize is the length of the AES-256 key that encrypts sealed bids.
+	sealingKeySize = 32
+	// sealedPrefix marks a bid field stored encrypted. Sealed values are kept in the regular
+	// columns so that bid versions and rollbacks carry them unchanged.
+	sealedPrefix = "sealed:v1:"
+	// revealBatchSize is how many tenders one run of the reveal worker handles.
+	revealBatchSize = 100
+	// eventBidsRevealed is the outbox event recorded when the bids of a sealed tender are revealed.
+	eventBidsRevealed = "tender.bids_revealed"
+)
+
+var (
+	errSealingDisabled = errors.New("sealed bids key is not configured")
+	errSealedCorrupt   = errors.New("sealed value is corrupt")
+)
+
+// Sealer encrypts the names and descriptions of bids on sealed tenders with AES-256-GCM.
+// The bid id is authenticated with every value, so a value cannot be moved to another bid.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// NewSealer creates a sealer from a base64 encoded 32 byte key.
+func NewSealer(key string) (*Sealer, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+	if len(raw) != sealingKeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", sealingKeySize, len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal encrypts a field of the bid. Empty values stay empty.
+func (s *Sealer) Seal(bidId uuid.UUID, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if s == nil {
+		return "", errSealingDisabled
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(value), bidId[:])
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a field of the bid. Values that are not sealed are returned as they are.
+func (s *Sealer) Open(bidId uuid.UUID, value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, sealedPrefix)
+	if !ok {
+		return value, nil
+	}
+	if s == nil {
+		return "", errSealingDisabled
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) < s.aead.NonceSize() {
+		return "", errSealedCorrupt
+	}
+	nonceSize := s.aead.NonceSize()
+	plain, err := s.aead.Open(nil, raw[:nonceSize], raw[nonceSize:], bidId[:])
+	if err != nil {
+		return "", errSealedCorrupt
+	}
+	return string(plain), nil
+}
+
+// sealBid encrypts the name and description of the bid in place.
+func (s *Sealer) sealBid(bidId uuid.UUID, name, description *string) error {
+	var err error
+	if *name, err = s.Seal(bidId, *name); err != nil {
+		return err
+	}
+	*description, err = s.Seal(bidId, *description)
+	return err
+}
+
+// openBid decrypts the name and description of the bid in place.
+func (s *Sealer) openBid(bidId uuid.UUID, name, description *string) error {
+	var err error
+	if *name, err = s.Open(bidId, *name); err != nil {
+		return err
+	}
+	*description, err = s.Open(bidId, *description)
+	return err
+}
+
+// tenderSealingColumns are the tender columns scanned by tenderSealing.
+const tenderSealingColumns = "COALESCE(sealed, false), submission_deadline, revealed_at"
+
+// tenderSealing scans the submission deadline and sealing state of a tender.
+type tenderSealing struct {
+	Sealed     bool
+	Deadline   *time.Time
+	RevealedAt *time.Time
+}
+
+func (t tenderSealing) apply(tender *Tender) {
+	tender.Sealed = t.Sealed
+	tender.SubmissionDeadline = formatOptionalTime(t.Deadline)
+	tender.RevealedAt = formatOptionalTime(t.RevealedAt)
+}
+
+// formatOptionalTime formats a nullable timestamp column in UTC, so that a deadline reads the
+// same whatever offset it was set with.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseOptionalTime returns the value of a nullable timestamp column for an RFC3339 string.
+func parseOptionalTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// deadlinePassed reports whether a submission deadline is set and not in the future.
+func deadlinePassed(deadline string, now time.Time) bool {
+	t := parseOptionalTime(deadline)
+	return t != nil && !t.After(now)
+}
+
+// bidsSealed reports whether the bids of the tender are still hidden from its organization.
+func (t *Tender) bidsSealed() bool {
+	return t.Sealed && t.RevealedAt == ""
+}
+
+// sealedBidsError is returned to responsibles who try to act on bids they cannot see yet.
+func sealedBidsError(tender *Tender) *APIError {
+	return NewAPIError(ErrCodeBidsSealed, nil).WithDetail(MsgBidsSealed, tender.SubmissionDeadline)
+}
+
+// maskSealedBid removes everything from the bid that the tender organization may not see
+// before the reveal. The price is hidden too: it is the most telling part of a bid.
+func maskSealedBid(bid *Bid) {
+	bid.Name = ""
+	bid.Description = ""
+	bid.Price = nil
+	bid.Sealed = true
+}
+
+// checkSubmissionOpen locks the tender and fails once its submission deadline has passed.
+// Keeping the lock until the bid is saved stops the reveal from running in between, and the
+// clock of the database is used so that both sides agree on when the deadline is.
+func checkSubmissionOpen(ctx context.Context, tx pgx.Tx, tenderId uuid.UUID) error {
+	var deadline *time.Time
+	var open bool
+	err := tx.QueryRow(ctx, `
+	SELECT submission_deadline, submission_deadline IS NULL OR submission_deadline > clock_timestamp()
+	FROM tenders WHERE id = $1 FOR UPDATE`, tenderId).Scan(&deadline, &open)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return NewAPIError(ErrCodeTenderNotFound, ErrNotFound)
+	}
+	if err != nil {
+		return NewAPIError(ErrCodeInternal, err)
+	}
+	if !open {
+		return NewAPIError(ErrCodeSubmissionClosed, nil).WithDetail(MsgSubmissionClosed, formatOptionalTime(deadline))
+	}
+	return nil
+}
+
+// unsealBid decrypts the bid for its author.
+func (s *DefaultAPIService) unsealBid(bid *Bid) error {
+	bidIdUUID, err := s.ConvertIntoUUID(bid.Id)
+	if err != nil {
+		return err
+	}
+	return s.sealer.openBid(bidIdUUID, &bid.Name, &bid.Description)
+}
+
+// BidRevealer decrypts the bids of sealed tenders once their submission deadline has passed.
+type BidRevealer struct {
+	pg     *Postgres
+	sealer *Sealer
+	log    *slog.Logger
+}
+
+func NewBidRevealer(pg *Postgres, sealer *Sealer, log *slog.Logger) *BidRevealer {
+	return &BidRevealer{pg: pg, sealer: sealer, log: log}
+}
+
+// Run reveals every sealed tender whose deadline has passed. It is a WorkerFunc; a tender
+// that fails is retried on the next run without holding up the others.
+func (r *BidRevealer) Run(ctx context.Context) error {
+	rows, err := r.pg.Pool.Query(ctx, `
+	SELECT id FROM tenders
+	WHERE sealed AND revealed_at IS NULL AND submission_deadline <= clock_timestamp()
+	ORDER BY submission_deadline
+	LIMIT $1`, revealBatchSize)
+	if err != nil {
+		return err
+	}
+	tenderIds, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, tenderId := range tenderIds {
+		if err := r.reveal(ctx, tenderId); err != nil {
+			errs = append(errs, fmt.Errorf("tender %s: %w", tenderId, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reveal decrypts the bids of one tender and their saved versions, marks the tender revealed
+// and records the event in one transaction, so the bids are either all sealed or all open.
+func (r *BidRevealer) reveal(ctx context.Context, tenderId uuid.UUID) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Bids being submitted hold the same lock, so none can slip in after the reveal.
+	var pending bool
+	err = tx.QueryRow(ctx, `
+	SELECT revealed_at IS NULL FROM tenders WHERE id = $1 AND sealed FOR UPDATE`, tenderId).Scan(&pending)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !pending {
+		// Revealed by another instance in the meantime.
+		return nil
+	}
+
+	bidCount, err := r.openRows(ctx, tx, `
+	SELECT bid_id, name, COALESCE(description, '') FROM bids WHERE tender_id = $1`, `
+	UPDATE bids SET name = $2, description = $3 WHERE bid_id = $1`, tenderId)
+	if err != nil {
+		return fmt.Errorf("reveal bids: %w", err)
+	}
+	if _, err := r.openRows(ctx, tx, `
+	SELECT v.bid_id, v.name, COALESCE(v.description, '')
+	FROM bids_versions v JOIN bids b ON b.bid_id = v.bid_id
+	WHERE b.tender_id = $1`, `
+	UPDATE bids_versions SET name = $2, description = $3 WHERE bid_id = $1`, tenderId); err != nil {
+		return fmt.Errorf("reveal bid versions: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tenders SET revealed_at = clock_timestamp() WHERE id = $1`, tenderId); err != nil {
+		return err
+	}
+	if err := recordEvent(ctx, tx, eventBidsRevealed, tenderId, map[string]any{"tenderId": tenderId, "bidCount": bidCount}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	r.log.Info("sealed bids revealed", slog.String("tender_id", tenderId.String()), slog.Int("bids", bidCount))
+	return nil
+}
+
+// openRows decrypts the name and description of every row returned by query and writes
+// them back with update. It returns the number of rows read.
+func (r *BidRevealer) openRows(ctx context.Context, tx pgx.Tx, query, update string, tenderId uuid.UUID) (int, error) {
+	type sealedRow struct {
+		bidId             uuid.UUID
+		name, description string
+	}
+
+	rows, err := tx.Query(ctx, query, tenderId)
+	if err != nil {
+		return 0, err
+	}
+	sealed, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (sealedRow, error) {
+		var s sealedRow
+		err := row.Scan(&s.bidId, &s.name, &s.description)
+		return s, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, row := range sealed {
+		if !strings.HasPrefix(row.name, sealedPrefix) && !strings.HasPrefix(row.description, sealedPrefix) {
+			continue
+		}
+		if err := r.sealer.openBid(row.bidId, &row.name, &row.description); err != nil {
+			return 0, fmt.Errorf("bid %s: %w", row.bidId, err)
+		}
+		if _, err := tx.Exec(ctx, update, row.bidId, row.name, row.description); err != nil {
+			return 0, err
+		}
+	}
+	return len(sealed), nil
+}
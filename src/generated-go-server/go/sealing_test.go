@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func testSealer(t *testing.T) *Sealer {
+	t.Helper()
+	sealer, err := NewSealer(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", sealingKeySize))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sealer
+}
+
+func TestSealerRoundTrip(t *testing.T) {
+	sealer := testSealer(t)
+	bidId := uuid.New()
+
+	sealed, err := sealer.Seal(bidId, "Доставка за 3 дня")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealed, sealedPrefix) || strings.Contains(sealed, "Доставка") {
+		t.Fatalf("sealed value %q", sealed)
+	}
+	if again, _ := sealer.Seal(bidId, "Доставка за 3 дня"); again == sealed {
+		t.Error("sealing the same text twice gave the same value")
+	}
+
+	plain, err := sealer.Open(bidId, sealed)
+	if err != nil || plain != "Доставка за 3 дня" {
+		t.Errorf("Open = %q, %v", plain, err)
+	}
+	if _, err := sealer.Open(uuid.New(), sealed); !errors.Is(err, errSealedCorrupt) {
+		t.Errorf("Open with another bid id: err = %v, want errSealedCorrupt", err)
+	}
+	if _, err := sealer.Open(bidId, sealed[:len(sealed)-4]); !errors.Is(err, errSealedCorrupt) {
+		t.Errorf("Open of a truncated value: err = %v, want errSealedCorrupt", err)
+	}
+
+	if empty, _ := sealer.Seal(bidId, ""); empty != "" {
+		t.Errorf("Seal of an empty value = %q", empty)
+	}
+	if plain, _ := sealer.Open(bidId, "открытое"); plain != "открытое" {
+		t.Errorf("Open of a plain value = %q", plain)
+	}
+
+	var disabled *Sealer
+	if _, err := disabled.Seal(bidId, "x"); !errors.Is(err, errSealingDisabled) {
+		t.Errorf("Seal without a key: err = %v", err)
+	}
+	if _, err := disabled.Open(bidId, sealed); !errors.Is(err, errSealingDisabled) {
+		t.Errorf("Open without a key: err = %v", err)
+	}
+}
+
+func TestNewSealerKey(t *testing.T) {
+	for _, key := range []string{"", "not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := NewSealer(key); err == nil {
+			t.Errorf("NewSealer(%q) accepted the key", key)
+		}
+	}
+}
+
+func TestDeadlinePassed(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for deadline, want := range map[string]bool{
+		"":                          false,
+		"2026-03-01T12:00:01Z":      false,
+		"2026-03-01T12:00:00Z":      true,
+		"2026-03-01T14:00:00+03:00": true,
+		"2026-03-01T16:00:00+03:00": false,
+	} {
+		if got := deadlinePassed(deadline, now); got != want {
+			t.Errorf("deadlinePassed(%q) = %v, want %v", deadline, got, want)
+		}
+	}
+	if got := formatOptionalTime(parseOptionalTime("2026-03-01T16:00:00+03:00")); got != "2026-03-01T13:00:00Z" {
+		t.Errorf("deadline formatted as %q", got)
+	}
+}
+
+func TestCreateTenderRequestDeadline(t *testing.T) {
+	base := CreateTenderRequest{
+		Name:            "Поставка",
+		Description:     "Поставка оборудования",
+		ServiceType:     DELIVERY,
+		OrganizationId:  uuid.NewString(),
+		CreatorUsername: "user1",
+	}
+	for _, tc := range []struct {
+		name     string
+		sealed   bool
+		deadline string
+		wantErr  bool
+	}{
+		{"open tender", false, "", false},
+		{"open tender with a deadline", false, "2030-01-01T00:00:00Z", false},
+		{"sealed tender", true, "2030-01-01T00:00:00+03:00", false},
+		{"sealed tender without a deadline", true, "", true},
+		{"malformed deadline", false, "2030-01-01", true},
+	} {
+		req := base
+		req.Sealed, req.SubmissionDeadline = tc.sealed, tc.deadline
+		err := AssertCreateTenderRequestConstraints(req)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v", tc.name, err)
+		}
+	}
+}
+
+func TestMaskSealedBid(t *testing.T) {
+	price := money("100", "RUB")
+	bid := Bid{Id: uuid.NewString(), Name: "n", Description: "d", Price: &price, Status: PUBLISHED_BID}
+	maskSealedBid(&bid)
+	if bid.Name != "" || bid.Description != "" || bid.Price != nil || !bid.Sealed || bid.Status != PUBLISHED_BID {
+		t.Errorf("masked bid %+v", bid)
+	}
+}
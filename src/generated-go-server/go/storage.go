@@ -26,12 +26,6 @@ type PgxPool interface {
 	Ping(ctx context.Context) error
 }
 
-const (
-	defaultMaxPoolSize  = 1
-	defaultConnAttempts = 10
-	defaultConnTimeout  = time.Second
-)
-
 type Postgres struct {
 	maxPoolSize  int
 	connAttempts int
@@ -43,19 +37,19 @@ type Postgres struct {
 	Log *slog.Logger
 }
 
-func NewStorage(dbUrl string, log *slog.Logger) (*Postgres, error) {
+func NewStorage(cfg PostgresConfig, log *slog.Logger) (*Postgres, error) {
 	const op = "postgres.New"
 
 	pg := &Postgres{
-		maxPoolSize:  defaultMaxPoolSize,
-		connAttempts: defaultConnAttempts,
-		connTimeout:  defaultConnTimeout,
+		maxPoolSize:  cfg.MaxPoolSize,
+		connAttempts: cfg.ConnAttempts,
+		connTimeout:  cfg.ConnTimeout,
 		Log:          log,
 	}
 
 	pg.Builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
-	poolConfig, err := pgxpool.ParseConfig(dbUrl)
+	poolConfig, err := pgxpool.ParseConfig(cfg.ConnString())
 	if err != nil {
 		log.Error("Ошибка при парсинге строки подключения", "error", err)
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// supplierColumns are the columns read by scanSupplier from organization_suppliers aliased e.
+const supplierColumns = `e.id, e.organization_id, e.supplier_organization_id,
+	COALESCE((SELECT username FROM employee WHERE id = e.supplier_user_id), ''), e.status, e.reason, e.expires_at,
+	e.expires_at IS NULL OR e.expires_at > CURRENT_TIMESTAMP,
+	COALESCE((SELECT username FROM employee WHERE id = e.updated_by), ''), e.updated_at`
+
+func scanSupplier(row pgx.Row) (SupplierEntry, error) {
+	var entry SupplierEntry
+	var id, organizationId uuid.UUID
+	var supplierOrganizationId *uuid.UUID
+	var expiresAt *time.Time
+	var updatedAt time.Time
+
+	err := row.Scan(&id, &organizationId, &supplierOrganizationId, &entry.SupplierUsername, &entry.Status,
+		&entry.Reason, &expiresAt, &entry.Active, &entry.UpdatedBy, &updatedAt)
+	if err != nil {
+		return SupplierEntry{}, err
+	}
+
+	entry.Id = id.String()
+	entry.OrganizationId = organizationId.String()
+	if supplierOrganizationId != nil {
+		entry.SupplierOrganizationId = supplierOrganizationId.String()
+	}
+	entry.ExpiresAt = formatOptionalTime(expiresAt)
+	entry.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	return entry, nil
+}
+
+// supplierMatch is an entry of the supplier list that applies to a bid author.
+type supplierMatch struct {
+	Status    SupplierStatus
+	Reason    string
+	ExpiresAt *time.Time
+	Active    bool
+}
+
+// supplierVerdict decides whether an author with the matching entries may bid on the tenders
+// of the organization. An active blacklist entry always wins; with requireQualification the
+// author also needs an active qualification.
+func supplierVerdict(matches []supplierMatch, requireQualification bool) *APIError {
+	var qualified bool
+	var lapsed *supplierMatch
+	for i, m := range matches {
+		switch {
+		case m.Status == SUPPLIER_BLACKLISTED && m.Active:
+			if m.ExpiresAt != nil {
+				return NewAPIError(ErrCodeSupplierBlacklisted, nil).WithDetail(MsgSupplierBlacklistedUntil, m.Reason, formatOptionalTime(m.ExpiresAt))
+			}
+			return NewAPIError(ErrCodeSupplierBlacklisted, nil).WithDetail(MsgSupplierBlacklisted, m.Reason)
+		case m.Status == SUPPLIER_QUALIFIED && m.Active:
+			qualified = true
+		case m.Status == SUPPLIER_QUALIFIED:
+			lapsed = &matches[i]
+		}
+	}
+	if !requireQualification || qualified {
+		return nil
+	}
+	if lapsed != nil {
+		return NewAPIError(ErrCodeSupplierNotQualified, nil).WithDetail(MsgSupplierQualificationExpired, formatOptionalTime(lapsed.ExpiresAt))
+	}
+	return NewAPIError(ErrCodeSupplierNotQualified, nil).WithDetail(MsgSupplierNotQualified)
+}
+
+// checkSupplier applies the supplier list of the organization to a bid author. An employee
+// is matched by their own entries and by those of the organizations they are responsible for.
+func (s *DefaultAPIService) checkSupplier(ctx context.Context, organizationId uuid.UUID, authorType BidAuthorType, authorId uuid.UUID) *APIError {
+	var requireQualification bool
+	err := s.pg.Pool.QueryRow(ctx, `
+	SELECT require_qualification FROM organization_supplier_policy WHERE organization_id = $1`,
+		organizationId).Scan(&requireQualification)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return NewAPIError(ErrCodeInternal, err)
+	}
+
+	subject := `e.supplier_organization_id = $2`
+	if authorType == USER {
+		subject = `(e.supplier_user_id = $2 OR e.supplier_organization_id IN (
+			SELECT organization_id FROM organization_responsible WHERE user_id = $2))`
+	}
+	rows, err := s.pg.Pool.Query(ctx, `
+	SELECT e.status, e.reason, e.expires_at, e.expires_at IS NULL OR e.expires_at > CURRENT_TIMESTAMP
+	FROM organization_suppliers e
+	WHERE e.organization_id = $1 AND `+subject, organizationId, authorId)
+	if err != nil {
+		return NewAPIError(ErrCodeInternal, err)
+	}
+	matches, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (supplierMatch, error) {
+		var m supplierMatch
+		err := row.Scan(&m.Status, &m.Reason, &m.ExpiresAt, &m.Active)
+		return m, err
+	})
+	if err != nil {
+		return NewAPIError(ErrCodeInternal, err)
+	}
+	return supplierVerdict(matches, requireQualification)
+}
+
+// organizationForWrite checks that the organization exists and the user is responsible for it.
+func (s *DefaultAPIService) organizationForWrite(ctx context.Context, organizationId string, username string) (*User, uuid.UUID, error) {
+	user, err := s.loadUser(ctx, username)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	orgIdUUID, err := s.ConvertIntoUUID(organizationId)
+	if err != nil {
+		return nil, uuid.Nil, NewAPIError(ErrCodeInvalidID, err)
+	}
+	if _, err := s.getOrganizationById(ctx, orgIdUUID); err != nil {
+		if errors.Is(err, ErrNoOrganization) {
+			return nil, uuid.Nil, NewAPIError(ErrCodeOrganizationNotFound, err)
+		}
+		return nil, uuid.Nil, NewAPIError(ErrCodeInternal, err)
+	}
+	if err := s.userBelongsToOrganization(ctx, user.Username, orgIdUUID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, uuid.Nil, NewAPIError(ErrCodeForbiddenNotResponsible, err).WithDetail(MsgNotOrganizationResponsible)
+		}
+		return nil, uuid.Nil, NewAPIError(ErrCodeInternal, err)
+	}
+	return user, orgIdUUID, nil
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSupplierVerdict(t *testing.T) {
+	until := schedulerEpoch.Add(24 * time.Hour)
+	blacklisted := supplierMatch{Status: SUPPLIER_BLACKLISTED, Reason: "срыв поставки", Active: true}
+	blacklistedUntil := supplierMatch{Status: SUPPLIER_BLACKLISTED, Reason: "срыв поставки", ExpiresAt: &until, Active: true}
+	blacklistLapsed := supplierMatch{Status: SUPPLIER_BLACKLISTED, Reason: "срыв поставки", ExpiresAt: &schedulerEpoch}
+	qualified := supplierMatch{Status: SUPPLIER_QUALIFIED, Active: true}
+	qualificationLapsed := supplierMatch{Status: SUPPLIER_QUALIFIED, ExpiresAt: &schedulerEpoch}
+
+	for _, tc := range []struct {
+		name     string
+		matches  []supplierMatch
+		required bool
+		code     ErrorCode
+		detail   MessageKey
+	}{
+		{"no entries", nil, false, "", ""},
+		{"blacklisted", []supplierMatch{blacklisted}, false, ErrCodeSupplierBlacklisted, MsgSupplierBlacklisted},
+		{"blacklisted until", []supplierMatch{blacklistedUntil}, false, ErrCodeSupplierBlacklisted, MsgSupplierBlacklistedUntil},
+		{"blacklist expired", []supplierMatch{blacklistLapsed}, false, "", ""},
+		{"blacklist beats qualification", []supplierMatch{qualified, blacklisted}, true, ErrCodeSupplierBlacklisted, MsgSupplierBlacklisted},
+		{"qualification required", nil, true, ErrCodeSupplierNotQualified, MsgSupplierNotQualified},
+		{"qualified", []supplierMatch{qualified}, true, "", ""},
+		{"qualification expired", []supplierMatch{qualificationLapsed}, true, ErrCodeSupplierNotQualified, MsgSupplierQualificationExpired},
+		{"qualification expired, not required", []supplierMatch{qualificationLapsed}, false, "", ""},
+	} {
+		err := supplierVerdict(tc.matches, tc.required)
+		if tc.code == "" {
+			if err != nil {
+				t.Errorf("%s: got %v, want allowed", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || err.Code != tc.code || err.Detail == nil || err.Detail.Key != tc.detail {
+			t.Errorf("%s: got %v, want %s with %s", tc.name, err, tc.code, tc.detail)
+		}
+	}
+
+	if got := supplierVerdict([]supplierMatch{blacklistedUntil}, false).Detail.Localize(LocaleEN); !strings.Contains(got, "until 2026-03-02T12:00:00Z") || !strings.HasSuffix(got, ": срыв поставки") {
+		t.Errorf("blacklist detail = %q", got)
+	}
+}
+
+func TestSetSupplierRequestValidation(t *testing.T) {
+	router := NewRouter(NewSupplierAPIController(nil))
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{"supplierUsername":"user2"}`, http.StatusUnprocessableEntity},
+		{`{"status":"Qualified"}`, http.StatusBadRequest},
+		{`{"supplierUsername":"user2","supplierOrganizationId":"` + importOrgID + `","status":"Qualified"}`, http.StatusBadRequest},
+		{`{"supplierOrganizationId":"org","status":"Qualified"}`, http.StatusBadRequest},
+		{`{"supplierUsername":"user2","status":"Trusted"}`, http.StatusBadRequest},
+		{`{"supplierUsername":"user2","status":"Blacklisted"}`, http.StatusBadRequest},
+		{`{"supplierUsername":"user2","status":"Qualified","expiresAt":"tomorrow"}`, http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/organizations/"+importOrgID+"/suppliers?username=user1", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: got %d %s, want %d", tc.body, rec.Code, rec.Body.String(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxImportRows limits the size of one import so that it fits in a single transaction.
+const maxImportRows = 1000
+
+// Content types accepted by the tender import.
+const (
+	importContentTypeCSV    = "text/csv"
+	importContentTypeNDJSON = "application/x-ndjson"
+)
+
+// tenderImportColumns maps the CSV header to the fields of CreateTenderRequest. The budget
+// is split into two columns; a row with both of them empty has no budget.
+var tenderImportColumns = map[string]func(*CreateTenderRequest, string) error{
+	"name":            func(r *CreateTenderRequest, v string) error { r.Name = v; return nil },
+	"description":     func(r *CreateTenderRequest, v string) error { r.Description = v; return nil },
+	"serviceType":     func(r *CreateTenderRequest, v string) error { r.ServiceType = TenderServiceType(v); return nil },
+	"organizationId":  func(r *CreateTenderRequest, v string) error { r.OrganizationId = v; return nil },
+	"creatorUsername": func(r *CreateTenderRequest, v string) error { r.CreatorUsername = v; return nil },
+	"budgetAmount": func(r *CreateTenderRequest, v string) error {
+		if v == "" {
+			return nil
+		}
+		amount, err := decimal.NewFromString(v)
+		if err != nil {
+			return err
+		}
+		importBudget(r).Amount = amount
+		return nil
+	},
+	"budgetCurrency": func(r *CreateTenderRequest, v string) error {
+		if v != "" {
+			importBudget(r).Currency = v
+		}
+		return nil
+	},
+	"sealed": func(r *CreateTenderRequest, v string) error {
+		if v == "" {
+			return nil
+		}
+		sealed, err := strconv.ParseBool(v)
+		r.Sealed = sealed
+		return err
+	},
+	"private": func(r *CreateTenderRequest, v string) error {
+		if v == "" {
+			return nil
+		}
+		private, err := strconv.ParseBool(v)
+		r.Private = private
+		return err
+	},
+	"conflictPolicy":     func(r *CreateTenderRequest, v string) error { r.ConflictPolicy = ConflictPolicy(v); return nil },
+	"submissionDeadline": func(r *CreateTenderRequest, v string) error { r.SubmissionDeadline = v; return nil },
+}
+
+func importBudget(r *CreateTenderRequest) *Money {
+	if r.Budget == nil {
+		r.Budget = &Money{}
+	}
+	return r.Budget
+}
+
+// ParseTenderImportCSV reads CreateTenderRequest rows from CSV with a header of JSON field
+// names in any order. Rows that cannot be parsed are returned with Err set; errors that
+// make the whole file unreadable are returned as a ParsingError.
+func ParseTenderImportCSV(r io.Reader) ([]TenderImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, &ParsingError{Err: NewLocalizedError(MsgImportNoRows)}
+	}
+	if err != nil {
+		return nil, &ParsingError{Err: NewLocalizedError(MsgImportMalformedRow, err.Error())}
+	}
+
+	setters := make([]func(*CreateTenderRequest, string) error, len(header))
+	for i, column := range header {
+		// Spreadsheet applications prefix UTF-8 files with a byte order mark.
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		set, ok := tenderImportColumns[column]
+		if !ok {
+			return nil, &ParsingError{Err: NewLocalizedError(MsgImportUnknownColumn, column)}
+		}
+		setters[i] = set
+	}
+
+	var rows []TenderImportRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &ParsingError{Err: NewLocalizedError(MsgImportMalformedRow, err.Error())}
+		}
+
+		line, _ := cr.FieldPos(0)
+		row := TenderImportRow{Line: line}
+		if len(record) != len(header) {
+			row.Err = csv.ErrFieldCount
+		} else {
+			for i, value := range record {
+				if err := setters[i](&row.Request, strings.TrimSpace(value)); err != nil {
+					row.Err = err
+					break
+				}
+			}
+		}
+		rows = append(rows, row)
+
+		if len(rows) > maxImportRows {
+			return nil, &ParsingError{Err: NewLocalizedError(MsgImportTooManyRows, maxImportRows)}
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, &ParsingError{Err: NewLocalizedError(MsgImportNoRows)}
+	}
+	return rows, nil
+}
+
+// ParseTenderImportNDJSON reads one CreateTenderRequest object per line. Blank lines are skipped.
+func ParseTenderImportNDJSON(r io.Reader) ([]TenderImportRow, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []TenderImportRow
+	for line := 1; sc.Scan(); line++ {
+		data := bytes.TrimSpace(sc.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := TenderImportRow{Line: line}
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.DisallowUnknownFields()
+		if err := d.Decode(&row.Request); err != nil {
+			row.Err = err
+		}
+		rows = append(rows, row)
+
+		if len(rows) > maxImportRows {
+			return nil, &ParsingError{Err: NewLocalizedError(MsgImportTooManyRows, maxImportRows)}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, &ParsingError{Err: NewLocalizedError(MsgImportMalformedRow, err.Error())}
+	}
+
+	if len(rows) == 0 {
+		return nil, &ParsingError{Err: NewLocalizedError(MsgImportNoRows)}
+	}
+	return rows, nil
+}
@@ -0,0 +1,173 @@
+package openapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const importOrgID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestParseTenderImportCSV(t *testing.T) {
+	data := "\ufeffcreatorUsername,name,description,serviceType,organizationId\n" +
+		"user1,Доставка,\"Москва, Казань\",Delivery," + importOrgID + "\n" +
+		"user1,Сборка\n"
+
+	rows, err := ParseTenderImportCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	want := CreateTenderRequest{
+		Name: "Доставка", Description: "Москва, Казань", ServiceType: DELIVERY,
+		OrganizationId: importOrgID, CreatorUsername: "user1",
+	}
+	if rows[0].Line != 2 || rows[0].Err != nil || rows[0].Request != want {
+		t.Errorf("row 1 = %+v", rows[0])
+	}
+	if rows[1].Line != 3 || !errors.Is(rows[1].Err, csv.ErrFieldCount) {
+		t.Errorf("row 2 = %+v, want a field count error", rows[1])
+	}
+}
+
+func TestParseTenderImportErrors(t *testing.T) {
+	tooMany := "name\n" + strings.Repeat("x\n", maxImportRows+1)
+
+	for name, parse := range map[string]func() ([]TenderImportRow, error){
+		"csv unknown column": func() ([]TenderImportRow, error) {
+			return ParseTenderImportCSV(strings.NewReader("name,deadline\nx,1\n"))
+		},
+		"csv no rows": func() ([]TenderImportRow, error) {
+			return ParseTenderImportCSV(strings.NewReader("name\n"))
+		},
+		"csv too many rows": func() ([]TenderImportRow, error) {
+			return ParseTenderImportCSV(strings.NewReader(tooMany))
+		},
+		"ndjson no rows": func() ([]TenderImportRow, error) {
+			return ParseTenderImportNDJSON(strings.NewReader("\n\n"))
+		},
+	} {
+		var parsingErr *ParsingError
+		if _, err := parse(); !errors.As(err, &parsingErr) {
+			t.Errorf("%s: err = %v, want a ParsingError", name, err)
+		}
+	}
+}
+
+func TestParseTenderImportNDJSON(t *testing.T) {
+	data := `{"name":"Доставка","description":"d","serviceType":"Delivery","organizationId":"` + importOrgID + `","creatorUsername":"user1"}` + "\n" +
+		"\n" +
+		`{"name":"x","deadline":1}` + "\n" +
+		`{"name":` + "\n"
+
+	rows, err := ParseTenderImportNDJSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+	if rows[0].Line != 1 || rows[0].Err != nil || rows[0].Request.Name != "Доставка" {
+		t.Errorf("row 1 = %+v", rows[0])
+	}
+	if rows[1].Line != 3 || rows[1].Err == nil {
+		t.Errorf("row 3 = %+v, want an unknown field error", rows[1])
+	}
+	if rows[2].Line != 4 || rows[2].Err == nil {
+		t.Errorf("row 4 = %+v, want a syntax error", rows[2])
+	}
+}
+
+func TestValidateTenderImportRows(t *testing.T) {
+	valid := CreateTenderRequest{
+		Name: "Доставка", Description: "d", ServiceType: DELIVERY,
+		OrganizationId: importOrgID, CreatorUsername: "user1",
+	}
+	badType := valid
+	badType.ServiceType = "Cleaning"
+	noName := valid
+	noName.Name = ""
+	sealed := valid
+	sealed.Sealed = true
+	sealed.SubmissionDeadline = time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	rows := []TenderImportRow{
+		{Line: 2, Request: valid},
+		{Line: 3, Request: badType},
+		{Line: 4, Request: noName},
+		{Line: 5, Err: csv.ErrFieldCount},
+		{Line: 6, Request: sealed},
+	}
+	if violations := validateTenderImportRows(LocaleEN, rows[4:], true); len(violations) != 0 {
+		t.Errorf("a sealed row with sealing enabled: %+v", violations)
+	}
+	violations := validateTenderImportRows(LocaleEN, rows, false)
+
+	want := []Violation{
+		{In: "body", Line: 3, Field: "serviceType"},
+		{In: "body", Line: 4, Field: "name"},
+		{In: "body", Line: 5},
+		{In: "body", Line: 6, Field: "sealed"},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("got %+v, want %d violations", violations, len(want))
+	}
+	for i, v := range violations {
+		if v.In != want[i].In || v.Line != want[i].Line || v.Field != want[i].Field || v.Message == "" {
+			t.Errorf("violation %d = %+v, want %+v with a message", i, v, want[i])
+		}
+	}
+}
+
+func TestImportTendersContentType(t *testing.T) {
+	router := NewRouter(NewImportAPIController(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/tenders/import", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "Content-Type") {
+		t.Errorf("got %d %s, want 400 for the content type", rec.Code, rec.Body.String())
+	}
+}
+
+func TestImportTenderAccessSettings(t *testing.T) {
+	csvData := "name,description,serviceType,organizationId,creatorUsername,private,conflictPolicy\n" +
+		"Доставка,d,Delivery," + importOrgID + ",user1,true,Block\n"
+	ndjsonData := `{"name":"Доставка","description":"d","serviceType":"Delivery","organizationId":"` + importOrgID + `","creatorUsername":"user1","private":true,"conflictPolicy":"Block"}`
+
+	service := NewImportAPIService(&Postgres{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	for name, parse := range map[string]func() ([]TenderImportRow, error){
+		"csv":    func() ([]TenderImportRow, error) { return ParseTenderImportCSV(strings.NewReader(csvData)) },
+		"ndjson": func() ([]TenderImportRow, error) { return ParseTenderImportNDJSON(strings.NewReader(ndjsonData)) },
+	} {
+		rows, err := parse()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if violations := validateTenderImportRows(LocaleEN, rows, false); len(violations) != 0 {
+			t.Fatalf("%s: %+v", name, violations)
+		}
+
+		tenders, tenderRows, _ := service.tenderImportRecords(rows, time.Now())
+		copied := map[string]any{}
+		for i, column := range tenderCopyColumns {
+			copied[column] = tenderRows[0][i]
+		}
+		if !tenders[0].Private || copied["private"] != true {
+			t.Errorf("%s: tender %+v copied as %v, want it private", name, tenders[0], copied)
+		}
+		if tenders[0].ConflictPolicy != CONFLICT_POLICY_BLOCK || copied["conflict_policy"] != string(CONFLICT_POLICY_BLOCK) {
+			t.Errorf("%s: tender %+v copied as %v, want the Block policy", name, tenders[0], copied)
+		}
+	}
+}
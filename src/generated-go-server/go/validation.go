@@ -0,0 +1,307 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/google/uuid"
+)
+
+func init() {
+	// The spec marks identifiers with format: uuid; accept exactly what the services can parse.
+	openapi3.DefineStringFormatCallback("uuid", func(s string) error {
+		_, err := uuid.Parse(s)
+		return err
+	})
+	// Import files are not decoded against a schema; the import service parses them line by line.
+	openapi3filter.RegisterBodyDecoder("application/x-ndjson", openapi3filter.FileBodyDecoder)
+}
+
+// SpecValidator checks requests and, optionally, responses against the OpenAPI spec.
+type SpecValidator struct {
+	router routers.Router
+	cfg    ValidationConfig
+	log    *slog.Logger
+}
+
+// NewSpecValidator loads and validates the spec. Server URLs are reduced to their paths
+// so that routes match regardless of the host the service is deployed on.
+func NewSpecValidator(spec []byte, cfg ValidationConfig, log *slog.Logger) (*SpecValidator, error) {
+	const op = "NewSpecValidator"
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: load spec: %w", op, err)
+	}
+	if err := doc.Validate(loader.Context, openapi3.DisableExamplesValidation()); err != nil {
+		return nil, fmt.Errorf("%s: invalid spec: %w", op, err)
+	}
+
+	for _, server := range doc.Servers {
+		if u, err := url.Parse(server.URL); err == nil {
+			server.URL = u.Path
+		}
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: build router: %w", op, err)
+	}
+
+	return &SpecValidator{
+		router: router,
+		cfg:    cfg,
+		log:    log.With(slog.String("op", "SpecValidator")),
+	}, nil
+}
+
+// Middleware rejects requests that violate the spec with a 400 problem listing every
+// violation. Routes absent from the spec are passed through unchecked. When response
+// validation is enabled, successful responses are buffered and violations are logged.
+func (v *SpecValidator) Middleware(next http.Handler) http.Handler {
+	return RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:          true,
+				SkipSettingDefaults: true,
+				AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+
+		if v.cfg.Requests {
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				p := newProblem(r, ErrCodeValidationFailed, "")
+				p.Violations = requestViolations(err, LocaleFromContext(r.Context()))
+				v.log.Info("request violates the spec",
+					slog.String("path", r.URL.Path),
+					slog.String("request_id", p.RequestID),
+					slog.Any("violations", p.Violations))
+				_ = WriteProblem(w, p)
+				return
+			}
+		}
+
+		if !v.cfg.Responses || streamedResponse(route) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		v.validateResponse(r.Context(), input, rec)
+		rec.flush()
+	}))
+}
+
+func (v *SpecValidator) validateResponse(ctx context.Context, input *openapi3filter.RequestValidationInput, rec *responseRecorder) {
+	// Errors are written by DefaultErrorHandler in one format for all routes.
+	if rec.status < 200 || rec.status >= 300 {
+		return
+	}
+
+	err := openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.status,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+		Options:                &openapi3filter.Options{MultiError: true},
+	})
+	if err != nil {
+		v.log.Error("response violates the spec",
+			slog.String("method", input.Request.Method),
+			slog.String("path", input.Request.URL.Path),
+			slog.String("request_id", RequestIDFromContext(ctx)),
+			slog.Int("status", rec.status),
+			slog.Any("error", err))
+	}
+}
+
+// streamedResponse reports whether the route answers with a file download or an event
+// stream. Both are streamed to the client, so they are never buffered for validation.
+func streamedResponse(route *routers.Route) bool {
+	resp := route.Operation.Responses.Status(http.StatusOK)
+	if resp == nil || resp.Value == nil {
+		return false
+	}
+	for contentType, media := range resp.Value.Content {
+		if contentType == "text/event-stream" {
+			return true
+		}
+		if media.Schema != nil && media.Schema.Value != nil && media.Schema.Value.Format == "binary" {
+			return true
+		}
+	}
+	return false
+}
+
+// requestViolations flattens the errors of openapi3filter into one violation per field,
+// with messages from the catalog in locale.
+func requestViolations(err error, locale Locale) []Violation {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var violations []Violation
+		for _, inner := range e {
+			violations = append(violations, requestViolations(inner, locale)...)
+		}
+		return violations
+	case *openapi3filter.RequestError:
+		return requestErrorViolations(e, locale)
+	default:
+		return []Violation{{Message: Translate(locale, MsgInvalidValue)}}
+	}
+}
+
+func requestErrorViolations(reqErr *openapi3filter.RequestError, locale Locale) []Violation {
+	in, field := "body", ""
+	if reqErr.Parameter != nil {
+		in, field = reqErr.Parameter.In, reqErr.Parameter.Name
+	}
+
+	schemaErrs := schemaErrors(reqErr.Err)
+	if len(schemaErrs) == 0 {
+		return []Violation{{In: in, Field: field, Message: requestErrorMessage(reqErr, locale)}}
+	}
+
+	violations := make([]Violation, 0, len(schemaErrs))
+	for _, se := range schemaErrs {
+		name := field
+		if pointer := se.JSONPointer(); len(pointer) > 0 {
+			if name != "" {
+				pointer = append([]string{name}, pointer...)
+			}
+			name = strings.Join(pointer, ".")
+		}
+		violations = append(violations, Violation{In: in, Field: name, Message: schemaErrorMessage(se, locale)})
+	}
+	return violations
+}
+
+// requestErrorMessage describes a request error that is not about a schema: a missing or
+// unparsable parameter or body.
+func requestErrorMessage(reqErr *openapi3filter.RequestError, locale Locale) string {
+	if errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired) || errors.Is(reqErr.Err, openapi3filter.ErrInvalidEmptyValue) {
+		return Translate(locale, MsgRequired)
+	}
+	var parseErr *openapi3filter.ParseError
+	if errors.As(reqErr.Err, &parseErr) && reqErr.Parameter != nil && reqErr.Parameter.Schema != nil &&
+		reqErr.Parameter.Schema.Value != nil && reqErr.Parameter.Schema.Value.Type != nil {
+		return Translate(locale, MsgType, strings.Join(reqErr.Parameter.Schema.Value.Type.Slice(), ", "))
+	}
+	return Translate(locale, MsgInvalidValue)
+}
+
+// schemaErrorMessage translates a schema violation by the keyword that failed. Keywords the
+// catalog does not know are reported as an invalid value.
+func schemaErrorMessage(se *openapi3.SchemaError, locale Locale) string {
+	schema := se.Schema
+	if schema == nil {
+		return Translate(locale, MsgInvalidValue)
+	}
+	switch se.SchemaField {
+	case "required":
+		return Translate(locale, MsgRequired)
+	case "type":
+		if schema.Type != nil {
+			return Translate(locale, MsgType, strings.Join(schema.Type.Slice(), ", "))
+		}
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			values = append(values, fmt.Sprintf("'%v'", v))
+		}
+		return Translate(locale, MsgOneOf, strings.Join(values, ", "))
+	case "format":
+		switch schema.Format {
+		case "uuid":
+			return Translate(locale, MsgMustBeUUID)
+		case "date-time":
+			return Translate(locale, MsgRFC3339)
+		}
+		return Translate(locale, MsgFormat, schema.Format)
+	case "pattern":
+		return Translate(locale, MsgPattern, schema.Pattern)
+	case "minLength":
+		return Translate(locale, MsgMinLength, schema.MinLength)
+	case "maxLength":
+		if schema.MaxLength != nil {
+			return Translate(locale, MsgMaxLength, *schema.MaxLength)
+		}
+	case "minimum":
+		if schema.Min != nil {
+			return Translate(locale, MsgMinimum, *schema.Min)
+		}
+	case "maximum":
+		if schema.Max != nil {
+			return Translate(locale, MsgMaximum, *schema.Max)
+		}
+	case "minItems":
+		return Translate(locale, MsgMinItems, schema.MinItems)
+	case "maxItems":
+		if schema.MaxItems != nil {
+			return Translate(locale, MsgMaxItems, *schema.MaxItems)
+		}
+	case "uniqueItems":
+		return Translate(locale, MsgUniqueItems)
+	}
+	return Translate(locale, MsgInvalidValue)
+}
+
+func schemaErrors(err error) []*openapi3.SchemaError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var errs []*openapi3.SchemaError
+		for _, inner := range e {
+			errs = append(errs, schemaErrors(inner)...)
+		}
+		return errs
+	case *openapi3.SchemaError:
+		return []*openapi3.SchemaError{e}
+	default:
+		var se *openapi3.SchemaError
+		if errors.As(err, &se) {
+			return []*openapi3.SchemaError{se}
+		}
+		return nil
+	}
+}
+
+// responseRecorder buffers a response so that it can be validated before it is sent.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) flush() {
+	r.ResponseWriter.WriteHeader(r.status)
+	_, _ = r.ResponseWriter.Write(r.body.Bytes())
+}
@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GIT_USER_ID/GIT_REPO_ID/api"
+)
+
+// specRoutes stands in for the controllers: each route answers with a fixed response, and the
+// streamed ones report whether their first write reached the client before they returned.
+type specRoutes struct {
+	tenders  string
+	streamed map[string]bool
+}
+
+func (s *specRoutes) Routes() Routes {
+	stream := func(name, contentType string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("data"))
+			rec, ok := w.(*httptest.ResponseRecorder)
+			s.streamed[name] = ok && rec.Body.Len() > 0
+		}
+	}
+	return Routes{
+		"CreateTender": Route{http.MethodPost, "/api/tenders/new", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}},
+		"GetUserTenders": Route{http.MethodGet, "/api/tenders/my", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(s.tenders))
+		}},
+		"FollowTenderAuction":      Route{http.MethodGet, "/api/tenders/{tenderId}/auction/events", stream("sse", "text/event-stream")},
+		"DownloadTenderAttachment": Route{http.MethodGet, "/api/tenders/{tenderId}/attachments/{attachmentId}", stream("binary", "application/octet-stream")},
+	}
+}
+
+func newValidatedRouter(t *testing.T, routes *specRoutes, logs *bytes.Buffer) http.Handler {
+	t.Helper()
+	validator, err := NewSpecValidator(api.OpenAPI, ValidationConfig{Requests: true, Responses: true}, slog.New(slog.NewTextHandler(logs, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := NewRouter(routes)
+	router.Use(validator.Middleware)
+	return router
+}
+
+func TestSpecValidatorRejectsRequests(t *testing.T) {
+	const tenderID = "550e8400-e29b-41d4-a716-446655440000"
+	router := newValidatedRouter(t, &specRoutes{}, &bytes.Buffer{})
+
+	cases := []struct {
+		name, method, target, body string
+		locale                     Locale
+		want                       []Violation
+	}{
+		{
+			name:   "body",
+			method: http.MethodPost,
+			target: "/api/tenders/new",
+			body:   `{"name":"` + strings.Repeat("n", 101) + `","description":"d","serviceType":"Repair","organizationId":"org","creatorUsername":"user1"}`,
+			locale: LocaleEN,
+			want: []Violation{
+				{In: "body", Field: "name", Message: "exceeds the maximum length of 100 characters"},
+				{In: "body", Field: "serviceType", Message: "must be one of 'Construction', 'Delivery', 'Manufacture'"},
+				{In: "body", Field: "organizationId", Message: "must be a valid UUID"},
+			},
+		},
+		{
+			name:   "missing body",
+			method: http.MethodPost,
+			target: "/api/tenders/new",
+			locale: LocaleEN,
+			want:   []Violation{{In: "body", Message: "is required"}},
+		},
+		{
+			name:   "parameters in russian",
+			method: http.MethodGet,
+			target: "/api/tenders/" + tenderID + "/auction/events",
+			locale: LocaleRU,
+			want:   []Violation{{In: "query", Field: "username", Message: "обязательное поле"}},
+		},
+		{
+			name:   "unparsable parameter",
+			method: http.MethodGet,
+			target: "/api/tenders/my?username=user1&limit=many",
+			locale: LocaleEN,
+			want:   []Violation{{In: "query", Field: "limit", Message: "must be of type integer"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req := httptest.NewRequest(tc.method, tc.target, body)
+			if body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			req = req.WithContext(ContextWithLocale(req.Context(), tc.locale))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != problemContentType {
+				t.Fatalf("got %d %s %s", rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+			}
+			var p Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatal(err)
+			}
+			if p.Code != ErrCodeValidationFailed {
+				t.Errorf("code = %s", p.Code)
+			}
+			for _, want := range tc.want {
+				found := false
+				for _, got := range p.Violations {
+					found = found || got == want
+				}
+				if !found {
+					t.Errorf("violation %+v not in %+v", want, p.Violations)
+				}
+			}
+		})
+	}
+}
+
+func TestSpecValidatorChecksResponses(t *testing.T) {
+	cases := []struct {
+		name, tenders string
+		logged        bool
+	}{
+		{"valid", `[]`, false},
+		{"invalid", `[{"name":1}]`, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			router := newValidatedRouter(t, &specRoutes{tenders: tc.tenders}, &logs)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/tenders/my?username=user1", nil))
+
+			// A violating response is only logged; the client still gets it.
+			if rec.Code != http.StatusOK || rec.Body.String() != tc.tenders {
+				t.Errorf("got %d %s", rec.Code, rec.Body.String())
+			}
+			if logged := strings.Contains(logs.String(), "response violates the spec"); logged != tc.logged {
+				t.Errorf("logged = %v, want %v: %s", logged, tc.logged, logs.String())
+			}
+		})
+	}
+}
+
+func TestSpecValidatorStreamsResponses(t *testing.T) {
+	const tenderID = "550e8400-e29b-41d4-a716-446655440000"
+	routes := &specRoutes{streamed: map[string]bool{}}
+	router := newValidatedRouter(t, routes, &bytes.Buffer{})
+
+	targets := map[string]string{
+		"sse":    "/api/tenders/" + tenderID + "/auction/events?username=user1",
+		"binary": "/api/tenders/" + tenderID + "/attachments/" + tenderID + "?username=user1",
+	}
+	for name, target := range targets {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusOK || rec.Body.String() != "data" {
+			t.Errorf("%s: got %d %s", name, rec.Code, rec.Body.String())
+		}
+		if !routes.streamed[name] {
+			t.Errorf("%s: the response was buffered", name)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WorkerFunc is one iteration of a periodic background job.
+type WorkerFunc func(ctx context.Context) error
+
+// WorkerStatus is a snapshot of a background worker for health reporting.
+type WorkerStatus struct {
+	Name      string     `json:"name"`
+	Running   bool       `json:"running"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+	LastError string     `json:"lastError,omitempty"`
+	Failures  int        `json:"consecutiveFailures"`
+}
+
+// Workers runs periodic background jobs and keeps track of their state.
+type Workers struct {
+	log *slog.Logger
+
+	mu     sync.Mutex
+	states map[string]*WorkerStatus
+	wg     sync.WaitGroup
+}
+
+func NewWorkers(log *slog.Logger) *Workers {
+	return &Workers{
+		log:    log,
+		states: make(map[string]*WorkerStatus),
+	}
+}
+
+// Start runs fn every interval until ctx is cancelled. Errors are logged and recorded
+// but do not stop the worker.
+func (w *Workers) Start(ctx context.Context, name string, interval time.Duration, fn WorkerFunc) {
+	log := w.log.With(slog.String("worker", name))
+
+	w.mu.Lock()
+	w.states[name] = &WorkerStatus{Name: name, Running: true}
+	w.mu.Unlock()
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer w.update(name, func(s *WorkerStatus) { s.Running = false })
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			err := fn(ctx)
+			now := time.Now()
+			w.update(name, func(s *WorkerStatus) {
+				s.LastRunAt = &now
+				if err != nil {
+					s.LastError = err.Error()
+					s.Failures++
+				} else {
+					s.LastError = ""
+					s.Failures = 0
+				}
+			})
+			if err != nil && ctx.Err() == nil {
+				log.Error("worker iteration failed", slog.Any("error", err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Wait blocks until every worker has returned after its context was cancelled.
+func (w *Workers) Wait() {
+	w.wg.Wait()
+}
+
+// Status returns the state of all workers ordered by name.
+func (w *Workers) Status() []WorkerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	statuses := make([]WorkerStatus, 0, len(w.states))
+	for _, s := range w.states {
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+func (w *Workers) update(name string, fn func(*WorkerStatus)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if s, ok := w.states[name]; ok {
+		fn(s)
+	}
+}
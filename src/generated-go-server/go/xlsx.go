@@ -0,0 +1,159 @@
+package openapi
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// The fixed parts of a workbook with a single worksheet. Strings are stored inline in the
+// cells, so the worksheet can be written row by row without a shared strings table.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	// Style 1 is the bold font of the header row.
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="1"><fill><patternFill patternType="none"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="{{sheet}}" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxWriter writes an Office Open XML workbook with one worksheet. The first row is
+// written in bold as the header.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	name  string
+	rows  int
+	err   error
+}
+
+func newXLSXWriter(w io.Writer, sheet string) *xlsxWriter {
+	return &xlsxWriter{zip: zip.NewWriter(w), name: sheet}
+}
+
+func (x *xlsxWriter) start() error {
+	var name strings.Builder
+	_ = xml.EscapeText(&name, []byte(x.name))
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", strings.Replace(xlsxWorkbook, "{{sheet}}", name.String(), 1)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		f, err := x.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	// The worksheet is the last part, so it stays open while the rows are written.
+	f, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.sheet = bufio.NewWriter(f)
+	_, err = x.sheet.WriteString(xlsxSheetStart)
+	return err
+}
+
+func (x *xlsxWriter) WriteRow(cells []any) error {
+	if x.err != nil {
+		return x.err
+	}
+	if x.sheet == nil {
+		if x.err = x.start(); x.err != nil {
+			return x.err
+		}
+	}
+
+	style := ""
+	if x.rows == 0 {
+		style = ` s="1"`
+	}
+	x.rows++
+
+	w := x.sheet
+	w.WriteString("<row>")
+	for _, cell := range cells {
+		switch v := cell.(type) {
+		case int, int32, int64:
+			w.WriteString("<c" + style + "><v>" + formatCell(v) + "</v></c>")
+		default:
+			w.WriteString(`<c` + style + ` t="inlineStr"><is><t xml:space="preserve">`)
+			_ = xml.EscapeText(w, []byte(formatCell(v)))
+			w.WriteString("</t></is></c>")
+		}
+	}
+	_, x.err = w.WriteString("</row>")
+	return x.err
+}
+
+func (x *xlsxWriter) Flush() error {
+	if x.err != nil || x.sheet == nil {
+		return x.err
+	}
+	if x.err = x.sheet.Flush(); x.err != nil {
+		return x.err
+	}
+	x.err = x.zip.Flush()
+	return x.err
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.err != nil {
+		return x.err
+	}
+	if x.sheet == nil {
+		if err := x.start(); err != nil {
+			return err
+		}
+	}
+	if _, err := x.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
@@ -8,52 +8,219 @@
  * API version: 1.0
  */
 
- package main
-
- import (
-	 "log"
-	 "log/slog"
-	 "net/http"
-	 "os"
- 
-	 openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
- )
- 
- const (
-	 envLocal = "local"
-	 envDev   = "dev"
-	 envProd  = "prod"
- )
- 
- func main() {
-	 config := openapi.MustLoad()
-	 log.Printf("Server started on port %s", config.ServerAddress)
-	 loggerSlog := setupLogger("local")
-	 psql, err := openapi.NewStorage(config.PostgresConn, loggerSlog)
-	 if err != nil {
-		 log.Fatal(err)
-	 }
-	 defer psql.Close()
- 
-	 DefaultAPIService := openapi.NewDefaultAPIService(psql, loggerSlog)
-	 DefaultAPIController := openapi.NewDefaultAPIController(DefaultAPIService)
- 
-	 router := openapi.NewRouter(DefaultAPIController)
- 
-	 log.Fatal(http.ListenAndServe(":8080", router))
- }
- 
- func setupLogger(env string) *slog.Logger {
-	 var log *slog.Logger
- 
-	 switch env {
-	 case envLocal:
-		 log = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	 case envDev:
-		 log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	 case envProd:
-		 log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
-	 }
- 
-	 return log
- }
\ No newline at end of file
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/GIT_USER_ID/GIT_REPO_ID/api"
+	openapi "github.com/GIT_USER_ID/GIT_REPO_ID/go"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	configPath := flag.String("config", os.Getenv("CONFIG_PATH"), "path to a YAML or TOML config file")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
+	config := openapi.MustLoad(*configPath)
+
+	if *printConfig {
+		out, err := yaml.Marshal(config.Redacted())
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(string(out))
+		return
+	}
+
+	loggerSlog := setupLogger(config.Log)
+	loggerSlog.Info("Server started", slog.String("address", config.Server.Address))
+
+	psql, err := openapi.NewStorage(config.Postgres, loggerSlog)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer psql.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workers := openapi.NewWorkers(loggerSlog)
+
+	var serviceOpts []openapi.DefaultAPIServiceOption
+	var sealer *openapi.Sealer
+	if config.SealedBids.Key != "" {
+		sealer, err = openapi.NewSealer(config.SealedBids.Key)
+		if err != nil {
+			log.Fatal(err)
+		}
+		serviceOpts = append(serviceOpts, openapi.WithSealer(sealer))
+	}
+
+	DefaultAPIService := openapi.NewDefaultAPIService(psql, loggerSlog, serviceOpts...)
+	DefaultAPIController := openapi.NewDefaultAPIController(DefaultAPIService)
+
+	HealthAPIService := openapi.NewHealthAPIService(psql, workers, loggerSlog)
+	HealthAPIController := openapi.NewHealthAPIController(HealthAPIService)
+
+	ExportAPIService := openapi.NewExportAPIService(psql, loggerSlog)
+	ExportAPIController := openapi.NewExportAPIController(ExportAPIService)
+
+	ImportAPIService := openapi.NewImportAPIService(psql, loggerSlog, serviceOpts...)
+	ImportAPIController := openapi.NewImportAPIController(ImportAPIService)
+
+	blobs, err := openapi.NewLocalBlobStore(config.Attachments.Dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	AttachmentAPIService := openapi.NewAttachmentAPIService(psql, blobs, config.Attachments, loggerSlog)
+	AttachmentAPIController := openapi.NewAttachmentAPIController(AttachmentAPIService)
+
+	LotAPIService := openapi.NewLotAPIService(psql, loggerSlog)
+	LotAPIController := openapi.NewLotAPIController(LotAPIService)
+
+	EvaluationAPIService := openapi.NewEvaluationAPIService(psql, loggerSlog)
+	EvaluationAPIController := openapi.NewEvaluationAPIController(EvaluationAPIService)
+
+	scheduler := openapi.NewScheduler(openapi.SystemClock, loggerSlog)
+	auctionHub := openapi.NewAuctionHub()
+	AuctionAPIService := openapi.NewAuctionAPIService(psql, openapi.SystemClock, scheduler, auctionHub, loggerSlog)
+	AuctionAPIController := openapi.NewAuctionAPIController(AuctionAPIService)
+
+	QuestionAPIService := openapi.NewQuestionAPIService(psql, loggerSlog)
+	QuestionAPIController := openapi.NewQuestionAPIController(QuestionAPIService)
+
+	InvitationAPIService := openapi.NewInvitationAPIService(psql, loggerSlog)
+	InvitationAPIController := openapi.NewInvitationAPIController(InvitationAPIService)
+
+	SupplierAPIService := openapi.NewSupplierAPIService(psql, loggerSlog)
+	SupplierAPIController := openapi.NewSupplierAPIController(SupplierAPIService)
+
+	ConflictAPIService := openapi.NewConflictAPIService(psql, loggerSlog)
+	ConflictAPIController := openapi.NewConflictAPIController(ConflictAPIService)
+
+	ReputationAPIService := openapi.NewReputationAPIService(psql, loggerSlog)
+	ReputationAPIController := openapi.NewReputationAPIController(ReputationAPIService)
+
+	FeedbackAPIService := openapi.NewFeedbackAPIService(psql, loggerSlog)
+	FeedbackAPIController := openapi.NewFeedbackAPIController(FeedbackAPIService)
+
+	NotificationAPIService := openapi.NewNotificationAPIService(psql, loggerSlog)
+	NotificationAPIController := openapi.NewNotificationAPIController(NotificationAPIService)
+
+	DocsAPIController, err := openapi.NewDocsAPIController(api.OpenAPI)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	router := openapi.NewRouter(DefaultAPIController, HealthAPIController, ExportAPIController, ImportAPIController, AttachmentAPIController, LotAPIController, EvaluationAPIController, AuctionAPIController, QuestionAPIController, InvitationAPIController, SupplierAPIController, ConflictAPIController, ReputationAPIController, FeedbackAPIController, NotificationAPIController, DocsAPIController)
+	if config.Validation.Requests || config.Validation.Responses {
+		validator, err := openapi.NewSpecValidator(api.OpenAPI, config.Validation, loggerSlog)
+		if err != nil {
+			log.Fatal(err)
+		}
+		router.Use(validator.Middleware)
+	}
+	// After the validator, so that a request rejected by the spec does not take its key.
+	idempotency := openapi.NewIdempotencyStore(psql, config.Idempotency, loggerSlog)
+	router.Use(idempotency.Middleware)
+	handler := openapi.WithLogger(openapi.Localize(router, openapi.Locale(config.I18n.DefaultLocale)), loggerSlog)
+
+	server := &http.Server{
+		Addr:         config.Server.Address,
+		Handler:      handler,
+		ReadTimeout:  config.Server.ReadTimeout,
+		WriteTimeout: config.Server.WriteTimeout,
+		IdleTimeout:  config.Server.IdleTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// The server is already listening so that readiness reports pending migrations.
+	if config.Features.AutoMigrate {
+		if err := openapi.Migrate(ctx, psql); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if config.Features.InitDatabase {
+		if err := openapi.InitDataBase(ctx, psql); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Without a key no tender can be sealed, but the worker still reports bids that were
+	// sealed under a key that has since been removed.
+	revealer := openapi.NewBidRevealer(psql, sealer, loggerSlog)
+	workers.Start(ctx, "reveal-sealed-bids", config.SealedBids.RevealInterval, revealer.Run)
+
+	// Emails are queued by the dispatcher and sent by their own worker, so a slow mail server
+	// delays neither the API nor the inbox.
+	mailer, err := openapi.NewMailer(config.Mail)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var dispatcherOpts []openapi.NotificationDispatcherOption
+	if mailer != nil {
+		dispatcherOpts = append(dispatcherOpts, openapi.WithEmailDelivery())
+		sender := openapi.NewEmailSender(psql, mailer, config.Mail, loggerSlog)
+		workers.Start(ctx, "send-email", config.Mail.SendInterval, sender.Run)
+	}
+
+	dispatcher := openapi.NewNotificationDispatcher(psql, loggerSlog, dispatcherOpts...)
+	workers.Start(ctx, "dispatch-notifications", config.Notifications.DispatchInterval, dispatcher.Run)
+
+	workers.Start(ctx, "expire-idempotency-keys", config.Idempotency.CleanupInterval, idempotency.Cleanup)
+
+	if err := AuctionAPIService.ScheduleAuctions(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	select {
+	case err := <-serverErr:
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+
+	loggerSlog.Info("Shutting down")
+	HealthAPIService.SetShuttingDown()
+	// Keep serving while readiness fails, so that the load balancer takes the instance out of
+	// rotation before it stops accepting connections.
+	time.Sleep(config.Server.DrainDelay)
+	// Auction streams last as long as their auctions; end them so that shutdown does not wait.
+	auctionHub.Close()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		loggerSlog.Error("Server shutdown failed", slog.Any("error", err))
+	}
+	scheduler.Stop()
+	workers.Wait()
+}
+
+func setupLogger(cfg openapi.LogConfig) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	if cfg.Format == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stdout, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stdout, opts))
+}